@@ -0,0 +1,125 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dummy
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/core/admin"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+type testBoundsController struct {
+	fixedBaseFee   *big.Int
+	floor, ceiling *big.Int
+	err            error
+}
+
+func (*testBoundsController) Start() {}
+
+func (c *testBoundsController) GetFixedBaseFee(*types.Header, admin.StateDB, uint64) (*big.Int, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return new(big.Int).Set(c.fixedBaseFee), nil
+}
+
+func (*testBoundsController) GetPendingBaseFee(*types.Header, admin.StateDB) (*big.Int, uint64) {
+	return nil, 0
+}
+
+func (c *testBoundsController) GetBaseFeeBounds(*types.Header, admin.StateDB) (*big.Int, *big.Int, error) {
+	return c.floor, c.ceiling, c.err
+}
+
+func (*testBoundsController) KycVerified(*types.Header, admin.StateDB, common.Address) bool {
+	return true
+}
+
+func (*testBoundsController) IsBlacklisted(*types.Header, admin.StateDB, common.Address, [4]byte) bool {
+	return false
+}
+
+func TestCalcBaseFeeSunrisePhase1(t *testing.T) {
+	require := require.New(t)
+
+	config := *params.TestSunrisePhase0Config
+	config.SunrisePhase1BlockTimestamp = big.NewInt(20)
+	ctrl := &testBoundsController{
+		fixedBaseFee: big.NewInt(100_000_000_000),
+		floor:        big.NewInt(50_000_000_000),
+		ceiling:      big.NewInt(150_000_000_000),
+	}
+
+	// next builds the child of [parent] at [parent.Time]+[delay] using [gasUsed]
+	next := func(parent *types.Header, delay, gasUsed uint64) *types.Header {
+		timestamp := parent.Time + delay
+		extra, baseFee, err := CalcBaseFee(&config, ctrl, parent, timestamp)
+		require.NoError(err)
+		return &types.Header{
+			Number:         new(big.Int).Add(parent.Number, common.Big1),
+			Time:           timestamp,
+			Extra:          extra,
+			BaseFee:        baseFee,
+			GasUsed:        gasUsed,
+			ExtDataGasUsed: new(big.Int),
+		}
+	}
+
+	// The fixed base fee applies before Sunrise Phase 1
+	header := &types.Header{Number: common.Big1, Time: 10, BaseFee: big.NewInt(1), ExtDataGasUsed: new(big.Int)}
+	header = next(header, 8, 2*params.ApricotPhase5TargetGas)
+	require.Empty(header.Extra)
+	require.Equal(ctrl.fixedBaseFee, header.BaseFee)
+
+	// The first block of Sunrise Phase 1 starts the rollup window from the
+	// fixed base fee, with the gas used by its parent
+	header = next(header, 2, params.ApricotPhase5TargetGas)
+	require.Len(header.Extra, int(params.ApricotPhase3ExtraDataSize))
+	require.Equal(1, header.BaseFee.Cmp(ctrl.fixedBaseFee))
+
+	// Congestion raises the base fee up to the ceiling
+	for i := 0; i < 100; i++ {
+		header = next(header, 2, params.ApricotPhase5TargetGas)
+	}
+	require.Equal(ctrl.ceiling, header.BaseFee)
+
+	// Idle blocks lower the base fee down to the floor
+	for i := 0; i < 100; i++ {
+		header = next(header, 20, 0)
+	}
+	require.Equal(ctrl.floor, header.BaseFee)
+
+	// Without ceiling the base fee is unbounded
+	ctrl.ceiling = nil
+	for i := 0; i < 100; i++ {
+		header = next(header, 2, params.ApricotPhase5TargetGas)
+	}
+	require.Equal(1, header.BaseFee.Cmp(big.NewInt(150_000_000_000)))
+
+	// Raising the floor applies to the next block
+	ctrl.floor = new(big.Int).Mul(header.BaseFee, common.Big2)
+	header = next(header, 2, 0)
+	require.Equal(ctrl.floor, header.BaseFee)
+
+	// EstimateNextBaseFee clamps timestamps prior to the parent
+	_, estimate, err := EstimateNextBaseFee(&config, ctrl, header, 0)
+	require.NoError(err)
+	_, baseFee, err := CalcBaseFee(&config, ctrl, header, header.Time)
+	require.NoError(err)
+	require.Equal(baseFee, estimate)
+
+	// The base fee cannot be calculated without the admin contract state
+	ctrl.err = errors.New("missing state")
+	_, _, err = CalcBaseFee(&config, ctrl, header, header.Time+2)
+	require.ErrorIs(err, ctrl.err)
+	phase0Parent := &types.Header{Number: common.Big1, Time: 10, ExtDataGasUsed: new(big.Int)}
+	_, _, err = CalcBaseFee(&config, ctrl, phase0Parent, 12)
+	require.ErrorIs(err, ctrl.err)
+}
@@ -217,7 +217,7 @@ func (self *DummyEngine) verifyHeader(chain consensus.ChainHeaderReader, header
 		if uint64(len(header.Extra)) > params.MaximumExtraDataSize {
 			return fmt.Errorf("extra-data too long: %d > %d", len(header.Extra), params.MaximumExtraDataSize)
 		}
-	} else if config.IsSunrisePhase0(parentTimestamp) {
+	} else if config.IsSunrisePhase0(parentTimestamp) && !config.IsSunrisePhase1(timestamp) {
 		if len(header.Extra) != params.SunrisePhase0ExtraDataSize {
 			return fmt.Errorf("expected extra-data field to be: %d, but found %d", params.SunrisePhase0ExtraDataSize, len(header.Extra))
 		}
@@ -57,8 +57,11 @@ func CalcBaseFee(config *params.ChainConfig, ctrl admin.AdminController, parent
 		isApricotPhase4 = config.IsApricotPhase4(bigTimestamp)
 		isApricotPhase5 = config.IsApricotPhase5(bigTimestamp)
 		isSunrisePhase0 = config.IsSunrisePhase0(bigTimestamp)
+		// Sunrise Phase 1 is decided by the child block, so that the first
+		// block of Sunrise Phase 1 already encodes the rollup window
+		isSunrisePhase1 = config.IsSunrisePhase1(new(big.Int).SetUint64(timestamp))
 	)
-	if !isSunrisePhase0 && (!isApricotPhase3 || parent.Number.Cmp(common.Big0) == 0) {
+	if !isSunrisePhase0 && !isSunrisePhase1 && (!isApricotPhase3 || parent.Number.Cmp(common.Big0) == 0) {
 		initialSlice := make([]byte, params.ApricotPhase3ExtraDataSize)
 		initialBaseFee := big.NewInt(params.ApricotPhase3InitialBaseFee)
 		return initialSlice, initialBaseFee, nil
@@ -68,23 +71,50 @@ func CalcBaseFee(config *params.ChainConfig, ctrl admin.AdminController, parent
 		return nil, nil, fmt.Errorf("cannot calculate base fee for timestamp (%d) prior to parent timestamp (%d)", timestamp, parent.Time)
 	}
 
-	if isSunrisePhase0 {
+	if isSunrisePhase0 && !isSunrisePhase1 {
 		fixedBaseFee := new(big.Int).SetUint64(params.SunrisePhase0BaseFee)
 		if ctrl != nil {
-			fixedBaseFee = ctrl.GetFixedBaseFee(parent, nil)
+			var err error
+			if fixedBaseFee, err = ctrl.GetFixedBaseFee(parent, nil, timestamp); err != nil {
+				return nil, nil, fmt.Errorf("cannot get the fixed base fee: %w", err)
+			}
 		}
 		return []byte{}, fixedBaseFee, nil
 	}
 
-	if uint64(len(parent.Extra)) != params.ApricotPhase3ExtraDataSize {
-		return nil, nil, fmt.Errorf("expected length of parent extra data to be %d, but found %d", params.ApricotPhase3ExtraDataSize, len(parent.Extra))
+	var (
+		parentExtra            = parent.Extra
+		parentBaseFee          = parent.BaseFee
+		minBaseFee, maxBaseFee *big.Int
+	)
+	if isSunrisePhase1 {
+		// The admin contract bounds the base fee as of the parent block
+		minBaseFee = new(big.Int).SetUint64(params.SunrisePhase0BaseFee)
+		if ctrl != nil {
+			var err error
+			if minBaseFee, maxBaseFee, err = ctrl.GetBaseFeeBounds(parent, nil); err != nil {
+				return nil, nil, fmt.Errorf("cannot get the base fee bounds: %w", err)
+			}
+		}
+		// Blocks with fixed base fee do not encode a rollup window, so the
+		// window starts empty at the parent's base fee
+		if uint64(len(parentExtra)) != params.ApricotPhase3ExtraDataSize {
+			parentExtra = make([]byte, params.ApricotPhase3ExtraDataSize)
+		}
+		if parentBaseFee == nil {
+			parentBaseFee = minBaseFee
+		}
+	}
+
+	if uint64(len(parentExtra)) != params.ApricotPhase3ExtraDataSize {
+		return nil, nil, fmt.Errorf("expected length of parent extra data to be %d, but found %d", params.ApricotPhase3ExtraDataSize, len(parentExtra))
 	}
 
 	roll := timestamp - parent.Time
 
 	// roll the window over by the difference between the timestamps to generate
 	// the new rollup window.
-	newRollupWindow, err := rollLongWindow(parent.Extra, int(roll))
+	newRollupWindow, err := rollLongWindow(parentExtra, int(roll))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -92,7 +122,7 @@ func CalcBaseFee(config *params.ChainConfig, ctrl admin.AdminController, parent
 	// If AP5, use a less responsive [BaseFeeChangeDenominator] and a higher gas
 	// block limit
 	var (
-		baseFee                  = new(big.Int).Set(parent.BaseFee)
+		baseFee                  = new(big.Int).Set(parentBaseFee)
 		baseFeeChangeDenominator = ApricotPhase4BaseFeeChangeDenominator
 		parentGasTarget          = params.ApricotPhase3TargetGas
 	)
@@ -166,7 +196,7 @@ func CalcBaseFee(config *params.ChainConfig, ctrl admin.AdminController, parent
 	if totalGas > parentGasTarget {
 		// If the parent block used more gas than its target, the baseFee should increase.
 		gasUsedDelta := new(big.Int).SetUint64(totalGas - parentGasTarget)
-		x := new(big.Int).Mul(parent.BaseFee, gasUsedDelta)
+		x := new(big.Int).Mul(parentBaseFee, gasUsedDelta)
 		y := x.Div(x, parentGasTargetBig)
 		baseFeeDelta := math.BigMax(
 			x.Div(y, baseFeeChangeDenominator),
@@ -177,7 +207,7 @@ func CalcBaseFee(config *params.ChainConfig, ctrl admin.AdminController, parent
 	} else {
 		// Otherwise if the parent block used less gas than its target, the baseFee should decrease.
 		gasUsedDelta := new(big.Int).SetUint64(parentGasTarget - totalGas)
-		x := new(big.Int).Mul(parent.BaseFee, gasUsedDelta)
+		x := new(big.Int).Mul(parentBaseFee, gasUsedDelta)
 		y := x.Div(x, parentGasTargetBig)
 		baseFeeDelta := math.BigMax(
 			x.Div(y, baseFeeChangeDenominator),
@@ -197,6 +227,8 @@ func CalcBaseFee(config *params.ChainConfig, ctrl admin.AdminController, parent
 
 	// Ensure that the base fee does not increase/decrease outside of the bounds
 	switch {
+	case isSunrisePhase1:
+		baseFee = selectBigWithinBounds(minBaseFee, baseFee, maxBaseFee)
 	case isApricotPhase5:
 		baseFee = selectBigWithinBounds(ApricotPhase4MinBaseFee, baseFee, nil)
 	case isApricotPhase4:
@@ -34,11 +34,21 @@ contract CaminoAdmin is SimpleAccessControlImpl {
     // address.funcSig (20+12bytes) => FuncSigs
     mapping(uint256 => uint256) private blacklist;
 
+    // Slot4, Slot5: bounds of the dynamic base fee (Sunrise Phase 1)
+    uint256 private baseFeeFloor;
+    uint256 private baseFeeCeiling;
+
+    // Slot6, Slot7: fixed base fee replacing baseGasFee from pendingBaseFeeTime
+    uint256 private pendingBaseFee;
+    uint256 private pendingBaseFeeTime;
+
     /**********************************/
     /************  Events  ************/
     /**********************************/
 
     event GasFeeSet(uint256 newGasFee);
+    event BaseFeeBoundsSet(uint256 floor, uint256 ceiling);
+    event BaseFeeScheduled(uint256 newGasFee, uint256 activationTime);
     event KycStateChanged(
         address indexed account,
         uint256 oldState,
@@ -67,15 +77,67 @@ contract CaminoAdmin is SimpleAccessControlImpl {
     /**********************************/
 
     function getBaseFee() external view returns (uint256) {
-        return baseGasFee;
+        return _activeBaseFee();
+    }
+
+    function getPendingBaseFee() external view returns (uint256, uint256) {
+        if (pendingBaseFeeTime == 0 || block.timestamp >= pendingBaseFeeTime) {
+            return (0, 0);
+        }
+        return (pendingBaseFee, pendingBaseFeeTime);
     }
 
+    // Sets the base fee immediately and drops a scheduled base fee
     function setBaseFee(uint256 newFee) external onlyRole(GAS_FEE_ROLE) {
         baseGasFee = newFee;
+        pendingBaseFee = 0;
+        pendingBaseFeeTime = 0;
 
         emit GasFeeSet(newFee);
     }
 
+    // Schedules the base fee of blocks from activationTime on
+    function scheduleBaseFee(uint256 newFee, uint256 activationTime)
+        external
+        onlyRole(GAS_FEE_ROLE)
+    {
+        require(
+            activationTime > block.timestamp,
+            "activation time not in the future"
+        );
+        baseGasFee = _activeBaseFee();
+        pendingBaseFee = newFee;
+        pendingBaseFeeTime = activationTime;
+
+        emit BaseFeeScheduled(newFee, activationTime);
+    }
+
+    function _activeBaseFee() internal view returns (uint256) {
+        if (pendingBaseFeeTime != 0 && block.timestamp >= pendingBaseFeeTime) {
+            return pendingBaseFee;
+        }
+        return baseGasFee;
+    }
+
+    function getBaseFeeBounds() external view returns (uint256, uint256) {
+        return (baseFeeFloor, baseFeeCeiling);
+    }
+
+    // A zero ceiling leaves the dynamic base fee unbounded
+    function setBaseFeeBounds(uint256 floor, uint256 ceiling)
+        external
+        onlyRole(GAS_FEE_ROLE)
+    {
+        require(
+            ceiling == 0 || floor <= ceiling,
+            "floor exceeds ceiling"
+        );
+        baseFeeFloor = floor;
+        baseFeeCeiling = ceiling;
+
+        emit BaseFeeBoundsSet(floor, ceiling);
+    }
+
     /**********************************/
     /**************  KYC  *************/
     /**********************************/
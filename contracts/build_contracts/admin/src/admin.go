@@ -30,7 +30,7 @@ var (
 
 // BuildMetaData contains all meta data concerning the Build contract.
 var BuildMetaData = &bind.MetaData{
-	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"role\",\"type\":\"uint256\"}],\"name\":\"DropRole\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newGasFee\",\"type\":\"uint256\"}],\"name\":\"GasFeeSet\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"oldState\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newState\",\"type\":\"uint256\"}],\"name\":\"KycStateChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"role\",\"type\":\"uint256\"}],\"name\":\"SetRole\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"remove\",\"type\":\"bool\"},{\"internalType\":\"uint256\",\"name\":\"state\",\"type\":\"uint256\"}],\"name\":\"applyKycState\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getBaseFee\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"bytes4\",\"name\":\"signature\",\"type\":\"bytes4\"}],\"name\":\"getBlacklistState\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"getKycState\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"}],\"name\":\"getRoles\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"role\",\"type\":\"uint256\"}],\"name\":\"grantRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"role\",\"type\":\"uint256\"}],\"name\":\"hasRole\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"role\",\"type\":\"uint256\"}],\"name\":\"revokeRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"newFee\",\"type\":\"uint256\"}],\"name\":\"setBaseFee\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"bytes4\",\"name\":\"signature\",\"type\":\"bytes4\"},{\"internalType\":\"uint256\",\"name\":\"state\",\"type\":\"uint256\"}],\"name\":\"setBlacklistState\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newImplementation\",\"type\":\"address\"}],\"name\":\"upgrade\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"floor\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"ceiling\",\"type\":\"uint256\"}],\"name\":\"BaseFeeBoundsSet\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newGasFee\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"activationTime\",\"type\":\"uint256\"}],\"name\":\"BaseFeeScheduled\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"role\",\"type\":\"uint256\"}],\"name\":\"DropRole\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newGasFee\",\"type\":\"uint256\"}],\"name\":\"GasFeeSet\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"oldState\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newState\",\"type\":\"uint256\"}],\"name\":\"KycStateChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"role\",\"type\":\"uint256\"}],\"name\":\"SetRole\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"remove\",\"type\":\"bool\"},{\"internalType\":\"uint256\",\"name\":\"state\",\"type\":\"uint256\"}],\"name\":\"applyKycState\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getBaseFee\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getBaseFeeBounds\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"bytes4\",\"name\":\"signature\",\"type\":\"bytes4\"}],\"name\":\"getBlacklistState\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"getKycState\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getPendingBaseFee\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"}],\"name\":\"getRoles\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"role\",\"type\":\"uint256\"}],\"name\":\"grantRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"role\",\"type\":\"uint256\"}],\"name\":\"hasRole\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"role\",\"type\":\"uint256\"}],\"name\":\"revokeRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"newFee\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"activationTime\",\"type\":\"uint256\"}],\"name\":\"scheduleBaseFee\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"newFee\",\"type\":\"uint256\"}],\"name\":\"setBaseFee\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"floor\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"ceiling\",\"type\":\"uint256\"}],\"name\":\"setBaseFeeBounds\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"bytes4\",\"name\":\"signature\",\"type\":\"bytes4\"},{\"internalType\":\"uint256\",\"name\":\"state\",\"type\":\"uint256\"}],\"name\":\"setBlacklistState\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newImplementation\",\"type\":\"address\"}],\"name\":\"upgrade\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
 }
 
 // BuildABI is the input ABI used to generate the binding from.
@@ -210,6 +210,38 @@ func (_Build *BuildCallerSession) GetBaseFee() (*big.Int, error) {
 	return _Build.Contract.GetBaseFee(&_Build.CallOpts)
 }
 
+// GetBaseFeeBounds is a free data retrieval call binding the contract method 0xe6da6461.
+//
+// Solidity: function getBaseFeeBounds() view returns(uint256, uint256)
+func (_Build *BuildCaller) GetBaseFeeBounds(opts *bind.CallOpts) (*big.Int, *big.Int, error) {
+	var out []interface{}
+	err := _Build.contract.Call(opts, &out, "getBaseFeeBounds")
+
+	if err != nil {
+		return *new(*big.Int), *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	out1 := *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+
+	return out0, out1, err
+
+}
+
+// GetBaseFeeBounds is a free data retrieval call binding the contract method 0xe6da6461.
+//
+// Solidity: function getBaseFeeBounds() view returns(uint256, uint256)
+func (_Build *BuildSession) GetBaseFeeBounds() (*big.Int, *big.Int, error) {
+	return _Build.Contract.GetBaseFeeBounds(&_Build.CallOpts)
+}
+
+// GetBaseFeeBounds is a free data retrieval call binding the contract method 0xe6da6461.
+//
+// Solidity: function getBaseFeeBounds() view returns(uint256, uint256)
+func (_Build *BuildCallerSession) GetBaseFeeBounds() (*big.Int, *big.Int, error) {
+	return _Build.Contract.GetBaseFeeBounds(&_Build.CallOpts)
+}
+
 // GetBlacklistState is a free data retrieval call binding the contract method 0x4de525d9.
 //
 // Solidity: function getBlacklistState(address account, bytes4 signature) view returns(uint256)
@@ -272,6 +304,38 @@ func (_Build *BuildCallerSession) GetKycState(account common.Address) (*big.Int,
 	return _Build.Contract.GetKycState(&_Build.CallOpts, account)
 }
 
+// GetPendingBaseFee is a free data retrieval call binding the contract method 0x02e39d81.
+//
+// Solidity: function getPendingBaseFee() view returns(uint256, uint256)
+func (_Build *BuildCaller) GetPendingBaseFee(opts *bind.CallOpts) (*big.Int, *big.Int, error) {
+	var out []interface{}
+	err := _Build.contract.Call(opts, &out, "getPendingBaseFee")
+
+	if err != nil {
+		return *new(*big.Int), *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	out1 := *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+
+	return out0, out1, err
+
+}
+
+// GetPendingBaseFee is a free data retrieval call binding the contract method 0x02e39d81.
+//
+// Solidity: function getPendingBaseFee() view returns(uint256, uint256)
+func (_Build *BuildSession) GetPendingBaseFee() (*big.Int, *big.Int, error) {
+	return _Build.Contract.GetPendingBaseFee(&_Build.CallOpts)
+}
+
+// GetPendingBaseFee is a free data retrieval call binding the contract method 0x02e39d81.
+//
+// Solidity: function getPendingBaseFee() view returns(uint256, uint256)
+func (_Build *BuildCallerSession) GetPendingBaseFee() (*big.Int, *big.Int, error) {
+	return _Build.Contract.GetPendingBaseFee(&_Build.CallOpts)
+}
+
 // GetRoles is a free data retrieval call binding the contract method 0xce6ccfaf.
 //
 // Solidity: function getRoles(address addr) view returns(uint256)
@@ -397,6 +461,27 @@ func (_Build *BuildTransactorSession) RevokeRole(addr common.Address, role *big.
 	return _Build.Contract.RevokeRole(&_Build.TransactOpts, addr, role)
 }
 
+// ScheduleBaseFee is a paid mutator transaction binding the contract method 0xf9c9063b.
+//
+// Solidity: function scheduleBaseFee(uint256 newFee, uint256 activationTime) returns()
+func (_Build *BuildTransactor) ScheduleBaseFee(opts *bind.TransactOpts, newFee *big.Int, activationTime *big.Int) (*types.Transaction, error) {
+	return _Build.contract.Transact(opts, "scheduleBaseFee", newFee, activationTime)
+}
+
+// ScheduleBaseFee is a paid mutator transaction binding the contract method 0xf9c9063b.
+//
+// Solidity: function scheduleBaseFee(uint256 newFee, uint256 activationTime) returns()
+func (_Build *BuildSession) ScheduleBaseFee(newFee *big.Int, activationTime *big.Int) (*types.Transaction, error) {
+	return _Build.Contract.ScheduleBaseFee(&_Build.TransactOpts, newFee, activationTime)
+}
+
+// ScheduleBaseFee is a paid mutator transaction binding the contract method 0xf9c9063b.
+//
+// Solidity: function scheduleBaseFee(uint256 newFee, uint256 activationTime) returns()
+func (_Build *BuildTransactorSession) ScheduleBaseFee(newFee *big.Int, activationTime *big.Int) (*types.Transaction, error) {
+	return _Build.Contract.ScheduleBaseFee(&_Build.TransactOpts, newFee, activationTime)
+}
+
 // SetBaseFee is a paid mutator transaction binding the contract method 0x46860698.
 //
 // Solidity: function setBaseFee(uint256 newFee) returns()
@@ -418,6 +503,27 @@ func (_Build *BuildTransactorSession) SetBaseFee(newFee *big.Int) (*types.Transa
 	return _Build.Contract.SetBaseFee(&_Build.TransactOpts, newFee)
 }
 
+// SetBaseFeeBounds is a paid mutator transaction binding the contract method 0x7f786f4b.
+//
+// Solidity: function setBaseFeeBounds(uint256 floor, uint256 ceiling) returns()
+func (_Build *BuildTransactor) SetBaseFeeBounds(opts *bind.TransactOpts, floor *big.Int, ceiling *big.Int) (*types.Transaction, error) {
+	return _Build.contract.Transact(opts, "setBaseFeeBounds", floor, ceiling)
+}
+
+// SetBaseFeeBounds is a paid mutator transaction binding the contract method 0x7f786f4b.
+//
+// Solidity: function setBaseFeeBounds(uint256 floor, uint256 ceiling) returns()
+func (_Build *BuildSession) SetBaseFeeBounds(floor *big.Int, ceiling *big.Int) (*types.Transaction, error) {
+	return _Build.Contract.SetBaseFeeBounds(&_Build.TransactOpts, floor, ceiling)
+}
+
+// SetBaseFeeBounds is a paid mutator transaction binding the contract method 0x7f786f4b.
+//
+// Solidity: function setBaseFeeBounds(uint256 floor, uint256 ceiling) returns()
+func (_Build *BuildTransactorSession) SetBaseFeeBounds(floor *big.Int, ceiling *big.Int) (*types.Transaction, error) {
+	return _Build.Contract.SetBaseFeeBounds(&_Build.TransactOpts, floor, ceiling)
+}
+
 // SetBlacklistState is a paid mutator transaction binding the contract method 0xfdff0b26.
 //
 // Solidity: function setBlacklistState(address account, bytes4 signature, uint256 state) returns()
@@ -460,6 +566,276 @@ func (_Build *BuildTransactorSession) Upgrade(newImplementation common.Address)
 	return _Build.Contract.Upgrade(&_Build.TransactOpts, newImplementation)
 }
 
+// BuildBaseFeeBoundsSetIterator is returned from FilterBaseFeeBoundsSet and is used to iterate over the raw logs and unpacked data for BaseFeeBoundsSet events raised by the Build contract.
+type BuildBaseFeeBoundsSetIterator struct {
+	Event *BuildBaseFeeBoundsSet // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BuildBaseFeeBoundsSetIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BuildBaseFeeBoundsSet)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BuildBaseFeeBoundsSet)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BuildBaseFeeBoundsSetIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BuildBaseFeeBoundsSetIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BuildBaseFeeBoundsSet represents a BaseFeeBoundsSet event raised by the Build contract.
+type BuildBaseFeeBoundsSet struct {
+	Floor   *big.Int
+	Ceiling *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterBaseFeeBoundsSet is a free log retrieval operation binding the contract event 0xacaa3de37b91392ae66b6312bd53eb15960206ea6c6f2f7afaf185a3fe3d7afc.
+//
+// Solidity: event BaseFeeBoundsSet(uint256 floor, uint256 ceiling)
+func (_Build *BuildFilterer) FilterBaseFeeBoundsSet(opts *bind.FilterOpts) (*BuildBaseFeeBoundsSetIterator, error) {
+
+	logs, sub, err := _Build.contract.FilterLogs(opts, "BaseFeeBoundsSet")
+	if err != nil {
+		return nil, err
+	}
+	return &BuildBaseFeeBoundsSetIterator{contract: _Build.contract, event: "BaseFeeBoundsSet", logs: logs, sub: sub}, nil
+}
+
+// WatchBaseFeeBoundsSet is a free log subscription operation binding the contract event 0xacaa3de37b91392ae66b6312bd53eb15960206ea6c6f2f7afaf185a3fe3d7afc.
+//
+// Solidity: event BaseFeeBoundsSet(uint256 floor, uint256 ceiling)
+func (_Build *BuildFilterer) WatchBaseFeeBoundsSet(opts *bind.WatchOpts, sink chan<- *BuildBaseFeeBoundsSet) (event.Subscription, error) {
+
+	logs, sub, err := _Build.contract.WatchLogs(opts, "BaseFeeBoundsSet")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BuildBaseFeeBoundsSet)
+				if err := _Build.contract.UnpackLog(event, "BaseFeeBoundsSet", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseBaseFeeBoundsSet is a log parse operation binding the contract event 0xacaa3de37b91392ae66b6312bd53eb15960206ea6c6f2f7afaf185a3fe3d7afc.
+//
+// Solidity: event BaseFeeBoundsSet(uint256 floor, uint256 ceiling)
+func (_Build *BuildFilterer) ParseBaseFeeBoundsSet(log types.Log) (*BuildBaseFeeBoundsSet, error) {
+	event := new(BuildBaseFeeBoundsSet)
+	if err := _Build.contract.UnpackLog(event, "BaseFeeBoundsSet", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// BuildBaseFeeScheduledIterator is returned from FilterBaseFeeScheduled and is used to iterate over the raw logs and unpacked data for BaseFeeScheduled events raised by the Build contract.
+type BuildBaseFeeScheduledIterator struct {
+	Event *BuildBaseFeeScheduled // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *BuildBaseFeeScheduledIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(BuildBaseFeeScheduled)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(BuildBaseFeeScheduled)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *BuildBaseFeeScheduledIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *BuildBaseFeeScheduledIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// BuildBaseFeeScheduled represents a BaseFeeScheduled event raised by the Build contract.
+type BuildBaseFeeScheduled struct {
+	NewGasFee      *big.Int
+	ActivationTime *big.Int
+	Raw            types.Log // Blockchain specific contextual infos
+}
+
+// FilterBaseFeeScheduled is a free log retrieval operation binding the contract event 0xb2e2364a8d535fae11bfe86b68a34ade78081c8b5083d3f3663d285bcb206745.
+//
+// Solidity: event BaseFeeScheduled(uint256 newGasFee, uint256 activationTime)
+func (_Build *BuildFilterer) FilterBaseFeeScheduled(opts *bind.FilterOpts) (*BuildBaseFeeScheduledIterator, error) {
+
+	logs, sub, err := _Build.contract.FilterLogs(opts, "BaseFeeScheduled")
+	if err != nil {
+		return nil, err
+	}
+	return &BuildBaseFeeScheduledIterator{contract: _Build.contract, event: "BaseFeeScheduled", logs: logs, sub: sub}, nil
+}
+
+// WatchBaseFeeScheduled is a free log subscription operation binding the contract event 0xb2e2364a8d535fae11bfe86b68a34ade78081c8b5083d3f3663d285bcb206745.
+//
+// Solidity: event BaseFeeScheduled(uint256 newGasFee, uint256 activationTime)
+func (_Build *BuildFilterer) WatchBaseFeeScheduled(opts *bind.WatchOpts, sink chan<- *BuildBaseFeeScheduled) (event.Subscription, error) {
+
+	logs, sub, err := _Build.contract.WatchLogs(opts, "BaseFeeScheduled")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(BuildBaseFeeScheduled)
+				if err := _Build.contract.UnpackLog(event, "BaseFeeScheduled", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseBaseFeeScheduled is a log parse operation binding the contract event 0xb2e2364a8d535fae11bfe86b68a34ade78081c8b5083d3f3663d285bcb206745.
+//
+// Solidity: event BaseFeeScheduled(uint256 newGasFee, uint256 activationTime)
+func (_Build *BuildFilterer) ParseBaseFeeScheduled(log types.Log) (*BuildBaseFeeScheduled, error) {
+	event := new(BuildBaseFeeScheduled)
+	if err := _Build.contract.UnpackLog(event, "BaseFeeScheduled", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
 // BuildDropRoleIterator is returned from FilterDropRole and is used to iterate over the raw logs and unpacked data for DropRole events raised by the Build contract.
 type BuildDropRoleIterator struct {
 	Event *BuildDropRole // Event containing the contract specifics and raw log
@@ -36,7 +36,7 @@ type MultisigDataOwners struct {
 
 // EvmMetaData contains all meta data concerning the Evm contract.
 var EvmMetaData = &bind.MetaData{
-	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"aliases\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"threshold\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"key\",\"type\":\"address\"}],\"name\":\"getAlias\",\"outputs\":[{\"components\":[{\"internalType\":\"uint256\",\"name\":\"threshold\",\"type\":\"uint256\"},{\"internalType\":\"address[]\",\"name\":\"ctrlGroup\",\"type\":\"address[]\"}],\"internalType\":\"structMultisigData.Owners\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"key\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"threshold\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"address[]\",\"name\":\"ctrlGroup\",\"type\":\"address[]\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"name\":\"AliasChanged\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"aliases\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"threshold\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"key\",\"type\":\"address\"}],\"name\":\"getAlias\",\"outputs\":[{\"components\":[{\"internalType\":\"uint256\",\"name\":\"threshold\",\"type\":\"uint256\"},{\"internalType\":\"address[]\",\"name\":\"ctrlGroup\",\"type\":\"address[]\"}],\"internalType\":\"structMultisigData.Owners\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"key\",\"type\":\"address\"}],\"name\":\"getNonce\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"key\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"threshold\",\"type\":\"uint256\"},{\"internalType\":\"address[]\",\"name\":\"ctrlGroup\",\"type\":\"address[]\"}],\"name\":\"setAlias\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
 }
 
 // EvmABI is the input ABI used to generate the binding from.
@@ -246,3 +246,202 @@ func (_Evm *EvmSession) GetAlias(key common.Address) (MultisigDataOwners, error)
 func (_Evm *EvmCallerSession) GetAlias(key common.Address) (MultisigDataOwners, error) {
 	return _Evm.Contract.GetAlias(&_Evm.CallOpts, key)
 }
+
+// GetNonce is a free data retrieval call binding the contract method 0x2d0335ab.
+//
+// Solidity: function getNonce(address key) view returns(uint256)
+func (_Evm *EvmCaller) GetNonce(opts *bind.CallOpts, key common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Evm.contract.Call(opts, &out, "getNonce", key)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetNonce is a free data retrieval call binding the contract method 0x2d0335ab.
+//
+// Solidity: function getNonce(address key) view returns(uint256)
+func (_Evm *EvmSession) GetNonce(key common.Address) (*big.Int, error) {
+	return _Evm.Contract.GetNonce(&_Evm.CallOpts, key)
+}
+
+// GetNonce is a free data retrieval call binding the contract method 0x2d0335ab.
+//
+// Solidity: function getNonce(address key) view returns(uint256)
+func (_Evm *EvmCallerSession) GetNonce(key common.Address) (*big.Int, error) {
+	return _Evm.Contract.GetNonce(&_Evm.CallOpts, key)
+}
+
+// SetAlias is a paid mutator transaction binding the contract method 0xdc35b3b2.
+//
+// Solidity: function setAlias(address key, uint256 threshold, address[] ctrlGroup) returns()
+func (_Evm *EvmTransactor) SetAlias(opts *bind.TransactOpts, key common.Address, threshold *big.Int, ctrlGroup []common.Address) (*types.Transaction, error) {
+	return _Evm.contract.Transact(opts, "setAlias", key, threshold, ctrlGroup)
+}
+
+// SetAlias is a paid mutator transaction binding the contract method 0xdc35b3b2.
+//
+// Solidity: function setAlias(address key, uint256 threshold, address[] ctrlGroup) returns()
+func (_Evm *EvmSession) SetAlias(key common.Address, threshold *big.Int, ctrlGroup []common.Address) (*types.Transaction, error) {
+	return _Evm.Contract.SetAlias(&_Evm.TransactOpts, key, threshold, ctrlGroup)
+}
+
+// SetAlias is a paid mutator transaction binding the contract method 0xdc35b3b2.
+//
+// Solidity: function setAlias(address key, uint256 threshold, address[] ctrlGroup) returns()
+func (_Evm *EvmTransactorSession) SetAlias(key common.Address, threshold *big.Int, ctrlGroup []common.Address) (*types.Transaction, error) {
+	return _Evm.Contract.SetAlias(&_Evm.TransactOpts, key, threshold, ctrlGroup)
+}
+
+// EvmAliasChangedIterator is returned from FilterAliasChanged and is used to iterate over the raw logs and unpacked data for AliasChanged events raised by the Evm contract.
+type EvmAliasChangedIterator struct {
+	Event *EvmAliasChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log          // Log channel receiving the found contract events
+	sub  interfaces.Subscription // Subscription for errors, completion and termination
+	done bool                    // Whether the subscription completed delivering logs
+	fail error                   // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EvmAliasChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EvmAliasChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EvmAliasChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EvmAliasChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EvmAliasChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EvmAliasChanged represents a AliasChanged event raised by the Evm contract.
+type EvmAliasChanged struct {
+	Key       common.Address
+	Threshold *big.Int
+	CtrlGroup []common.Address
+	Nonce     *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterAliasChanged is a free log retrieval operation binding the contract event 0x67d8534a1087ab3e04966ead6f042693b590cce0914604a1ebc6c9ad0cd99896.
+//
+// Solidity: event AliasChanged(address indexed key, uint256 threshold, address[] ctrlGroup, uint256 nonce)
+func (_Evm *EvmFilterer) FilterAliasChanged(opts *bind.FilterOpts, key []common.Address) (*EvmAliasChangedIterator, error) {
+
+	var keyRule []interface{}
+	for _, keyItem := range key {
+		keyRule = append(keyRule, keyItem)
+	}
+
+	logs, sub, err := _Evm.contract.FilterLogs(opts, "AliasChanged", keyRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EvmAliasChangedIterator{contract: _Evm.contract, event: "AliasChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchAliasChanged is a free log subscription operation binding the contract event 0x67d8534a1087ab3e04966ead6f042693b590cce0914604a1ebc6c9ad0cd99896.
+//
+// Solidity: event AliasChanged(address indexed key, uint256 threshold, address[] ctrlGroup, uint256 nonce)
+func (_Evm *EvmFilterer) WatchAliasChanged(opts *bind.WatchOpts, sink chan<- *EvmAliasChanged, key []common.Address) (event.Subscription, error) {
+
+	var keyRule []interface{}
+	for _, keyItem := range key {
+		keyRule = append(keyRule, keyItem)
+	}
+
+	logs, sub, err := _Evm.contract.WatchLogs(opts, "AliasChanged", keyRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EvmAliasChanged)
+				if err := _Evm.contract.UnpackLog(event, "AliasChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAliasChanged is a log parse operation binding the contract event 0x67d8534a1087ab3e04966ead6f042693b590cce0914604a1ebc6c9ad0cd99896.
+//
+// Solidity: event AliasChanged(address indexed key, uint256 threshold, address[] ctrlGroup, uint256 nonce)
+func (_Evm *EvmFilterer) ParseAliasChanged(log types.Log) (*EvmAliasChanged, error) {
+	event := new(EvmAliasChanged)
+	if err := _Evm.contract.UnpackLog(event, "AliasChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
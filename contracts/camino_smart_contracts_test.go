@@ -242,6 +242,17 @@ func TestDeployContract(t *testing.T) {
 	_, _, _, err = bind.DeployContract(kycAddrOpts, parsed, common.FromHex(dummyContractBin), sim)
 	sim.Commit(true)
 	assert.Error(t, err, vmerrs.ErrNotKycVerified)
+
+	// Add verified but expired kyc state to kycAddr
+	_, err = kycAddrSession.ApplyKycState(kycAddr, false, big.NewInt(3))
+	assert.NoError(t, err)
+
+	sim.Commit(true)
+
+	// Deploy contract again with kycAddr, we assume it will fail because KYC expired
+	_, _, _, err = bind.DeployContract(kycAddrOpts, parsed, common.FromHex(dummyContractBin), sim)
+	sim.Commit(true)
+	assert.Error(t, err, vmerrs.ErrNotKycVerified)
 }
 
 func TestAdminRoleFunctions(t *testing.T) {
@@ -650,7 +661,8 @@ func TestEthAdmin(t *testing.T) {
 
 	latestHeader, state := getLatestHeaderAndState(t, sim)
 
-	bf := ac.GetFixedBaseFee(latestHeader, state)
+	bf, err := ac.GetFixedBaseFee(latestHeader, state, latestHeader.Time)
+	assert.NoError(t, err)
 	assert.EqualValues(t, big.NewInt(0), big.NewInt(int64(bf.Cmp(big.NewInt(int64(params.SunrisePhase0BaseFee))))))
 
 	adminContract, err := admin.NewBuild(contractAddr, sim)
@@ -670,7 +682,8 @@ func TestEthAdmin(t *testing.T) {
 
 	sim.Commit(true)
 
-	bf = ac.GetFixedBaseFee(latestHeader, state)
+	bf, err = ac.GetFixedBaseFee(latestHeader, state, latestHeader.Time)
+	assert.NoError(t, err)
 	// Despite the fact that the base fee changed, the Admin Controller still tries to get it from the previous role.
 	// Therefore, it should return the SunrisePhase0BaseFee value
 	assert.EqualValues(t, big.NewInt(0), big.NewInt(int64(bf.Cmp(big.NewInt(int64(params.SunrisePhase0BaseFee))))))
@@ -678,12 +691,101 @@ func TestEthAdmin(t *testing.T) {
 	// Get new block's header
 	latestHeader, state = getLatestHeaderAndState(t, sim)
 
-	bf = ac.GetFixedBaseFee(latestHeader, state)
+	bf, err = ac.GetFixedBaseFee(latestHeader, state, latestHeader.Time)
+	assert.NoError(t, err)
 
 	// Now with the new block's header, Base Fee should be the new one.
 	assert.EqualValues(t, big.NewInt(1), bf)
 }
 
+func TestEthAdminBlacklist(t *testing.T) {
+	contractAddr := AdminProxyAddr
+
+	var getKycStateSig, getBaseFeeSig [4]byte
+	copy(getKycStateSig[:], crypto.Keccak256([]byte("getKycState(address)")))
+	copy(getBaseFeeSig[:], crypto.Keccak256([]byte("getBaseFee()")))
+
+	// Initialize TransactOpts for each key
+	blacklistOpts, err := bind.NewKeyedTransactorWithChainID(blacklistKey, big.NewInt(1337))
+	assert.NoError(t, err)
+
+	// Generate GenesisAlloc
+	alloc := makeGenesisAllocation()
+
+	// Blacklists are enforced as of Sunrise Phase 2
+	ethChain := newETHChain(t)
+	sunrisePhase0Ctrl := ethadmin.NewController(ethChain.backend.APIBackend, ethChain.chainConfig)
+	chainConfig := *ethChain.chainConfig
+	chainConfig.SunrisePhase2BlockTimestamp = big.NewInt(0)
+	ac := ethadmin.NewController(ethChain.backend.APIBackend, &chainConfig)
+
+	// Generate SimulatedBackend
+	sim := backends.NewSimulatedBackendWithInitialAdminAndAdminController(alloc, gasLimit, blacklistAddr, ac)
+	defer func() {
+		err := sim.Close()
+		assert.NoError(t, err)
+	}()
+
+	sim.Commit(true)
+
+	adminContract, err := admin.NewBuild(contractAddr, sim)
+	assert.NoError(t, err)
+
+	// BuildSession Initialization
+	blacklistSession := admin.BuildSession{Contract: adminContract, TransactOpts: *blacklistOpts}
+
+	_, err = blacklistSession.GrantRole(blacklistAddr, BLACKLIST_ROLE)
+	assert.NoError(t, err)
+
+	sim.Commit(true)
+
+	// Blacklist a single function of dummyAddr
+	latestHeader, state := getLatestHeaderAndState(t, sim)
+	assert.False(t, ac.IsBlacklisted(latestHeader, state, dummyAddr, getKycStateSig))
+
+	_, err = blacklistSession.SetBlacklistState(dummyAddr, getKycStateSig, big.NewInt(1))
+	assert.NoError(t, err)
+
+	sim.Commit(true)
+
+	latestHeader, state = getLatestHeaderAndState(t, sim)
+	assert.True(t, ac.IsBlacklisted(latestHeader, state, dummyAddr, getKycStateSig))
+	assert.False(t, ac.IsBlacklisted(latestHeader, state, dummyAddr, getBaseFeeSig))
+	assert.False(t, sunrisePhase0Ctrl.IsBlacklisted(latestHeader, state, dummyAddr, getKycStateSig))
+
+	// The admin contract is never blacklisted
+	_, err = blacklistSession.SetBlacklistState(contractAddr, getKycStateSig, big.NewInt(1))
+	assert.NoError(t, err)
+	_, err = blacklistSession.SetBlacklistState(contractAddr, [4]byte{}, big.NewInt(1))
+	assert.NoError(t, err)
+
+	sim.Commit(true)
+
+	latestHeader, state = getLatestHeaderAndState(t, sim)
+	assert.False(t, ac.IsBlacklisted(latestHeader, state, contractAddr, getKycStateSig))
+	assert.False(t, ac.IsBlacklisted(latestHeader, state, contractAddr, [4]byte{}))
+
+	_, err = blacklistSession.GetKycState(dummyAddr)
+	assert.NoError(t, err)
+
+	// A zero signature blacklists the whole address
+	_, err = sim.CallContract(ctx, interfaces.CallMsg{From: blacklistAddr, To: &dummyAddr}, nil)
+	assert.NoError(t, err)
+
+	_, err = blacklistSession.SetBlacklistState(dummyAddr, [4]byte{}, big.NewInt(1))
+	assert.NoError(t, err)
+
+	sim.Commit(true)
+
+	latestHeader, state = getLatestHeaderAndState(t, sim)
+	assert.True(t, ac.IsBlacklisted(latestHeader, state, dummyAddr, getBaseFeeSig))
+	assert.True(t, ac.IsBlacklisted(latestHeader, state, dummyAddr, [4]byte{}))
+	assert.False(t, sunrisePhase0Ctrl.IsBlacklisted(latestHeader, state, dummyAddr, [4]byte{}))
+
+	_, err = sim.CallContract(ctx, interfaces.CallMsg{From: blacklistAddr, To: &dummyAddr}, nil)
+	assert.ErrorContains(t, err, vmerrs.ErrBlacklisted.Error())
+}
+
 func getLatestHeaderAndState(t *testing.T, sim *backends.SimulatedBackend) (*types.Header, *state.StateDB) {
 	latestHeader := sim.Blockchain().LastAcceptedBlock().Header()
 	state, err := sim.Blockchain().State()
@@ -725,6 +827,10 @@ func newETHChain(t *testing.T) *ETHChain {
 		ApricotPhase6BlockTimestamp:     big.NewInt(0),
 		ApricotPhasePost6BlockTimestamp: big.NewInt(0),
 		BanffBlockTimestamp:             big.NewInt(0),
+		// Reject expired KYC states
+		KycPolicyUpgrades: []params.KycPolicyUpgrade{
+			{BlockTimestamp: big.NewInt(0), KycPolicy: params.KycPolicy{ContractCreation: true}},
+		},
 	}
 
 	config.Genesis = &core.Genesis{
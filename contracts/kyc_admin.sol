@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.0;
+
+// Interface of the KYC admin precompile, enabled through the kycAdminConfig
+// precompile upgrade of the chain config.
+interface IKycAdmin {
+    // Returns the KYC state bitmask of account (1 = verified, 2 = expired)
+    function kycState(address account) external view returns (uint256);
+
+    // Returns the fixed base fee set in the admin contract
+    function baseFee() external view returns (uint256);
+
+    // Returns true if calls of signature into account are rejected. An
+    // account blacklisted without signature rejects every call.
+    function isBlacklisted(address account, bytes4 signature)
+        external
+        view
+        returns (bool);
+}
+
+library KycAdmin {
+    IKycAdmin internal constant PRECOMPILE =
+        IKycAdmin(0x0100000000000000000000000000000000000003);
+}
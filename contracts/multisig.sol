@@ -2,15 +2,77 @@
 
 pragma solidity ^0.8.0;
 
+interface IAccessControl {
+    function hasRole(address addr, uint256 role) external view returns (bool);
+}
+
 contract MultisigData {
+    // Aliases are managed by the admins of the CaminoAdmin contract
+    address private constant AdminProxyAddress =
+        0x010000000000000000000000000000000000000a;
+    uint256 internal constant ADMIN_ROLE = 1 << 0;
+
+    // The node ignores aliases with a larger control group
+    uint256 internal constant MAX_OWNERS = 256;
+
     struct Owners {
         uint256 threshold;
         address[] ctrlGroup;
     }
 
+    // Slot0
     mapping (address => Owners) public aliases;
 
+    // Slot1: incremented on every change of an alias. The node resolves
+    // conflicting definitions of an alias by their nonce, like AliasWithNonce.
+    mapping (address => uint256) internal nonces;
+
+    event AliasChanged(
+        address indexed key,
+        uint256 threshold,
+        address[] ctrlGroup,
+        uint256 nonce
+    );
+
+    modifier onlyAdmin() {
+        require(
+            IAccessControl(AdminProxyAddress).hasRole(msg.sender, ADMIN_ROLE),
+            "Access denied"
+        );
+        _;
+    }
+
     function getAlias(address key) public view returns (Owners memory) {
         return aliases[key];
     }
+
+    function getNonce(address key) external view returns (uint256) {
+        return nonces[key];
+    }
+
+    // Defines or changes the alias key. An empty control group with a zero
+    // threshold removes it. The control group must be sorted ascending.
+    function setAlias(
+        address key,
+        uint256 threshold,
+        address[] calldata ctrlGroup
+    ) external onlyAdmin {
+        require(ctrlGroup.length <= MAX_OWNERS, "too many owners");
+        require(
+            ctrlGroup.length == 0
+                ? threshold == 0
+                : threshold > 0 && threshold <= ctrlGroup.length,
+            "invalid threshold"
+        );
+        for (uint256 i = 1; i < ctrlGroup.length; i++) {
+            require(ctrlGroup[i - 1] < ctrlGroup[i], "owners not sorted");
+        }
+
+        Owners storage owners = aliases[key];
+        owners.threshold = threshold;
+        owners.ctrlGroup = ctrlGroup;
+        uint256 nonce = ++nonces[key];
+
+        emit AliasChanged(key, threshold, ctrlGroup, nonce);
+    }
 }
@@ -21,7 +21,18 @@ type AdminController interface {
 	// Starts listening to blockchain events
 	Start()
 	// Get the FixedBaseFee which should applied for blocks after height
-	GetFixedBaseFee(head *types.Header, state StateDB) *big.Int
-	// Returns true if we are in SunrisePhase0 and KYC flag is set
+	// with the given timestamp, respecting a scheduled base fee change
+	GetFixedBaseFee(head *types.Header, state StateDB, timestamp uint64) (*big.Int, error)
+	// Get the scheduled FixedBaseFee and its activation timestamp as of
+	// height, nil if no change is scheduled
+	GetPendingBaseFee(head *types.Header, state StateDB) (*big.Int, uint64)
+	// Get the floor and ceiling (nil if unbounded) of the dynamic base fee
+	// which should applied for blocks after height in SunrisePhase1
+	GetBaseFeeBounds(head *types.Header, state StateDB) (*big.Int, *big.Int, error)
+	// Returns true if we are not in SunrisePhase0 or KYC flag is set and not expired
 	KycVerified(head *types.Header, state StateDB, addr common.Address) bool
+	// Returns true if we are in SunrisePhase2 and the function signature
+	// or the whole address (zero signature) is blacklisted. The admin
+	// contract is never blacklisted.
+	IsBlacklisted(head *types.Header, state StateDB, addr common.Address, signature [4]byte) bool
 }
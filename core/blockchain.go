@@ -52,6 +52,7 @@ import (
 	"github.com/ava-labs/coreth/core/admin"
 	"github.com/ava-labs/coreth/core/rawdb"
 	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/core/state/pruner"
 	"github.com/ava-labs/coreth/core/state/snapshot"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/core/vm"
@@ -115,6 +116,9 @@ const (
 	txLookupCacheLimit = 1024
 	badBlockLimit      = 10
 
+	stateHistoryCacheLimit   = 8
+	stateHistoryRebuildLimit = 2
+
 	// BlockChainVersion ensures that an incompatible database forces a resync from scratch.
 	//
 	// Changelog:
@@ -171,6 +175,9 @@ type CacheConfig struct {
 	Preimages                       bool          // Whether to store preimage of trie key to the disk
 	AcceptedCacheSize               int           // Depth of accepted headers cache and accepted logs cache at the accepted tip
 	TxLookupLimit                   uint64        // Number of recent blocks for which to maintain transaction lookup indices
+	StateHistoryWindow              uint64        // Number of recent accepted blocks for which to keep state diffs in pruning mode (0 = disabled)
+	OnlinePruningBloomSize          uint64        // Memory allowance (MB) of the bloom filter of the live state during online pruning
+	OnlinePruningRate               uint64        // Maximum number of state entries processed per second during online pruning (0 = unlimited)
 }
 
 var DefaultCacheConfig = &CacheConfig{
@@ -226,13 +233,17 @@ type BlockChain struct {
 
 	currentBlock atomic.Value // Current head of the block chain
 
-	stateCache    state.Database // State database to reuse between imports (contains state cache)
+	stateCache    state.Database       // State database to reuse between imports (contains state cache)
+	onlinePruner  *pruner.OnlinePruner // Deletes the stale state in the background (nil in archive mode)
 	stateManager  TrieWriter
 	bodyCache     *lru.Cache // Cache for the most recent block bodies
 	receiptsCache *lru.Cache // Cache for the most recent receipts per block
 	blockCache    *lru.Cache // Cache for the most recent entire blocks
 	txLookupCache *lru.Cache // Cache for the most recent transaction lookup data.
 
+	stateHistoryCache *lru.Cache    // Cache for the most recent states rebuilt from the state history
+	stateHistorySem   chan struct{} // Limits the number of states rebuilt concurrently
+
 	running int32 // 0 if chain is running, 1 when stopped
 
 	engine     consensus.Engine
@@ -303,13 +314,27 @@ func NewBlockChain(
 	blockCache, _ := lru.New(blockCacheLimit)
 	txLookupCache, _ := lru.New(txLookupCacheLimit)
 	badBlocks, _ := lru.New(badBlockLimit)
+	stateHistoryCache, _ := lru.New(stateHistoryCacheLimit)
+
+	// The state is written through the online pruner so that the entries
+	// written while it is running are kept
+	var onlinePruner *pruner.OnlinePruner
+	stateDB := db
+	if cacheConfig.Pruning {
+		onlinePruner = pruner.NewOnlinePruner(db, pruner.OnlinePrunerConfig{
+			BloomSize: cacheConfig.OnlinePruningBloomSize,
+			Rate:      cacheConfig.OnlinePruningRate,
+		})
+		stateDB = onlinePruner.Database()
+	}
 
 	bc := &BlockChain{
-		chainConfig: chainConfig,
-		cacheConfig: cacheConfig,
-		adminCtrl:   vmConfig.AdminContoller,
-		db:          db,
-		stateCache: state.NewDatabaseWithConfig(db, &trie.Config{
+		chainConfig:  chainConfig,
+		cacheConfig:  cacheConfig,
+		adminCtrl:    vmConfig.AdminContoller,
+		db:           db,
+		onlinePruner: onlinePruner,
+		stateCache: state.NewDatabaseWithConfig(stateDB, &trie.Config{
 			Cache:       cacheConfig.TrieCleanLimit,
 			Journal:     cacheConfig.TrieCleanJournal,
 			Preimages:   cacheConfig.Preimages,
@@ -319,6 +344,8 @@ func NewBlockChain(
 		receiptsCache:     receiptsCache,
 		blockCache:        blockCache,
 		txLookupCache:     txLookupCache,
+		stateHistoryCache: stateHistoryCache,
+		stateHistorySem:   make(chan struct{}, stateHistoryRebuildLimit),
 		engine:            engine,
 		vmConfig:          vmConfig,
 		badBlocks:         badBlocks,
@@ -393,9 +420,17 @@ func NewBlockChain(
 	// Warm up [hc.acceptedNumberCache] and [acceptedLogsCache]
 	bc.warmAcceptedCaches()
 
+	// Remove the state history outside of the configured window
+	if err := bc.pruneStateHistory(bc.lastAccepted.NumberU64()); err != nil {
+		return nil, fmt.Errorf("could not prune state history: %w", err)
+	}
+
 	// Start processing accepted blocks effects in the background
 	go bc.startAcceptor()
 
+	// Resume the online pruning interrupted by the last shutdown
+	bc.resumeStatePruning()
+
 	// If periodic cache journal is required, spin it up.
 	if bc.cacheConfig.TrieCleanRejournal > 0 && len(bc.cacheConfig.TrieCleanJournal) > 0 {
 		log.Info("Starting to save trie clean cache periodically", "journalDir", bc.cacheConfig.TrieCleanJournal, "freq", bc.cacheConfig.TrieCleanRejournal)
@@ -565,6 +600,13 @@ func (bc *BlockChain) startAcceptor() {
 		start := time.Now()
 		acceptorQueueGauge.Dec(1)
 
+		// Write the state diff while the trie of the parent is still referenced
+		if bc.stateHistoryEnabled() {
+			if err := bc.writeStateHistory(next); err != nil {
+				log.Crit("unable to write state history from acceptor", "blockHash", next.Hash(), "err", err)
+			}
+		}
+
 		if err := bc.flattenSnapshot(func() error {
 			return bc.stateManager.AcceptTrie(next)
 		}, next.Hash()); err != nil {
@@ -634,6 +676,12 @@ func (bc *BlockChain) DrainAcceptorQueue() {
 	bc.acceptorWg.Wait()
 }
 
+// AcceptorQueueLen returns the number of accepted blocks waiting in the
+// [acceptorQueue] to be processed.
+func (bc *BlockChain) AcceptorQueueLen() int {
+	return len(bc.acceptorQueue)
+}
+
 // stopAcceptor sends a signal to the Acceptor to stop processing accepted
 // blocks. The Acceptor will exit once all items in [acceptorQueue] have been
 // processed.
@@ -912,6 +960,11 @@ func (bc *BlockChain) Stop() {
 
 	log.Info("Closing quit channel")
 	close(bc.quit)
+
+	if bc.onlinePruner != nil {
+		log.Info("Stopping online pruner")
+		bc.onlinePruner.Stop()
+	}
 	// Wait for accepted feed to process all remaining items
 	log.Info("Stopping Acceptor")
 	start := time.Now()
@@ -2057,7 +2110,13 @@ func (bc *BlockChain) ResetToStateSyncedBlock(block *types.Block) error {
 	bc.hc.SetCurrentHeader(block.Header())
 
 	lastAcceptedHash := block.Hash()
-	bc.stateCache = state.NewDatabaseWithConfig(bc.db, &trie.Config{
+	stateDB := bc.db
+	if bc.onlinePruner != nil {
+		// The live state of the running session is replaced
+		bc.onlinePruner.Stop()
+		stateDB = bc.onlinePruner.Database()
+	}
+	bc.stateCache = state.NewDatabaseWithConfig(stateDB, &trie.Config{
 		Cache:       bc.cacheConfig.TrieCleanLimit,
 		Journal:     bc.cacheConfig.TrieCleanJournal,
 		Preimages:   bc.cacheConfig.Preimages,
@@ -0,0 +1,146 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// implementationSlot is the EIP-1967 slot of the system contract proxies
+// holding the address of their implementation
+var implementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+// contractUpgrade replaces the implementation behind a system contract proxy
+// when a network upgrade activates
+type contractUpgrade struct {
+	// fork returns the activation timestamp of the network upgrade
+	fork func(*params.ChainConfig) *big.Int
+	// proxy returns the address of the system contract proxy
+	proxy func(*params.SystemContracts) common.Address
+	// replaces are the code hashes of the implementations which are
+	// upgraded: the one deployed in the genesis and the previous upgrades.
+	// Implementations installed through the proxy by governance are kept.
+	replaces []common.Hash
+	// artifact is the build output in contracts/build_contracts the code is
+	// taken from, along with the solc metadata of its source
+	artifact string
+	// code is the runtime code of the new implementation
+	code []byte
+}
+
+var (
+	// Code hashes of the implementations deployed by [Genesis.PreDeploy]
+	adminGenesisCodeHash    = common.HexToHash("0x8c4e2c3881f6f20e48021c755089265f0615568c3e7fdaf8f1ccdecb15542a6e")
+	multisigGenesisCodeHash = common.HexToHash("0x2de762de31918ff461d22621f81365dbff0c2e484ae970efb3d633f4374baaf1")
+
+	// CaminoAdmin (contracts/admin.sol) with the dynamic base fee bounds,
+	// compiled with 0.8.21+commit.d9974bed, 200 runs, evm version london
+	adminSunrisePhase1Code = common.Hex2Bytes("608060405234801561001057600080fd5b50600436106100cf5760003560e01c80635c97f4a21161008c578063bb03fa1d11610066578063bb03fa1d146101ba578063ce6ccfaf146101e3578063e6da64611461020c578063fdff0b261461022757600080fd5b80635c97f4a2146101715780637f786f4b146101945780639a11b2e8146101a757600080fd5b80630900f010146100d45780630912ed77146100e957806315e812ad146100fc5780633c09e2fd1461011357806346860698146101265780634de525d914610139575b600080fd5b6100e76100e236600461069b565b61023a565b005b6100e76100f73660046106b6565b6102cf565b6001545b6040519081526020015b60405180910390f35b6100e76101213660046106b6565b610306565b6100e76101343660046106e0565b610338565b610100610147366004610711565b6001600160a01b039190911663ffffffff60a01b604092831c161760009081526003602052205490565b61018461017f3660046106b6565b61039d565b604051901515815260200161010a565b6100e76101a2366004610744565b6103b0565b6100e76101b5366004610766565b610471565b6101006101c836600461069b565b6001600160a01b031660009081526002602052604090205490565b6101006101f136600461069b565b6001600160a01b031660009081526020819052604090205490565b6004546005546040805192835260208301919091520161010a565b6100e76102353660046107aa565b610527565b60016102463382610579565b61026b5760405162461bcd60e51b8152600401610262906107e6565b60405180910390fd5b604051636bc26a1360e11b81526001600160a01b0383166004820152600a600160981b019063d784d42690602401600060405180830381600087803b1580156102b357600080fd5b505af11580156102c7573d6000803e3d6000fd5b505050505050565b60016102db3382610579565b6102f75760405162461bcd60e51b8152600401610262906107e6565b610301838361059a565b505050565b60016103123382610579565b61032e5760405162461bcd60e51b8152600401610262906107e6565b61030183836105ed565b60026103443382610579565b6103605760405162461bcd60e51b8152600401610262906107e6565b60018290556040518281527f1c053aa9b674900648619554980ac10e913d661c372ca30455e1e4ec0ce44071906020015b60405180910390a15050565b60006103a98383610579565b9392505050565b60026103bc3382610579565b6103d85760405162461bcd60e51b8152600401610262906107e6565b8115806103e55750818311155b6104295760405162461bcd60e51b8152602060048201526015602482015274666c6f6f722065786365656473206365696c696e6760581b6044820152606401610262565b6004839055600582905560408051848152602081018490527facaa3de37b91392ae66b6312bd53eb15960206ea6c6f2f7afaf185a3fe3d7afc910160405180910390a1505050565b600461047d3382610579565b6104995760405162461bcd60e51b8152600401610262906107e6565b6001600160a01b03841660009081526002602052604081205490846104c0578382176104c5565b831982165b6001600160a01b038716600081815260026020908152604091829020849055815186815290810184905292935090917ff64784c1c207eed151b4adc53adde03b1c3a4ecad6b8de3a65539d464b3e1add910160405180910390a2505050505050565b60086105333382610579565b61054f5760405162461bcd60e51b8152600401610262906107e6565b506001600160a01b039290921663ffffffff60a01b604092831c1617600090815260036020522055565b6001600160a01b039190911660009081526020819052604090205416151590565b6001600160a01b0382166000818152602081815260409182902080548519169055815192835282018390527fcfa5316bd1be4ceb62f363b0a162f322c33ba870641138cd8600dd4fa603fc3b9101610391565b600881111561062d5760405162461bcd60e51b815260206004820152600c60248201526b556e6b6e6f776e20526f6c6560a01b6044820152606401610262565b6001600160a01b03821660008181526020818152604091829020805485179055815192835282018390527f385a9c70004a48177c93b74796d77d5ebf7e1248f9e2369624514da454cd01b09101610391565b80356001600160a01b038116811461069657600080fd5b919050565b6000602082840312156106ad57600080fd5b6103a98261067f565b600080604083850312156106c957600080fd5b6106d28361067f565b946020939093013593505050565b6000602082840312156106f257600080fd5b5035919050565b80356001600160e01b03198116811461069657600080fd5b6000806040838503121561072457600080fd5b61072d8361067f565b915061073b602084016106f9565b90509250929050565b6000806040838503121561075757600080fd5b50508035926020909101359150565b60008060006060848603121561077b57600080fd5b6107848461067f565b92506020840135801515811461079957600080fd5b929592945050506040919091013590565b6000806000606084860312156107bf57600080fd5b6107c88461067f565b92506107d6602085016106f9565b9150604084013590509250925092565b6020808252600d908201526c1058d8d95cdcc819195b9a5959609a1b60408201526060019056fea264697066735822122083b02aa450dc64006bc2c227fa9007981a89c8c1837c17748ca26a19ddf109db64736f6c63430008150033")
+
+	// CaminoAdmin (contracts/admin.sol) with the scheduled base fee,
+	// compiled with 0.8.21+commit.d9974bed, 200 runs, evm version london
+	adminSunrisePhase2Code = common.Hex2Bytes("608060405234801561001057600080fd5b50600436106100f55760003560e01c80635c97f4a211610097578063ce6ccfaf11610066578063ce6ccfaf1461022a578063e6da646114610253578063f9c9063b1461025e578063fdff0b261461027157600080fd5b80635c97f4a2146101b85780637f786f4b146101db5780639a11b2e8146101ee578063bb03fa1d1461020157600080fd5b806315e812ad116100d357806315e812ad146101445780633c09e2fd1461015a578063468606981461016d5780634de525d91461018057600080fd5b806302e39d81146100fa5780630900f0101461011c5780630912ed7714610131575b600080fd5b610102610284565b604080519283526020830191909152015b60405180910390f35b61012f61012a366004610825565b6102b4565b005b61012f61013f366004610840565b610349565b61014c610380565b604051908152602001610113565b61012f610168366004610840565b61038f565b61012f61017b36600461086a565b6103c1565b61014c61018e36600461089b565b6001600160a01b039190911663ffffffff60a01b604092831c161760009081526003602052205490565b6101cb6101c6366004610840565b610430565b6040519015158152602001610113565b61012f6101e93660046108ce565b610443565b61012f6101fc3660046108f0565b610505565b61014c61020f366004610825565b6001600160a01b031660009081526002602052604090205490565b61014c610238366004610825565b6001600160a01b031660009081526020819052604090205490565b600454600554610102565b61012f61026c3660046108ce565b6105bb565b61012f61027f366004610934565b610687565b6000806007546000148061029a57506007544210155b156102a85750600091829150565b50506006546007549091565b60016102c033826106d9565b6102e55760405162461bcd60e51b81526004016102dc90610970565b60405180910390fd5b604051636bc26a1360e11b81526001600160a01b0383166004820152600a600160981b019063d784d42690602401600060405180830381600087803b15801561032d57600080fd5b505af1158015610341573d6000803e3d6000fd5b505050505050565b600161035533826106d9565b6103715760405162461bcd60e51b81526004016102dc90610970565b61037b83836106fa565b505050565b600061038a61074d565b905090565b600161039b33826106d9565b6103b75760405162461bcd60e51b81526004016102dc90610970565b61037b8383610777565b60026103cd33826106d9565b6103e95760405162461bcd60e51b81526004016102dc90610970565b6001829055600060068190556007556040518281527f1c053aa9b674900648619554980ac10e913d661c372ca30455e1e4ec0ce44071906020015b60405180910390a15050565b600061043c83836106d9565b9392505050565b600261044f33826106d9565b61046b5760405162461bcd60e51b81526004016102dc90610970565b8115806104785750818311155b6104bc5760405162461bcd60e51b8152602060048201526015602482015274666c6f6f722065786365656473206365696c696e6760581b60448201526064016102dc565b6004839055600582905560408051848152602081018490527facaa3de37b91392ae66b6312bd53eb15960206ea6c6f2f7afaf185a3fe3d7afc91015b60405180910390a1505050565b600461051133826106d9565b61052d5760405162461bcd60e51b81526004016102dc90610970565b6001600160a01b038416600090815260026020526040812054908461055457838217610559565b831982165b6001600160a01b038716600081815260026020908152604091829020849055815186815290810184905292935090917ff64784c1c207eed151b4adc53adde03b1c3a4ecad6b8de3a65539d464b3e1add910160405180910390a2505050505050565b60026105c733826106d9565b6105e35760405162461bcd60e51b81526004016102dc90610970565b42821161063c5760405162461bcd60e51b815260206004820152602160248201527f61637469766174696f6e2074696d65206e6f7420696e207468652066757475726044820152606560f81b60648201526084016102dc565b61064461074d565b6001556006839055600782905560408051848152602081018490527fb2e2364a8d535fae11bfe86b68a34ade78081c8b5083d3f3663d285bcb20674591016104f8565b600861069333826106d9565b6106af5760405162461bcd60e51b81526004016102dc90610970565b506001600160a01b039290921663ffffffff60a01b604092831c1617600090815260036020522055565b6001600160a01b039190911660009081526020819052604090205416151590565b6001600160a01b0382166000818152602081815260409182902080548519169055815192835282018390527fcfa5316bd1be4ceb62f363b0a162f322c33ba870641138cd8600dd4fa603fc3b9101610424565b600060075460001415801561076457506007544210155b15610770575060065490565b5060015490565b60088111156107b75760405162461bcd60e51b815260206004820152600c60248201526b556e6b6e6f776e20526f6c6560a01b60448201526064016102dc565b6001600160a01b03821660008181526020818152604091829020805485179055815192835282018390527f385a9c70004a48177c93b74796d77d5ebf7e1248f9e2369624514da454cd01b09101610424565b80356001600160a01b038116811461082057600080fd5b919050565b60006020828403121561083757600080fd5b61043c82610809565b6000806040838503121561085357600080fd5b61085c83610809565b946020939093013593505050565b60006020828403121561087c57600080fd5b5035919050565b80356001600160e01b03198116811461082057600080fd5b600080604083850312156108ae57600080fd5b6108b783610809565b91506108c560208401610883565b90509250929050565b600080604083850312156108e157600080fd5b50508035926020909101359150565b60008060006060848603121561090557600080fd5b61090e84610809565b92506020840135801515811461092357600080fd5b929592945050506040919091013590565b60008060006060848603121561094957600080fd5b61095284610809565b925061096060208501610883565b9150604084013590509250925092565b6020808252600d908201526c1058d8d95cdcc819195b9a5959609a1b60408201526060019056fea2646970667358221220fff271cc94c944cb43fb2456d3ce5e19282ee57d8aec07b00f308996e3b2d10764736f6c63430008150033")
+
+	// MultisigData (contracts/multisig.sol) writing aliases and nonces,
+	// compiled with 0.8.21+commit.d9974bed, 200 runs, evm version london
+	multisigSunrisePhase2Code = common.Hex2Bytes("608060405234801561001057600080fd5b506004361061004c5760003560e01c80632d0335ab1461005157806399900d111461008d578063c3bf2ba4146100ad578063dc35b3b2146100cd575b600080fd5b61007a61005f3660046104d7565b6001600160a01b031660009081526001602052604090205490565b6040519081526020015b60405180910390f35b6100a061009b3660046104d7565b6100e2565b60405161008491906104f9565b61007a6100bb3660046104d7565b60006020819052908152604090205481565b6100e06100db36600461055a565b610184565b005b6040805180820190915260008152606060208201526001600160a01b0382166000908152602081815260409182902082518084018452815481526001820180548551818602810186019096528086529194929385810193929083018282801561017457602002820191906000526020600020905b81546001600160a01b03168152600190910190602001808311610156575b5050505050815250509050919050565b604051632e4bfa5160e11b815233600482015260016024820152600a600160981b0190635c97f4a290604401602060405180830381865afa1580156101cd573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906101f191906105e4565b6102325760405162461bcd60e51b815260206004820152600d60248201526c1058d8d95cdcc819195b9a5959609a1b60448201526064015b60405180910390fd5b6101008111156102765760405162461bcd60e51b815260206004820152600f60248201526e746f6f206d616e79206f776e65727360881b6044820152606401610229565b80156102915760008311801561028c5750808311155b610294565b82155b6102d45760405162461bcd60e51b81526020600482015260116024820152701a5b9d985b1a59081d1a1c995cda1bdb19607a1b6044820152606401610229565b60015b8181101561039c578282828181106102f1576102f1610606565b905060200201602081019061030691906104d7565b6001600160a01b0316838361031c600185610632565b81811061032b5761032b610606565b905060200201602081019061034091906104d7565b6001600160a01b03161061038a5760405162461bcd60e51b81526020600482015260116024820152701bdddb995c9cc81b9bdd081cdbdc9d1959607a1b6044820152606401610229565b806103948161064b565b9150506102d7565b506001600160a01b03841660009081526020819052604090208381556103c6600182018484610443565b506001600160a01b0385166000908152600160205260408120805482906103ec9061064b565b9190508190559050856001600160a01b03167f67d8534a1087ab3e04966ead6f042693b590cce0914604a1ebc6c9ad0cd99896868686856040516104339493929190610664565b60405180910390a2505050505050565b828054828255906000526020600020908101928215610496579160200282015b828111156104965781546001600160a01b0319166001600160a01b03843516178255602090920191600190910190610463565b506104a29291506104a6565b5090565b5b808211156104a257600081556001016104a7565b80356001600160a01b03811681146104d257600080fd5b919050565b6000602082840312156104e957600080fd5b6104f2826104bb565b9392505050565b60208082528251828201528281015160408084015280516060840181905260009291820190839060808601905b8083101561054f5783516001600160a01b03168252928401926001929092019190840190610526565b509695505050505050565b6000806000806060858703121561057057600080fd5b610579856104bb565b935060208501359250604085013567ffffffffffffffff8082111561059d57600080fd5b818701915087601f8301126105b157600080fd5b8135818111156105c057600080fd5b8860208260051b85010111156105d557600080fd5b95989497505060200194505050565b6000602082840312156105f657600080fd5b815180151581146104f257600080fd5b634e487b7160e01b600052603260045260246000fd5b634e487b7160e01b600052601160045260246000fd5b818103818111156106455761064561061c565b92915050565b60006001820161065d5761065d61061c565b5060010190565b84815260606020808301829052908201849052600090859060808401835b878110156106ae576001600160a01b0361069b856104bb565b1682529282019290820190600101610682565b508093505050508260408301529594505050505056fea264697066735822122080009efb04c8ca130136134ffa8dcff22328003aa69a9b1d5cc7fd9c048109a164736f6c63430008150033")
+)
+
+// contractUpgrades are ordered by fork, so that the latest implementation of a
+// proxy is set if several forks activate at once
+var contractUpgrades = []contractUpgrade{
+	{
+		fork:     func(c *params.ChainConfig) *big.Int { return c.SunrisePhase1BlockTimestamp },
+		proxy:    func(s *params.SystemContracts) common.Address { return s.AdminAddress },
+		replaces: []common.Hash{adminGenesisCodeHash},
+		artifact: "admin/bin/CaminoAdmin.sunrisePhase1",
+		code:     adminSunrisePhase1Code,
+	},
+	{
+		fork:     func(c *params.ChainConfig) *big.Int { return c.SunrisePhase2BlockTimestamp },
+		proxy:    func(s *params.SystemContracts) common.Address { return s.AdminAddress },
+		replaces: []common.Hash{adminGenesisCodeHash, crypto.Keccak256Hash(adminSunrisePhase1Code)},
+		artifact: "admin/bin/CaminoAdmin.sunrisePhase2",
+		code:     adminSunrisePhase2Code,
+	},
+	{
+		fork:     func(c *params.ChainConfig) *big.Int { return c.SunrisePhase2BlockTimestamp },
+		proxy:    func(s *params.SystemContracts) common.Address { return s.MultisigAddress },
+		replaces: []common.Hash{multisigGenesisCodeHash},
+		artifact: "multisig/bin/MultisigData.sunrisePhase2",
+		code:     multisigSunrisePhase2Code,
+	},
+}
+
+// ApplyContractUpgrades replaces the implementations of the system contracts
+// whose network upgrade activates in the transition from [parentTimestamp] to
+// [timestamp]. The implementation is the one the proxy active at [timestamp]
+// points to; proxies without implementation are skipped. An implementation is
+// only replaced if it is one the upgrade replaces and no other system contract
+// proxy points to it.
+// This function is called within genesis setup, with a nil [parentTimestamp],
+// and before processing every block.
+func ApplyContractUpgrades(config *params.ChainConfig, parentTimestamp *big.Int, timestamp *big.Int, statedb *state.StateDB) {
+	contracts := config.CaminoSystemContracts(timestamp)
+	for _, upgrade := range contractUpgrades {
+		if !utils.IsForkTransition(upgrade.fork(config), parentTimestamp, timestamp) {
+			continue
+		}
+		proxy := upgrade.proxy(contracts)
+		if proxy == (common.Address{}) {
+			continue
+		}
+		implementation := proxyImplementation(statedb, proxy)
+		if implementation == (common.Address{}) {
+			continue
+		}
+		if codeHash := statedb.GetCodeHash(implementation); !upgrade.isReplaced(codeHash) {
+			log.Warn("Skipping upgrade of a system contract implementation installed by governance",
+				"proxy", proxy, "implementation", implementation, "codeHash", codeHash)
+			continue
+		}
+		if other, shared := sharedImplementation(contracts, statedb, proxy, implementation); shared {
+			log.Warn("Skipping upgrade of a system contract implementation shared with another proxy",
+				"proxy", proxy, "implementation", implementation, "other", other)
+			continue
+		}
+		statedb.SetCode(implementation, upgrade.code)
+	}
+}
+
+// isReplaced returns true if the implementation with [codeHash] is upgraded
+func (u *contractUpgrade) isReplaced(codeHash common.Hash) bool {
+	for _, replaced := range u.replaces {
+		if replaced == codeHash {
+			return true
+		}
+	}
+	return false
+}
+
+// proxyImplementation returns the implementation [proxy] points to
+func proxyImplementation(statedb *state.StateDB, proxy common.Address) common.Address {
+	return common.BytesToAddress(statedb.GetState(proxy, implementationSlot).Bytes())
+}
+
+// sharedImplementation returns the system contract proxy other than [proxy]
+// pointing to [implementation], if any
+func sharedImplementation(contracts *params.SystemContracts, statedb *state.StateDB, proxy, implementation common.Address) (common.Address, bool) {
+	for _, other := range []common.Address{contracts.AdminAddress, contracts.FeeRewardAddress, contracts.MultisigAddress} {
+		if other == proxy || other == (common.Address{}) {
+			continue
+		}
+		if proxyImplementation(statedb, other) == implementation {
+			return other, true
+		}
+	}
+	return common.Address{}, false
+}
@@ -0,0 +1,350 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/coreth/accounts/abi"
+	"github.com/ava-labs/coreth/consensus/dummy"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/core/vm"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// contractUpgradeCode returns the code of the last upgrade of [proxy]
+// scheduled by [config]
+func contractUpgradeCode(config *params.ChainConfig, proxy common.Address) []byte {
+	var code []byte
+	for _, upgrade := range contractUpgrades {
+		if fork := upgrade.fork(config); fork != nil && upgrade.proxy(config.CaminoSystemContracts(fork)) == proxy {
+			code = upgrade.code
+		}
+	}
+	return code
+}
+
+func TestMultisigContractUpgrade(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		key, _    = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		adminAddr = crypto.PubkeyToAddress(key.PublicKey)
+		alias     = common.Address{0xaa}
+		owners    = []common.Address{{0x01}, {0x02}, {0x03}}
+		contracts = params.DefaultSystemContracts
+		db        = rawdb.NewMemoryDatabase()
+		config    = *params.TestSunrisePhase0Config
+	)
+	config.SunrisePhase2BlockTimestamp = big.NewInt(10)
+
+	abiJSON, err := os.ReadFile("../contracts/build_contracts/multisig/abi/MultisigData.abi")
+	require.NoError(err)
+	multisigABI, err := abi.JSON(strings.NewReader(string(abiJSON)))
+	require.NoError(err)
+
+	gspec := &Genesis{
+		Config:       &config,
+		Alloc:        GenesisAlloc{adminAddr: {Balance: big.NewInt(params.Ether)}},
+		InitialAdmin: adminAddr,
+		BaseFee:      big.NewInt(params.ApricotPhase3InitialBaseFee),
+	}
+	require.NoError(gspec.PreDeploy())
+	genesis := gspec.MustCommit(db)
+
+	// The implementation of the genesis is kept until Sunrise Phase 2
+	implementation := common.HexToAddress("0x010000000000000000000000000000000000000f")
+	statedb, err := state.New(genesis.Root(), state.NewDatabase(db), nil)
+	require.NoError(err)
+	require.Equal(gspec.Alloc[implementation].Code, statedb.GetCode(implementation))
+	upgradedCode := contractUpgradeCode(&config, contracts.MultisigAddress)
+	require.NotEqual(upgradedCode, statedb.GetCode(implementation))
+
+	setAlias := func(gen *BlockGen, threshold int64, ctrlGroup []common.Address) {
+		data, err := multisigABI.Pack("setAlias", alias, big.NewInt(threshold), ctrlGroup)
+		require.NoError(err)
+		tx := types.NewTransaction(gen.TxNonce(adminAddr), contracts.MultisigAddress, nil, 500_000, gen.BaseFee(), data)
+		signedTx, err := types.SignTx(tx, types.LatestSigner(&config), key)
+		require.NoError(err)
+		gen.AddTx(signedTx)
+	}
+	chain, receipts, err := GenerateChain(&config, genesis, dummy.NewFaker(), db, 2, 10, func(i int, gen *BlockGen) {
+		switch i {
+		case 0:
+			setAlias(gen, 2, owners)
+		case 1:
+			// The control group must be sorted
+			setAlias(gen, 1, []common.Address{owners[1], owners[0]})
+			setAlias(gen, 1, owners[:1])
+		}
+	})
+	require.NoError(err)
+	require.Equal(types.ReceiptStatusSuccessful, receipts[0][0].Status)
+	require.Equal(types.ReceiptStatusFailed, receipts[1][0].Status)
+	require.Equal(types.ReceiptStatusSuccessful, receipts[1][1].Status)
+
+	// The blocks are processed like they were generated
+	chainDB := rawdb.NewMemoryDatabase()
+	gspec.MustCommit(chainDB)
+	blockchain, err := NewBlockChain(chainDB, DefaultCacheConfig, &config, dummy.NewFaker(), vm.Config{}, common.Hash{})
+	require.NoError(err)
+	defer blockchain.Stop()
+	_, err = blockchain.InsertChain(chain)
+	require.NoError(err)
+
+	// The aliases written by the upgraded contract are read by the node
+	for i, expected := range []*secp256k1fx.OutputOwners{
+		{Threshold: 2, Addrs: []ids.ShortID{ids.ShortID(owners[0]), ids.ShortID(owners[1]), ids.ShortID(owners[2])}},
+		{Threshold: 1, Addrs: []ids.ShortID{ids.ShortID(owners[0])}},
+	} {
+		statedb, err := blockchain.StateAt(chain[i].Root())
+		require.NoError(err)
+		require.Equal(upgradedCode, statedb.GetCode(implementation))
+
+		aliasWithNonce, err := NewStateAliasGetter(&contracts, statedb).GetMultisigAlias(ids.ShortID(alias))
+		require.NoError(err)
+		require.Equal(expected, aliasWithNonce.Owners)
+		require.Equal(uint64(i+1), aliasWithNonce.Nonce)
+	}
+}
+
+func TestAdminContractUpgrade(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		key, _    = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		adminAddr = crypto.PubkeyToAddress(key.PublicKey)
+		contracts = params.DefaultSystemContracts
+		db        = rawdb.NewMemoryDatabase()
+		config    = *params.TestSunrisePhase0Config
+	)
+	config.SunrisePhase1BlockTimestamp = big.NewInt(20)
+	config.SunrisePhase2BlockTimestamp = big.NewInt(30)
+
+	abiJSON, err := os.ReadFile("../contracts/build_contracts/admin/abi/CaminoAdmin.abi")
+	require.NoError(err)
+	adminABI, err := abi.JSON(strings.NewReader(string(abiJSON)))
+	require.NoError(err)
+
+	gspec := &Genesis{
+		Config:       &config,
+		Alloc:        GenesisAlloc{adminAddr: {Balance: big.NewInt(params.Ether)}},
+		InitialAdmin: adminAddr,
+		BaseFee:      big.NewInt(params.ApricotPhase3InitialBaseFee),
+	}
+	require.NoError(gspec.PreDeploy())
+	genesis := gspec.MustCommit(db)
+
+	call := func(gen *BlockGen, method string, args ...interface{}) {
+		data, err := adminABI.Pack(method, args...)
+		require.NoError(err)
+		tx := types.NewTransaction(gen.TxNonce(adminAddr), contracts.AdminAddress, nil, 500_000, gen.BaseFee(), data)
+		signedTx, err := types.SignTx(tx, types.LatestSigner(&config), key)
+		require.NoError(err)
+		gen.AddTx(signedTx)
+	}
+	floor, ceiling := big.NewInt(50_000_000_000), big.NewInt(500_000_000_000)
+	scheduledFee, activation := big.NewInt(25_000_000_000), big.NewInt(1_000)
+	chain, receipts, err := GenerateChain(&config, genesis, dummy.NewFaker(), db, 3, 10, func(i int, gen *BlockGen) {
+		switch i {
+		case 0:
+			call(gen, "grantRole", adminAddr, big.NewInt(2))
+			// The implementation of the genesis has no base fee bounds
+			call(gen, "setBaseFeeBounds", floor, ceiling)
+		case 1:
+			// The floor must not exceed the ceiling
+			call(gen, "setBaseFeeBounds", ceiling, floor)
+			call(gen, "setBaseFeeBounds", floor, ceiling)
+			// Base fees are scheduled as of Sunrise Phase 2
+			call(gen, "scheduleBaseFee", scheduledFee, activation)
+		case 2:
+			call(gen, "scheduleBaseFee", scheduledFee, activation)
+		}
+	})
+	require.NoError(err)
+	for i, statuses := range [][]uint64{
+		{types.ReceiptStatusSuccessful, types.ReceiptStatusFailed},
+		{types.ReceiptStatusFailed, types.ReceiptStatusSuccessful, types.ReceiptStatusFailed},
+		{types.ReceiptStatusSuccessful},
+	} {
+		require.Len(receipts[i], len(statuses))
+		for j, status := range statuses {
+			require.Equal(status, receipts[i][j].Status, "block %d, tx %d", i, j)
+		}
+	}
+
+	// The blocks are processed like they were generated
+	chainDB := rawdb.NewMemoryDatabase()
+	gspec.MustCommit(chainDB)
+	blockchain, err := NewBlockChain(chainDB, DefaultCacheConfig, &config, dummy.NewFaker(), vm.Config{}, common.Hash{})
+	require.NoError(err)
+	defer blockchain.Stop()
+	_, err = blockchain.InsertChain(chain)
+	require.NoError(err)
+
+	// The settings written by the upgraded contracts are read by the node
+	implementation := common.HexToAddress("0x010000000000000000000000000000000000000b")
+	phase1State, err := blockchain.StateAt(chain[1].Root())
+	require.NoError(err)
+	statedb, err := blockchain.StateAt(chain[2].Root())
+	require.NoError(err)
+	require.NotEqual(phase1State.GetCode(implementation), statedb.GetCode(implementation))
+	require.Equal(contractUpgradeCode(&config, contracts.AdminAddress), statedb.GetCode(implementation))
+	require.Equal(floor, statedb.GetState(contracts.AdminAddress, contracts.AdminBaseFeeFloorSlot).Big())
+	require.Equal(ceiling, statedb.GetState(contracts.AdminAddress, contracts.AdminBaseFeeCeilingSlot).Big())
+	require.Equal(scheduledFee, statedb.GetState(contracts.AdminAddress, contracts.AdminPendingBaseFeeSlot).Big())
+	require.Equal(activation, statedb.GetState(contracts.AdminAddress, contracts.AdminPendingBaseFeeTimeSlot).Big())
+}
+
+func TestContractUpgradeArtifacts(t *testing.T) {
+	require := require.New(t)
+
+	// Sources of the latest release of each contract
+	latest := map[string]map[string]common.Hash{}
+	for _, upgrade := range contractUpgrades {
+		// The code is the runtime code built from the contracts
+		bin, err := os.ReadFile(filepath.Join("../contracts/build_contracts", upgrade.artifact+".bin-runtime"))
+		require.NoError(err)
+		require.Equal(strings.TrimSpace(string(bin)), common.Bytes2Hex(upgrade.code), upgrade.artifact)
+
+		// The metadata embedded in the code is the one of the artifact
+		rawMetadata, err := os.ReadFile(filepath.Join("../contracts/build_contracts", upgrade.artifact+".metadata.json"))
+		require.NoError(err)
+		require.Contains(common.Bytes2Hex(upgrade.code), common.Bytes2Hex(metadataMultihash(rawMetadata)), upgrade.artifact)
+
+		var metadata struct {
+			Settings struct {
+				CompilationTarget map[string]string `json:"compilationTarget"`
+			} `json:"settings"`
+			Sources map[string]struct {
+				Keccak256 common.Hash `json:"keccak256"`
+			} `json:"sources"`
+		}
+		require.NoError(json.Unmarshal(rawMetadata, &metadata))
+		require.Len(metadata.Settings.CompilationTarget, 1)
+		for target := range metadata.Settings.CompilationTarget {
+			latest[target] = map[string]common.Hash{}
+			for source, hashes := range metadata.Sources {
+				latest[target][source] = hashes.Keccak256
+			}
+		}
+	}
+
+	// The latest release of a contract is built from the sources in the tree
+	for target, sources := range latest {
+		for source, hash := range sources {
+			code, err := os.ReadFile(filepath.Join("../contracts", source))
+			require.NoError(err)
+			require.Equal(hash, crypto.Keccak256Hash(code), "%s: %s", target, source)
+		}
+	}
+}
+
+// metadataMultihash returns the IPFS multihash of the solc [metadata] which
+// solc appends to the runtime code
+func metadataMultihash(metadata []byte) []byte {
+	varint := func(v int) []byte {
+		buf := make([]byte, binary.MaxVarintLen64)
+		return buf[:binary.PutUvarint(buf, uint64(v))]
+	}
+	unixfs := append([]byte{0x08, 0x02, 0x12}, varint(len(metadata))...)
+	unixfs = append(unixfs, metadata...)
+	unixfs = append(append(unixfs, 0x18), varint(len(metadata))...)
+	node := append(append([]byte{0x0a}, varint(len(unixfs))...), unixfs...)
+	digest := sha256.Sum256(node)
+	return append([]byte{0x12, 0x20}, digest[:]...)
+}
+
+func TestContractUpgradeGenesisCode(t *testing.T) {
+	require := require.New(t)
+
+	gspec := &Genesis{Config: params.TestSunrisePhase0Config, Alloc: GenesisAlloc{}}
+	require.NoError(gspec.PreDeploy())
+
+	// The first implementation replaced is the one of the genesis
+	contracts := params.DefaultSystemContracts
+	replaced := map[common.Address]bool{}
+	for _, upgrade := range contractUpgrades {
+		proxy := upgrade.proxy(&contracts)
+		if replaced[proxy] {
+			continue
+		}
+		replaced[proxy] = true
+		implementation := common.BytesToAddress(gspec.Alloc[proxy].Storage[implementationSlot].Bytes())
+		require.Equal(crypto.Keccak256Hash(gspec.Alloc[implementation].Code), upgrade.replaces[0], upgrade.artifact)
+	}
+}
+
+func TestContractUpgradeKeepsImplementation(t *testing.T) {
+	config := *params.TestSunrisePhase0Config
+	config.SunrisePhase2BlockTimestamp = big.NewInt(10)
+	contracts := params.DefaultSystemContracts
+
+	tests := map[string]struct {
+		setup    func(*state.StateDB) common.Address
+		upgraded bool
+	}{
+		"genesis implementation": {
+			setup: func(statedb *state.StateDB) common.Address {
+				return common.BytesToAddress(statedb.GetState(contracts.MultisigAddress, implementationSlot).Bytes())
+			},
+			upgraded: true,
+		},
+		"implementation installed by governance": {
+			setup: func(statedb *state.StateDB) common.Address {
+				implementation := common.Address{0xbb}
+				statedb.SetCode(implementation, []byte{0x60, 0x00})
+				statedb.SetState(contracts.MultisigAddress, implementationSlot, common.BytesToHash(implementation.Bytes()))
+				return implementation
+			},
+		},
+		"implementation shared with another proxy": {
+			setup: func(statedb *state.StateDB) common.Address {
+				implementation := common.BytesToAddress(statedb.GetState(contracts.MultisigAddress, implementationSlot).Bytes())
+				statedb.SetState(contracts.FeeRewardAddress, implementationSlot, common.BytesToHash(implementation.Bytes()))
+				return implementation
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			gspec := &Genesis{Config: &config, Alloc: GenesisAlloc{}}
+			require.NoError(gspec.PreDeploy())
+			statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+			require.NoError(err)
+			for addr, account := range gspec.Alloc {
+				statedb.SetCode(addr, account.Code)
+				for key, value := range account.Storage {
+					statedb.SetState(addr, key, value)
+				}
+			}
+
+			implementation := tt.setup(statedb)
+			code := statedb.GetCode(implementation)
+			ApplyContractUpgrades(&config, big.NewInt(0), big.NewInt(10), statedb)
+			if tt.upgraded {
+				require.Equal(contractUpgradeCode(&config, contracts.MultisigAddress), statedb.GetCode(implementation))
+			} else {
+				require.Equal(code, statedb.GetCode(implementation))
+			}
+		})
+	}
+}
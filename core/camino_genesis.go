@@ -0,0 +1,39 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var errSystemContractNoCode = errors.New("system contract has no code")
+
+// verifySystemContracts checks that the system contracts configured in
+// [config] and active at [timestamp] are deployed in [statedb]. The default
+// layout is deployed by [Genesis.PreDeploy] and is not checked.
+func verifySystemContracts(config *params.ChainConfig, timestamp uint64, statedb *state.StateDB) error {
+	active := config.CaminoSystemContracts(new(big.Int).SetUint64(timestamp))
+	if config.SystemContracts == nil && active == &params.DefaultSystemContracts {
+		return nil
+	}
+	contracts := map[string]common.Address{
+		"admin":      active.AdminAddress,
+		"fee reward": active.FeeRewardAddress,
+	}
+	if active.MultisigAddress != (common.Address{}) {
+		contracts["multisig"] = active.MultisigAddress
+	}
+	for name, addr := range contracts {
+		if statedb.GetCodeSize(addr) == 0 {
+			return fmt.Errorf("%w: %s contract at %s", errSystemContractNoCode, name, addr)
+		}
+	}
+	return nil
+}
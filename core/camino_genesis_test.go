@@ -0,0 +1,101 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenesisVerifySystemContracts(t *testing.T) {
+	config := *params.TestChainConfig
+	contracts := params.DefaultSystemContracts
+	contracts.AdminAddress = common.Address{1}
+	config.SystemContracts = &contracts
+
+	newGenesis := func() *Genesis {
+		g := &Genesis{
+			Config:       &config,
+			Alloc:        GenesisAlloc{},
+			InitialAdmin: common.Address{2},
+			BaseFee:      big.NewInt(params.ApricotPhase3InitialBaseFee),
+		}
+		require.NoError(t, g.PreDeploy())
+		return g
+	}
+
+	// The relocated admin contract is not deployed
+	_, err := newGenesis().Commit(rawdb.NewMemoryDatabase())
+	require.ErrorIs(t, err, errSystemContractNoCode)
+
+	g := newGenesis()
+	g.Alloc[contracts.AdminAddress] = g.Alloc[params.DefaultSystemContracts.AdminAddress]
+	_, err = g.Commit(rawdb.NewMemoryDatabase())
+	require.NoError(t, err)
+
+	// Without explicit configuration nothing is verified
+	g = &Genesis{
+		Config:  params.TestChainConfig,
+		Alloc:   GenesisAlloc{},
+		BaseFee: big.NewInt(params.ApricotPhase3InitialBaseFee),
+	}
+	_, err = g.Commit(rawdb.NewMemoryDatabase())
+	require.NoError(t, err)
+}
+
+func TestGenesisKeepProxyStorage(t *testing.T) {
+	contracts := params.DefaultSystemContracts
+	alias := ids.ShortID{1}
+	owners := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{{2}},
+	}
+	aliasStorage := MultisigAliasStorage(&contracts, alias, owners, 0)
+
+	newGenesis := func(keepProxyStorage bool, storage map[common.Hash]common.Hash) *Genesis {
+		g := &Genesis{
+			Config:           params.TestSunrisePhase0Config,
+			Alloc:            GenesisAlloc{},
+			InitialAdmin:     common.Address{2},
+			BaseFee:          big.NewInt(params.ApricotPhase3InitialBaseFee),
+			KeepProxyStorage: keepProxyStorage,
+		}
+		if storage != nil {
+			g.Alloc[contracts.MultisigAddress] = GenesisAccount{Balance: common.Big0, Storage: storage}
+		}
+		require.NoError(t, g.PreDeploy())
+		return g
+	}
+	preDeployed := newGenesis(false, nil).ToBlock(nil).Hash()
+
+	// The storage allocated to the proxies is replaced by default, so that
+	// the genesis of existing networks is unchanged
+	g := newGenesis(false, aliasStorage)
+	require.Equal(t, preDeployed, g.ToBlock(nil).Hash())
+
+	// The storage is kept if requested, without overwriting the proxy slots
+	storage := map[common.Hash]common.Hash{implementationSlot: {1}}
+	for key, value := range aliasStorage {
+		storage[key] = value
+	}
+	g = newGenesis(true, storage)
+	require.NotEqual(t, preDeployed, g.ToBlock(nil).Hash())
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	for key, value := range g.Alloc[contracts.MultisigAddress].Storage {
+		statedb.SetState(contracts.MultisigAddress, key, value)
+	}
+	require.Equal(t, common.HexToAddress("0x010000000000000000000000000000000000000f"), proxyImplementation(statedb, contracts.MultisigAddress))
+	aliasWithNonce, err := NewStateAliasGetter(&contracts, statedb).GetMultisigAlias(alias)
+	require.NoError(t, err)
+	require.Equal(t, owners, aliasWithNonce.Owners)
+}
@@ -0,0 +1,208 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/precompile"
+	"github.com/ava-labs/coreth/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKycAdminPrecompile(t *testing.T) {
+	var (
+		contracts = params.DefaultSystemContracts
+		account   = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		signature = [4]byte{0xde, 0xad, 0xbe, 0xef}
+		contract  = (&precompile.KycAdminConfig{}).Contract()
+	)
+
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	accessibleState := &mockAccessibleState{
+		state:        statedb,
+		blockContext: &mockBlockContext{blockNumber: common.Big0},
+		chainConfig:  params.TestSunrisePhase0Config,
+	}
+
+	pack := func(function string, args ...[]byte) []byte {
+		input := precompile.CalculateFunctionSelector(function)
+		for _, arg := range args {
+			input = append(input, arg...)
+		}
+		return input
+	}
+	signatureWord := common.RightPadBytes(signature[:], common.HashLength)
+	blacklistSlot := func(account common.Address, signature [4]byte) common.Hash {
+		var key common.Hash
+		copy(key[8:], signature[:])
+		copy(key[12:], account[:])
+		return crypto.Keccak256Hash(key.Bytes(), contracts.AdminBlacklistSlot.Bytes())
+	}
+
+	tests := []struct {
+		name        string
+		setup       func()
+		input       []byte
+		suppliedGas uint64
+		expected    []byte
+		err         string
+	}{
+		{
+			name:        "kycState unset",
+			input:       pack("kycState(address)", account.Hash().Bytes()),
+			suppliedGas: precompile.KycStateGasCost,
+			expected:    common.Hash{}.Bytes(),
+		},
+		{
+			name: "kycState verified",
+			setup: func() {
+				storagePos := crypto.Keccak256Hash(account.Hash().Bytes(), contracts.AdminKycSlot.Bytes())
+				statedb.SetState(contracts.AdminAddress, storagePos, common.BigToHash(big.NewInt(1)))
+			},
+			input:       pack("kycState(address)", account.Hash().Bytes()),
+			suppliedGas: precompile.KycStateGasCost,
+			expected:    common.BigToHash(big.NewInt(1)).Bytes(),
+		},
+		{
+			name:        "kycState out of gas",
+			input:       pack("kycState(address)", account.Hash().Bytes()),
+			suppliedGas: precompile.KycStateGasCost - 1,
+			err:         vmerrs.ErrOutOfGas.Error(),
+		},
+		{
+			name:        "kycState dirty padding",
+			input:       pack("kycState(address)", common.BytesToHash(append([]byte{1}, make([]byte, 31)...)).Bytes()),
+			suppliedGas: precompile.KycStateGasCost,
+			err:         "invalid input to KYC admin precompile",
+		},
+		{
+			name:        "baseFee default",
+			input:       pack("baseFee()"),
+			suppliedGas: precompile.BaseFeeGasCost,
+			expected:    common.BigToHash(new(big.Int).SetUint64(params.SunrisePhase0BaseFee)).Bytes(),
+		},
+		{
+			name:        "baseFee out of gas",
+			input:       pack("baseFee()"),
+			suppliedGas: precompile.BaseFeeGasCost - 1,
+			err:         vmerrs.ErrOutOfGas.Error(),
+		},
+		{
+			name: "baseFee set",
+			setup: func() {
+				statedb.SetState(contracts.AdminAddress, contracts.AdminBaseFeeSlot, common.BigToHash(big.NewInt(42)))
+			},
+			input:       pack("baseFee()"),
+			suppliedGas: precompile.BaseFeeGasCost,
+			expected:    common.BigToHash(big.NewInt(42)).Bytes(),
+		},
+		{
+			name: "baseFee pending",
+			setup: func() {
+				statedb.SetState(contracts.AdminAddress, contracts.AdminPendingBaseFeeSlot, common.BigToHash(big.NewInt(84)))
+				statedb.SetState(contracts.AdminAddress, contracts.AdminPendingBaseFeeTimeSlot, common.BigToHash(big.NewInt(10)))
+			},
+			input:       pack("baseFee()"),
+			suppliedGas: precompile.BaseFeeGasCost,
+			expected:    common.BigToHash(big.NewInt(42)).Bytes(),
+		},
+		{
+			name: "baseFee pending ignored before Sunrise Phase 2",
+			setup: func() {
+				accessibleState.blockContext.timestamp = 10
+			},
+			input:       pack("baseFee()"),
+			suppliedGas: precompile.BaseFeeGasCost,
+			expected:    common.BigToHash(big.NewInt(42)).Bytes(),
+		},
+		{
+			name: "baseFee out of gas after Sunrise Phase 2",
+			setup: func() {
+				accessibleState.chainConfig = params.TestSunrisePhase2Config
+			},
+			input:       pack("baseFee()"),
+			suppliedGas: precompile.BaseFeeScheduledGasCost - 1,
+			err:         vmerrs.ErrOutOfGas.Error(),
+		},
+		{
+			name:        "baseFee pending activated after Sunrise Phase 2",
+			input:       pack("baseFee()"),
+			suppliedGas: precompile.BaseFeeScheduledGasCost,
+			expected:    common.BigToHash(big.NewInt(84)).Bytes(),
+		},
+		{
+			name:        "isBlacklisted unset",
+			input:       pack("isBlacklisted(address,bytes4)", account.Hash().Bytes(), signatureWord),
+			suppliedGas: precompile.IsBlacklistedGasCost,
+			expected:    common.Hash{}.Bytes(),
+		},
+		{
+			name: "isBlacklisted signature",
+			setup: func() {
+				statedb.SetState(contracts.AdminAddress, blacklistSlot(account, signature), common.BigToHash(big.NewInt(1)))
+			},
+			input:       pack("isBlacklisted(address,bytes4)", account.Hash().Bytes(), signatureWord),
+			suppliedGas: precompile.IsBlacklistedGasCost,
+			expected:    common.BigToHash(big.NewInt(1)).Bytes(),
+		},
+		{
+			name: "isBlacklisted whole address",
+			setup: func() {
+				statedb.SetState(contracts.AdminAddress, blacklistSlot(account, signature), common.Hash{})
+				statedb.SetState(contracts.AdminAddress, blacklistSlot(account, [4]byte{}), common.BigToHash(big.NewInt(1)))
+			},
+			input:       pack("isBlacklisted(address,bytes4)", account.Hash().Bytes(), signatureWord),
+			suppliedGas: precompile.IsBlacklistedGasCost,
+			expected:    common.BigToHash(big.NewInt(1)).Bytes(),
+		},
+		{
+			name: "isBlacklisted before Sunrise Phase 2",
+			setup: func() {
+				accessibleState.chainConfig = params.TestSunrisePhase0Config
+			},
+			input:       pack("isBlacklisted(address,bytes4)", account.Hash().Bytes(), signatureWord),
+			suppliedGas: precompile.IsBlacklistedGasCost,
+			expected:    common.Hash{}.Bytes(),
+		},
+		{
+			name: "isBlacklisted admin contract",
+			setup: func() {
+				accessibleState.chainConfig = params.TestSunrisePhase2Config
+				statedb.SetState(contracts.AdminAddress, blacklistSlot(contracts.AdminAddress, [4]byte{}), common.BigToHash(big.NewInt(1)))
+			},
+			input:       pack("isBlacklisted(address,bytes4)", contracts.AdminAddress.Hash().Bytes(), signatureWord),
+			suppliedGas: precompile.IsBlacklistedGasCost,
+			expected:    common.Hash{}.Bytes(),
+		},
+		{
+			name:        "isBlacklisted short input",
+			input:       pack("isBlacklisted(address,bytes4)", account.Hash().Bytes()),
+			suppliedGas: precompile.IsBlacklistedGasCost,
+			err:         "invalid input to KYC admin precompile",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.setup != nil {
+				test.setup()
+			}
+			ret, remainingGas, err := contract.Run(accessibleState, common.Address{}, precompile.KycAdminAddress, test.input, test.suppliedGas, true)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Zero(t, remainingGas)
+			require.Equal(t, test.expected, ret)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// maxAliasOwners bounds the control group read from the EVM state
+const maxAliasOwners = 256
+
+var (
+	_ secp256k1fx.AliasGetter = (*StateAliasGetter)(nil)
+	_ secp256k1fx.AliasGetter = AliasGetters{}
+)
+
+// AliasStateDB is the state required to read multisig aliases
+type AliasStateDB interface {
+	GetState(common.Address, common.Hash) common.Hash
+}
+
+// StateAliasGetter reads the multisig aliases stored in the MultisigData
+// contract (contracts/multisig.sol) from the EVM state
+type StateAliasGetter struct {
+	contracts *params.SystemContracts
+	state     AliasStateDB
+}
+
+// NewStateAliasGetter returns an AliasGetter reading the multisig contract
+// configured in [contracts] from [state]
+func NewStateAliasGetter(contracts *params.SystemContracts, state AliasStateDB) *StateAliasGetter {
+	return &StateAliasGetter{contracts: contracts, state: state}
+}
+
+// GetMultisigAlias returns the alias [id] with the owners stored in
+// aliases[id] and the nonce stored in nonces[id]. Entries without control
+// group or with a threshold which cannot be reached are not aliases.
+func (g *StateAliasGetter) GetMultisigAlias(id ids.ShortID) (*multisig.AliasWithNonce, error) {
+	contract := g.contracts.MultisigAddress
+	if contract == (common.Address{}) {
+		return nil, database.ErrNotFound
+	}
+
+	key := common.Address(id).Hash().Bytes()
+	// struct Owners { uint256 threshold; address[] ctrlGroup; }
+	ownersPos := crypto.Keccak256Hash(key, g.contracts.MultisigAliasesSlot.Bytes())
+	threshold := g.state.GetState(contract, ownersPos).Big()
+	ctrlGroupPos := common.BigToHash(new(big.Int).Add(ownersPos.Big(), common.Big1))
+	ctrlGroupLen := g.state.GetState(contract, ctrlGroupPos).Big()
+	if threshold.Sign() == 0 || ctrlGroupLen.Sign() == 0 ||
+		ctrlGroupLen.Cmp(big.NewInt(maxAliasOwners)) > 0 || threshold.Cmp(ctrlGroupLen) > 0 {
+		return nil, database.ErrNotFound
+	}
+
+	// The array elements are stored from keccak(ctrlGroupPos) on, one per slot
+	addrs := make([]ids.ShortID, ctrlGroupLen.Uint64())
+	elementPos := crypto.Keccak256Hash(ctrlGroupPos.Bytes()).Big()
+	for i := range addrs {
+		addrs[i] = ids.ShortID(common.BytesToAddress(g.state.GetState(contract, common.BigToHash(elementPos)).Bytes()))
+		elementPos.Add(elementPos, common.Big1)
+	}
+	utils.Sort(addrs)
+
+	noncePos := crypto.Keccak256Hash(key, g.contracts.MultisigNoncesSlot.Bytes())
+	return &multisig.AliasWithNonce{
+		Alias: multisig.Alias{
+			ID: id,
+			Owners: &secp256k1fx.OutputOwners{
+				Threshold: uint32(threshold.Uint64()),
+				Addrs:     addrs,
+			},
+		},
+		Nonce: g.state.GetState(contract, noncePos).Big().Uint64(),
+	}, nil
+}
+
+// MultisigAliasStorage returns the storage of the multisig contract which
+// defines [alias] with [owners] and [nonce], e.g. to define aliases in the
+// genesis allocation
+func MultisigAliasStorage(contracts *params.SystemContracts, alias ids.ShortID, owners *secp256k1fx.OutputOwners, nonce uint64) map[common.Hash]common.Hash {
+	key := common.Address(alias).Hash().Bytes()
+	ownersPos := crypto.Keccak256Hash(key, contracts.MultisigAliasesSlot.Bytes())
+	ctrlGroupPos := common.BigToHash(new(big.Int).Add(ownersPos.Big(), common.Big1))
+	storage := map[common.Hash]common.Hash{
+		ownersPos:    common.BigToHash(new(big.Int).SetUint64(uint64(owners.Threshold))),
+		ctrlGroupPos: common.BigToHash(big.NewInt(int64(len(owners.Addrs)))),
+		crypto.Keccak256Hash(key, contracts.MultisigNoncesSlot.Bytes()): common.BigToHash(new(big.Int).SetUint64(nonce)),
+	}
+	elementPos := crypto.Keccak256Hash(ctrlGroupPos.Bytes()).Big()
+	for _, addr := range owners.Addrs {
+		storage[common.BigToHash(elementPos)] = common.Address(addr).Hash()
+		elementPos = new(big.Int).Add(elementPos, common.Big1)
+	}
+	return storage
+}
+
+// AliasGetters resolves an alias from several sources. If more than one
+// source defines the alias, the definition with the highest nonce is
+// returned, the first one on equal nonces.
+type AliasGetters []secp256k1fx.AliasGetter
+
+func (ag AliasGetters) GetMultisigAlias(id ids.ShortID) (*multisig.AliasWithNonce, error) {
+	var latest *multisig.AliasWithNonce
+	for _, getter := range ag {
+		alias, err := getter.GetMultisigAlias(id)
+		switch {
+		case err == database.ErrNotFound:
+			continue
+		case err != nil:
+			return nil, err
+		}
+		if latest == nil || alias.Nonce > latest.Nonce {
+			latest = alias
+		}
+	}
+	if latest == nil {
+		return nil, database.ErrNotFound
+	}
+	return latest, nil
+}
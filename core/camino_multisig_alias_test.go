@@ -0,0 +1,72 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateAliasGetter(t *testing.T) {
+	contracts := params.DefaultSystemContracts
+	alias := ids.ShortID{0xaa}
+	owners := &secp256k1fx.OutputOwners{Threshold: 2, Addrs: []ids.ShortID{{0x02}, {0x01}}}
+
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	for key, value := range MultisigAliasStorage(&contracts, alias, owners, 3) {
+		statedb.SetState(contracts.MultisigAddress, key, value)
+	}
+	// Threshold without control group, as written for the initial admin
+	// into the shared slot 0 of the proxy
+	for key, value := range MultisigAliasStorage(&contracts, ids.ShortID{0xbb}, &secp256k1fx.OutputOwners{Threshold: 1}, 0) {
+		statedb.SetState(contracts.MultisigAddress, key, value)
+	}
+
+	getter := NewStateAliasGetter(&contracts, statedb)
+	aliasWithNonce, err := getter.GetMultisigAlias(alias)
+	require.NoError(t, err)
+	require.Equal(t, &multisig.AliasWithNonce{
+		Alias: multisig.Alias{
+			ID: alias,
+			Owners: &secp256k1fx.OutputOwners{
+				Threshold: 2,
+				Addrs:     []ids.ShortID{{0x01}, {0x02}},
+			},
+		},
+		Nonce: 3,
+	}, aliasWithNonce)
+
+	_, err = getter.GetMultisigAlias(ids.ShortID{0xbb})
+	require.ErrorIs(t, err, database.ErrNotFound)
+	_, err = getter.GetMultisigAlias(ids.ShortID{0xcc})
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	// The contract is disabled
+	disabled := contracts
+	disabled.MultisigAddress = common.Address{}
+	_, err = NewStateAliasGetter(&disabled, statedb).GetMultisigAlias(alias)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	// The definition with the higher nonce wins
+	older := &multisig.AliasWithNonce{Alias: multisig.Alias{ID: alias}, Nonce: 2}
+	newer := &multisig.AliasWithNonce{Alias: multisig.Alias{ID: alias}, Nonce: 4}
+	resolved, err := AliasGetters{AliasSet{older.ID: older}, getter}.GetMultisigAlias(alias)
+	require.NoError(t, err)
+	require.Equal(t, aliasWithNonce, resolved)
+	resolved, err = AliasGetters{AliasSet{newer.ID: newer}, getter}.GetMultisigAlias(alias)
+	require.NoError(t, err)
+	require.Equal(t, newer, resolved)
+	_, err = AliasGetters{AliasSet{}, getter}.GetMultisigAlias(ids.ShortID{0xcc})
+	require.ErrorIs(t, err, database.ErrNotFound)
+}
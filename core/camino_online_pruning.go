@@ -0,0 +1,95 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/state/pruner"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var errOnlinePruningDisabled = errors.New("online pruning requires pruning to be enabled")
+
+// StartStatePruning starts deleting the stale state in the background while
+// the chain keeps running, or resumes the interrupted session.
+func (bc *BlockChain) StartStatePruning() error {
+	if bc.onlinePruner == nil {
+		return errOnlinePruningDisabled
+	}
+	return bc.onlinePruner.Start(bc.stateCache.TrieDB(), bc.liveStateRoots)
+}
+
+// StopStatePruning interrupts the running online pruning session, which is
+// resumed on the next start.
+func (bc *BlockChain) StopStatePruning() error {
+	if bc.onlinePruner == nil {
+		return errOnlinePruningDisabled
+	}
+	bc.onlinePruner.Stop()
+	return nil
+}
+
+// StatePruningStatus returns the progress of the current or last online
+// pruning session.
+func (bc *BlockChain) StatePruningStatus() (pruner.OnlinePruningStatus, error) {
+	if bc.onlinePruner == nil {
+		return pruner.OnlinePruningStatus{}, errOnlinePruningDisabled
+	}
+	return bc.onlinePruner.Status(), nil
+}
+
+// resumeStatePruning resumes the online pruning session interrupted by a
+// shutdown, if any.
+func (bc *BlockChain) resumeStatePruning() {
+	if bc.onlinePruner == nil || !bc.onlinePruner.Pending() {
+		return
+	}
+	if err := bc.StartStatePruning(); err != nil {
+		log.Error("Failed to resume online pruning", "err", err)
+	}
+}
+
+// liveStateRoots returns the roots of the state tries to keep while pruning.
+// The first root is the most recent accepted state committed to disk. The
+// accepted states committed within [tipBufferSize] blocks, or within the state
+// history window if larger, are kept along with the nearest one below, so that
+// the states which can be served now are still available after pruning. The
+// tries kept in memory and the genesis state are kept as well.
+func (bc *BlockChain) liveStateRoots() ([]common.Hash, error) {
+	var (
+		lastAccepted = bc.LastAcceptedBlock().NumberU64()
+		retain       = uint64(tipBufferSize)
+		roots        []common.Hash
+	)
+	if bc.stateHistoryEnabled() && bc.cacheConfig.StateHistoryWindow > retain {
+		retain = bc.cacheConfig.StateHistoryWindow
+	}
+	for number := lastAccepted; ; number-- {
+		header := bc.GetHeaderByNumber(number)
+		if header == nil {
+			return nil, fmt.Errorf("missing header %d", number)
+		}
+		if rawdb.HasTrieNode(bc.db, header.Root) {
+			roots = append(roots, header.Root)
+			if lastAccepted-number >= retain {
+				break
+			}
+		}
+		if number == 0 {
+			break
+		}
+	}
+	if len(roots) == 0 {
+		return nil, errors.New("no accepted state available on disk")
+	}
+	roots = append(roots, bc.stateCache.TrieDB().Roots()...)
+	if root := bc.genesisBlock.Root(); rawdb.HasTrieNode(bc.db, root) {
+		roots = append(roots, root)
+	}
+	return roots, nil
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/core/state/pruner"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestOnlinePruning(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		key1, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2   = common.HexToAddress("0x0200000000000000000000000000000000000000")
+		// Contract storing its calldata at slot 0
+		contractCode = hexutil.MustDecode("0x6007600c60003960076000f360003560005500")
+		contract     = crypto.CreateAddress(addr1, 0)
+		// GenerateChain commits the state of every block to [genDB]
+		genDB   = rawdb.NewMemoryDatabase()
+		chainDB = rawdb.NewMemoryDatabase()
+		config  = *pruningConfig
+	)
+	config.CommitInterval = 4
+	config.OnlinePruningBloomSize = 1
+	// Slow enough to interrupt the first session
+	config.OnlinePruningRate = 20
+
+	gspec := &Genesis{
+		Config: &params.ChainConfig{HomesteadBlock: new(big.Int)},
+		Alloc:  GenesisAlloc{addr1: {Balance: big.NewInt(params.Ether)}},
+	}
+	genesis := gspec.MustCommit(genDB)
+	_ = gspec.MustCommit(chainDB)
+
+	blockchain, err := createBlockChain(chainDB, &config, gspec.Config, common.Hash{})
+	require.NoError(err)
+
+	signer := types.HomesteadSigner{}
+	chain, _, err := GenerateChain(gspec.Config, genesis, blockchain.engine, genDB, 56, 10, func(i int, gen *BlockGen) {
+		var tx *types.Transaction
+		if i == 0 {
+			tx = types.NewContractCreation(gen.TxNonce(addr1), nil, 100_000, nil, contractCode)
+		} else {
+			value := common.BigToHash(big.NewInt(int64(i + 1)))
+			tx = types.NewTransaction(gen.TxNonce(addr1), contract, nil, 100_000, nil, value[:])
+		}
+		signedTx, err := types.SignTx(tx, signer, key1)
+		require.NoError(err)
+		gen.AddTx(signedTx)
+		signedTx, err = types.SignTx(types.NewTransaction(gen.TxNonce(addr1), addr2, big.NewInt(10_000), params.TxGas, nil, nil), signer, key1)
+		require.NoError(err)
+		gen.AddTx(signedTx)
+	})
+	require.NoError(err)
+
+	insertAndAccept := func(blocks []*types.Block) {
+		_, err := blockchain.InsertChain(blocks)
+		require.NoError(err)
+		for _, block := range blocks {
+			require.NoError(blockchain.Accept(block))
+		}
+		blockchain.DrainAcceptorQueue()
+	}
+	waitForPruning := func() {
+		require.Eventually(func() bool {
+			status, err := blockchain.StatePruningStatus()
+			require.NoError(err)
+			return !status.Running
+		}, 10*time.Second, 10*time.Millisecond)
+	}
+	insertAndAccept(chain[:48])
+
+	// The states committed every [CommitInterval] blocks are on disk
+	for _, block := range chain[:48] {
+		require.Equal(block.NumberU64()%config.CommitInterval == 0, rawdb.HasTrieNode(chainDB, block.Root()), "block %d", block.NumberU64())
+	}
+
+	// Interrupt the session by shutting down the chain
+	require.NoError(blockchain.StartStatePruning())
+	require.ErrorIs(blockchain.StartStatePruning(), pruner.ErrOnlinePruningRunning)
+	blockchain.Stop()
+
+	status, err := blockchain.StatePruningStatus()
+	require.NoError(err)
+	require.False(status.Running)
+	require.Empty(status.Error)
+	require.NotEmpty(rawdb.ReadOnlinePruning(chainDB))
+
+	// The session is resumed on restart while blocks keep being accepted
+	config.OnlinePruningRate = 0
+	blockchain, err = createBlockChain(chainDB, &config, gspec.Config, chain[47].Hash())
+	require.NoError(err)
+
+	insertAndAccept(chain[48:])
+	waitForPruning()
+
+	status, err = blockchain.StatePruningStatus()
+	require.NoError(err)
+	require.Empty(status.Error)
+	require.Equal(float64(1), status.Progress)
+	require.NotZero(status.Deleted)
+	require.Empty(rawdb.ReadOnlinePruning(chainDB))
+
+	// The committed states older than [tipBufferSize] blocks are pruned, except
+	// for the genesis. The session started with the last accepted block in
+	// [48, 56].
+	for _, block := range chain[:48] {
+		number := block.NumberU64()
+		switch {
+		case number%config.CommitInterval != 0:
+		case number <= 12:
+			require.False(rawdb.HasTrieNode(chainDB, block.Root()), "block %d", number)
+		case number >= 24:
+			require.True(rawdb.HasTrieNode(chainDB, block.Root()), "block %d", number)
+		}
+	}
+	require.True(rawdb.HasTrieNode(chainDB, genesis.Root()))
+	blockchain.Stop()
+
+	// The live state is intact after a restart
+	blockchain, err = createBlockChain(chainDB, &config, gspec.Config, chain[55].Hash())
+	require.NoError(err)
+	defer blockchain.Stop()
+
+	for _, block := range []*types.Block{chain[51], chain[55]} {
+		expected, err := state.New(block.Root(), state.NewDatabase(genDB), nil)
+		require.NoError(err)
+		statedb, err := blockchain.StateAt(block.Root())
+		require.NoError(err)
+		for _, addr := range []common.Address{addr1, addr2, contract} {
+			require.Equal(expected.GetBalance(addr), statedb.GetBalance(addr))
+			require.Equal(expected.GetNonce(addr), statedb.GetNonce(addr))
+			require.Equal(expected.GetCode(addr), statedb.GetCode(addr))
+		}
+		require.Equal(expected.GetState(contract, common.Hash{}), statedb.GetState(contract, common.Hash{}))
+	}
+}
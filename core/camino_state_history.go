@@ -0,0 +1,371 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/metrics"
+	"github.com/ava-labs/coreth/trie"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	errStateHistoryDisabled    = errors.New("state history is disabled")
+	errStateHistoryUnavailable = errors.New("state history unavailable")
+
+	stateHistoryWriteTimer   = metrics.NewRegisteredTimer("chain/statehistory/writes", nil)
+	stateHistoryRebuildTimer = metrics.NewRegisteredTimer("chain/statehistory/rebuilds", nil)
+)
+
+// stateHistoryCacheEntry is a state rebuilt from the state history, kept in
+// its ephemeral trie database
+type stateHistoryCacheEntry struct {
+	database state.Database
+	root     common.Hash
+}
+
+// stateHistory is the state diff of an accepted block. Accounts and storage
+// slots are keyed by the hashes of their trie keys and hold the trie leaf
+// after the block. An empty value marks a deleted leaf.
+type stateHistory struct {
+	Root     common.Hash
+	Accounts []stateHistoryAccount
+	Storage  []stateHistoryStorage
+}
+
+type stateHistoryAccount struct {
+	Hash  common.Hash
+	Value []byte
+}
+
+type stateHistoryStorage struct {
+	Account common.Hash
+	Hash    common.Hash
+	Value   []byte
+}
+
+// stateHistoryEnabled returns true if the state diffs of accepted blocks are
+// kept, which is only useful with pruning
+func (bc *BlockChain) stateHistoryEnabled() bool {
+	return bc.cacheConfig.Pruning && bc.cacheConfig.StateHistoryWindow > 0
+}
+
+// pruneStateHistory removes the state diffs outside of the window ending at
+// [lastAccepted], or all of them if the state history is disabled.
+func (bc *BlockChain) pruneStateHistory(lastAccepted uint64) error {
+	var tail uint64
+	if window := bc.cacheConfig.StateHistoryWindow; !bc.stateHistoryEnabled() {
+		tail = lastAccepted + 1
+	} else if lastAccepted >= window {
+		tail = lastAccepted - window + 1
+	}
+	deleted, err := rawdb.DeleteStateHistoryBelow(bc.db, tail)
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Info("Pruned state history", "tail", tail, "deleted", deleted)
+	}
+	return nil
+}
+
+// writeStateHistory stores the state diff of the accepted block [b] to its
+// parent and removes the diff falling out of the window.
+// It must be called before the trie of the parent is dereferenced.
+func (bc *BlockChain) writeStateHistory(b *types.Block) error {
+	start := time.Now()
+	parent := bc.GetHeader(b.ParentHash(), b.NumberU64()-1)
+	if parent == nil {
+		return fmt.Errorf("missing parent %s:%d", b.ParentHash(), b.NumberU64()-1)
+	}
+	history, err := diffStateTries(bc.stateCache.TrieDB(), parent.Root, b.Root())
+	if err != nil {
+		return err
+	}
+	data, err := rlp.EncodeToBytes(history)
+	if err != nil {
+		return err
+	}
+
+	batch := bc.db.NewBatch()
+	rawdb.WriteStateHistory(batch, b.NumberU64(), data)
+	if window := bc.cacheConfig.StateHistoryWindow; b.NumberU64() >= window {
+		rawdb.DeleteStateHistory(batch, b.NumberU64()-window)
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	stateHistoryWriteTimer.UpdateSince(start)
+	return nil
+}
+
+// StateHistoryAt returns the state of the accepted [header], rebuilt from the
+// nearest available state trie and the state diffs of the blocks accepted
+// since. The state is kept in an ephemeral trie database, isolated from the
+// live one. The most recently rebuilt states are cached and the number of
+// concurrent rebuilds is limited.
+func (bc *BlockChain) StateHistoryAt(header *types.Header) (*state.StateDB, error) {
+	if !bc.stateHistoryEnabled() {
+		return nil, errStateHistoryDisabled
+	}
+	number := header.Number.Uint64()
+	if number > bc.LastAcceptedBlock().NumberU64() || bc.GetCanonicalHash(number) != header.Hash() {
+		return nil, fmt.Errorf("%w: block %s:%d is not accepted", errStateHistoryUnavailable, header.Hash(), number)
+	}
+	if entry, ok := bc.stateHistoryCache.Get(header.Hash()); ok {
+		entry := entry.(*stateHistoryCacheEntry)
+		return state.New(entry.root, entry.database, nil)
+	}
+
+	select {
+	case bc.stateHistorySem <- struct{}{}:
+	case <-bc.quit:
+		return nil, fmt.Errorf("%w: chain is stopped", errStateHistoryUnavailable)
+	}
+	defer func() { <-bc.stateHistorySem }()
+
+	// The state may have been rebuilt while waiting
+	if entry, ok := bc.stateHistoryCache.Get(header.Hash()); ok {
+		entry := entry.(*stateHistoryCacheEntry)
+		return state.New(entry.root, entry.database, nil)
+	}
+
+	start := time.Now()
+	database := state.NewDatabaseWithConfig(bc.db, &trie.Config{Cache: 16})
+	triedb := database.TrieDB()
+
+	// Find the nearest ancestor whose state is available on disk
+	var (
+		tail uint64
+		base = number
+	)
+	if window := bc.cacheConfig.StateHistoryWindow; number >= window {
+		tail = number - window
+	}
+	for {
+		baseHeader := bc.GetHeaderByNumber(base)
+		if baseHeader == nil {
+			return nil, fmt.Errorf("%w: missing header %d", errStateHistoryUnavailable, base)
+		}
+		if _, err := database.OpenTrie(baseHeader.Root); err == nil {
+			break
+		}
+		if base == tail {
+			return nil, fmt.Errorf("%w: no state available within the window of block %d", errStateHistoryUnavailable, number)
+		}
+		base--
+	}
+
+	// Apply the state diffs of the blocks following [base]
+	root := bc.GetHeaderByNumber(base).Root
+	for height := base + 1; height <= number; height++ {
+		data := rawdb.ReadStateHistory(bc.db, height)
+		if len(data) == 0 {
+			return nil, fmt.Errorf("%w: missing state diff of block %d", errStateHistoryUnavailable, height)
+		}
+		history := new(stateHistory)
+		if err := rlp.DecodeBytes(data, history); err != nil {
+			return nil, fmt.Errorf("failed to decode state diff of block %d: %w", height, err)
+		}
+		var err error
+		if root, err = applyStateHistory(triedb, root, history); err != nil {
+			return nil, fmt.Errorf("failed to apply state diff of block %d: %w", height, err)
+		}
+		if root != history.Root {
+			return nil, fmt.Errorf("state diff of block %d resulted in root %s, expected %s", height, root, history.Root)
+		}
+	}
+	if root != header.Root {
+		return nil, fmt.Errorf("rebuilt state root %s does not match block %d root %s", root, number, header.Root)
+	}
+	stateHistoryRebuildTimer.UpdateSince(start)
+	log.Debug("Rebuilt state from history", "number", number, "base", base, "elapsed", common.PrettyDuration(time.Since(start)))
+	bc.stateHistoryCache.Add(header.Hash(), &stateHistoryCacheEntry{database: database, root: root})
+	return state.New(root, database, nil)
+}
+
+// diffStateTries returns the leaves of the state at [root] which differ from
+// the state at [parentRoot]
+func diffStateTries(db *trie.Database, parentRoot, root common.Hash) (*stateHistory, error) {
+	parentTrie, err := trie.New(common.Hash{}, parentRoot, db)
+	if err != nil {
+		return nil, err
+	}
+	accTrie, err := trie.New(common.Hash{}, root, db)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &stateHistory{Root: root}
+	err = diffTries(parentTrie, accTrie, func(key, value []byte) error {
+		accountHash := common.BytesToHash(key)
+		history.Accounts = append(history.Accounts, stateHistoryAccount{
+			Hash:  accountHash,
+			Value: value,
+		})
+		if len(value) == 0 {
+			// The storage of deleted accounts does not need to be tracked
+			return nil
+		}
+
+		storageRoot, err := storageRootOf(value)
+		if err != nil {
+			return err
+		}
+		parentStorageRoot := types.EmptyRootHash
+		if parentValue, err := parentTrie.TryGet(key); err != nil {
+			return err
+		} else if len(parentValue) != 0 {
+			if parentStorageRoot, err = storageRootOf(parentValue); err != nil {
+				return err
+			}
+		}
+		if storageRoot == parentStorageRoot {
+			return nil
+		}
+
+		parentStorageTrie, err := trie.New(accountHash, parentStorageRoot, db)
+		if err != nil {
+			return err
+		}
+		storageTrie, err := trie.New(accountHash, storageRoot, db)
+		if err != nil {
+			return err
+		}
+		return diffTries(parentStorageTrie, storageTrie, func(key, value []byte) error {
+			history.Storage = append(history.Storage, stateHistoryStorage{
+				Account: accountHash,
+				Hash:    common.BytesToHash(key),
+				Value:   value,
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// diffTries calls [onDiff] with the value in [b] of each leaf which differs
+// between [a] and [b], the value of leaves missing in [b] being nil
+func diffTries(a, b *trie.Trie, onDiff func(key, value []byte) error) error {
+	it, _ := trie.NewDifferenceIterator(a.NodeIterator(nil), b.NodeIterator(nil))
+	for it.Next(true) {
+		if !it.Leaf() {
+			continue
+		}
+		if err := onDiff(it.LeafKey(), it.LeafBlob()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	// Leaves of [a] which are not in [b] anymore have been deleted
+	it, _ = trie.NewDifferenceIterator(b.NodeIterator(nil), a.NodeIterator(nil))
+	for it.Next(true) {
+		if !it.Leaf() {
+			continue
+		}
+		value, err := b.TryGet(it.LeafKey())
+		if err != nil {
+			return err
+		}
+		if len(value) != 0 {
+			continue
+		}
+		if err := onDiff(it.LeafKey(), nil); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// applyStateHistory applies [history] to the state at [root] and returns the
+// root of the resulting state, whose nodes are added to [db]
+func applyStateHistory(db *trie.Database, root common.Hash, history *stateHistory) (common.Hash, error) {
+	accTrie, err := trie.New(common.Hash{}, root, db)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	// Group the storage changes by account, preserving their order
+	var (
+		accounts []common.Hash
+		storage  = make(map[common.Hash][]stateHistoryStorage)
+	)
+	for _, slot := range history.Storage {
+		if _, ok := storage[slot.Account]; !ok {
+			accounts = append(accounts, slot.Account)
+		}
+		storage[slot.Account] = append(storage[slot.Account], slot)
+	}
+
+	nodes := trie.NewMergedNodeSet()
+	for _, accountHash := range accounts {
+		storageRoot := types.EmptyRootHash
+		if value, err := accTrie.TryGet(accountHash[:]); err != nil {
+			return common.Hash{}, err
+		} else if len(value) != 0 {
+			if storageRoot, err = storageRootOf(value); err != nil {
+				return common.Hash{}, err
+			}
+		}
+		storageTrie, err := trie.New(accountHash, storageRoot, db)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		for _, slot := range storage[accountHash] {
+			if err := storageTrie.TryUpdate(slot.Hash[:], slot.Value); err != nil {
+				return common.Hash{}, err
+			}
+		}
+		_, set, err := storageTrie.Commit(false)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		if set != nil {
+			if err := nodes.Merge(set); err != nil {
+				return common.Hash{}, err
+			}
+		}
+	}
+	for _, account := range history.Accounts {
+		if err := accTrie.TryUpdate(account.Hash[:], account.Value); err != nil {
+			return common.Hash{}, err
+		}
+	}
+	newRoot, set, err := accTrie.Commit(true)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if set != nil {
+		if err := nodes.Merge(set); err != nil {
+			return common.Hash{}, err
+		}
+	}
+	if err := db.Update(nodes); err != nil {
+		return common.Hash{}, err
+	}
+	return newRoot, nil
+}
+
+// storageRootOf returns the storage root of the RLP encoded account [value]
+func storageRootOf(value []byte) (common.Hash, error) {
+	var account types.StateAccount
+	if err := rlp.DecodeBytes(value, &account); err != nil {
+		return common.Hash{}, err
+	}
+	return account.Root, nil
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestStateHistory(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		key1, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		addr2   = common.HexToAddress("0x0200000000000000000000000000000000000000")
+		// Contract storing its calldata at slot 0
+		contractCode = hexutil.MustDecode("0x6007600c60003960076000f360003560005500")
+		contract     = crypto.CreateAddress(addr1, 0)
+		// GenerateChain commits the state of every block to [genDB]
+		genDB   = rawdb.NewMemoryDatabase()
+		chainDB = rawdb.NewMemoryDatabase()
+		config  = *pruningConfig
+	)
+	config.CommitInterval = 8
+	config.StateHistoryWindow = 12
+
+	gspec := &Genesis{
+		Config: &params.ChainConfig{HomesteadBlock: new(big.Int)},
+		Alloc:  GenesisAlloc{addr1: {Balance: big.NewInt(params.Ether)}},
+	}
+	genesis := gspec.MustCommit(genDB)
+	_ = gspec.MustCommit(chainDB)
+
+	blockchain, err := createBlockChain(chainDB, &config, gspec.Config, common.Hash{})
+	require.NoError(err)
+
+	signer := types.HomesteadSigner{}
+	chain, _, err := GenerateChain(gspec.Config, genesis, blockchain.engine, genDB, 20, 10, func(i int, gen *BlockGen) {
+		var tx *types.Transaction
+		if i == 0 {
+			tx = types.NewContractCreation(gen.TxNonce(addr1), nil, 100_000, nil, contractCode)
+		} else {
+			// Zero values delete the slot
+			value := common.BigToHash(big.NewInt(int64(i % 3)))
+			tx = types.NewTransaction(gen.TxNonce(addr1), contract, nil, 100_000, nil, value[:])
+		}
+		signedTx, err := types.SignTx(tx, signer, key1)
+		require.NoError(err)
+		gen.AddTx(signedTx)
+		signedTx, err = types.SignTx(types.NewTransaction(gen.TxNonce(addr1), addr2, big.NewInt(10_000), params.TxGas, nil, nil), signer, key1)
+		require.NoError(err)
+		gen.AddTx(signedTx)
+	})
+	require.NoError(err)
+
+	_, err = blockchain.InsertChain(chain)
+	require.NoError(err)
+	for _, block := range chain {
+		require.NoError(blockchain.Accept(block))
+	}
+	blockchain.DrainAcceptorQueue()
+	blockchain.Stop()
+
+	// Restart to drop the tries kept in memory
+	blockchain, err = createBlockChain(chainDB, &config, gspec.Config, chain[len(chain)-1].Hash())
+	require.NoError(err)
+
+	requireState := func(block *types.Block, statedb *state.StateDB) {
+		expected, err := state.New(block.Root(), state.NewDatabase(genDB), nil)
+		require.NoError(err)
+		for _, addr := range []common.Address{addr1, addr2, contract} {
+			require.Equal(expected.GetBalance(addr), statedb.GetBalance(addr))
+			require.Equal(expected.GetNonce(addr), statedb.GetNonce(addr))
+		}
+		require.Equal(expected.GetState(contract, common.Hash{}), statedb.GetState(contract, common.Hash{}))
+		require.Equal(block.Root(), statedb.IntermediateRoot(true))
+	}
+
+	// The diffs of blocks [9, 20] are kept, the tries of blocks 8 and 16 are
+	// committed
+	for _, block := range chain {
+		number := block.NumberU64()
+		if number%config.CommitInterval != 0 && number != 20 {
+			require.False(blockchain.HasState(block.Root()), "block %d", number)
+		}
+		statedb, err := blockchain.StateHistoryAt(block.Header())
+		if number < 8 {
+			require.ErrorIs(err, errStateHistoryUnavailable, "block %d", number)
+			continue
+		}
+		require.NoError(err, "block %d", number)
+		requireState(block, statedb)
+	}
+
+	// The most recently rebuilt states are served from the cache
+	require.True(blockchain.stateHistoryCache.Contains(chain[19].Hash()))
+	require.False(blockchain.stateHistoryCache.Contains(chain[8].Hash()))
+	statedb, err := blockchain.StateHistoryAt(chain[19].Header())
+	require.NoError(err)
+	requireState(chain[19], statedb)
+	blockchain.Stop()
+
+	// Shrinking the window prunes the diffs on startup
+	config.StateHistoryWindow = 4
+	blockchain, err = createBlockChain(chainDB, &config, gspec.Config, chain[len(chain)-1].Hash())
+	require.NoError(err)
+
+	require.Empty(rawdb.ReadStateHistory(chainDB, 16))
+	require.NotEmpty(rawdb.ReadStateHistory(chainDB, 17))
+	_, err = blockchain.StateHistoryAt(chain[14].Header())
+	require.ErrorIs(err, errStateHistoryUnavailable)
+	statedb, err = blockchain.StateHistoryAt(chain[17].Header())
+	require.NoError(err)
+	requireState(chain[17], statedb)
+	blockchain.Stop()
+
+	// Disabling the state history removes all diffs
+	config.StateHistoryWindow = 0
+	blockchain, err = createBlockChain(chainDB, &config, gspec.Config, chain[len(chain)-1].Hash())
+	require.NoError(err)
+	defer blockchain.Stop()
+
+	require.Empty(rawdb.ReadStateHistory(chainDB, 20))
+	_, err = blockchain.StateHistoryAt(chain[17].Header())
+	require.ErrorIs(err, errStateHistoryDisabled)
+}
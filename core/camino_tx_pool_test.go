@@ -0,0 +1,144 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ava-labs/coreth/core/admin"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+type testAdminController struct {
+	lock sync.Mutex
+	kyc  map[common.Address]bool
+}
+
+func (c *testAdminController) setKyc(addr common.Address, verified bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.kyc[addr] = verified
+}
+
+func (*testAdminController) Start() {}
+
+func (*testAdminController) GetFixedBaseFee(*types.Header, admin.StateDB, uint64) (*big.Int, error) {
+	return new(big.Int).SetUint64(params.SunrisePhase0BaseFee), nil
+}
+
+func (*testAdminController) GetPendingBaseFee(*types.Header, admin.StateDB) (*big.Int, uint64) {
+	return nil, 0
+}
+
+func (*testAdminController) GetBaseFeeBounds(*types.Header, admin.StateDB) (*big.Int, *big.Int, error) {
+	return new(big.Int).SetUint64(params.SunrisePhase0BaseFee), nil, nil
+}
+
+func (c *testAdminController) KycVerified(_ *types.Header, _ admin.StateDB, addr common.Address) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.kyc[addr]
+}
+
+func (*testAdminController) IsBlacklisted(*types.Header, admin.StateDB, common.Address, [4]byte) bool {
+	return false
+}
+
+func setupKycTxPool(policy params.KycPolicy, ctrl admin.AdminController) *TxPool {
+	config := *params.TestChainConfig
+	config.KycPolicyUpgrades = []params.KycPolicyUpgrade{
+		{BlockTimestamp: common.Big0, KycPolicy: policy},
+	}
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockchain(statedb, 10000000, new(event.Feed))
+	blockchain.adminCtrl = ctrl
+
+	pool := NewTxPool(testTxPoolConfig, &config, blockchain)
+	<-pool.initDoneCh
+	return pool
+}
+
+func TestTxPoolKycPolicy(t *testing.T) {
+	t.Parallel()
+
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	ctrl := &testAdminController{kyc: map[common.Address]bool{}}
+
+	pool := setupKycTxPool(params.KycPolicy{ValueTransfer: true}, ctrl)
+	defer pool.Stop()
+
+	testAddBalance(pool, from, big.NewInt(1000000000000))
+
+	// Value transfers require KYC
+	if err := pool.AddRemote(transaction(0, 100000, key)); !errors.Is(err, vmerrs.ErrNotKycVerified) {
+		t.Fatalf("expected %v, got %v", vmerrs.ErrNotKycVerified, err)
+	}
+	// Transactions without value do not
+	if err := pool.AddRemote(pricedDataTransaction(0, 100000, big.NewInt(1), key, 0)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	ctrl.setKyc(from, true)
+	if err := pool.AddRemote(transaction(1, 100000, key)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+func TestTxPoolKycEviction(t *testing.T) {
+	t.Parallel()
+
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	ctrl := &testAdminController{kyc: map[common.Address]bool{from: true}}
+
+	pool := setupKycTxPool(params.KycPolicy{ValueTransfer: true}, ctrl)
+	defer pool.Stop()
+
+	testAddBalance(pool, from, big.NewInt(1000000000000))
+
+	txs := []*types.Transaction{
+		transaction(0, 100000, key),
+		pricedDataTransaction(1, 100000, big.NewInt(1), key, 0),
+		transaction(2, 100000, key),
+	}
+	for _, err := range pool.AddRemotesSync(txs) {
+		if err != nil {
+			t.Fatalf("failed to add transaction: %v", err)
+		}
+	}
+	if pending, queued := pool.Stats(); pending != 3 || queued != 0 {
+		t.Fatalf("pending/queued mismatch: have %d/%d, want %d/%d", pending, queued, 3, 0)
+	}
+
+	// Losing KYC drops the value transfers and parks the remaining transaction
+	ctrl.setKyc(from, false)
+	<-pool.requestReset(nil, nil)
+
+	if pending, queued := pool.Stats(); pending != 0 || queued != 1 {
+		t.Fatalf("pending/queued mismatch: have %d/%d, want %d/%d", pending, queued, 0, 1)
+	}
+	if pool.Get(txs[0].Hash()) != nil || pool.Get(txs[2].Hash()) != nil {
+		t.Fatalf("value transfers of not KYC verified sender not dropped")
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
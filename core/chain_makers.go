@@ -249,6 +249,7 @@ func GenerateChain(config *params.ChainConfig, parent *types.Block, engine conse
 		if config.DAOForkSupport && config.DAOForkBlock != nil && config.DAOForkBlock.Cmp(b.header.Number) == 0 {
 			misc.ApplyDAOHardFork(statedb)
 		}
+		ApplyContractUpgrades(config, new(big.Int).SetUint64(parent.Time()), timestamp, statedb)
 		// Execute any user modifications to the block
 		if gen != nil {
 			gen(i, b)
@@ -29,6 +29,7 @@ func (g Genesis) MarshalJSON() ([]byte, error) {
 		InitialAdmin                   common.Address                              `json:"initialAdmin"`
 		FeeRewardExportMinAmount       math.HexOrDecimal64                         `json:"feeRewardExportMinAmount"`
 		FeeRewardExportMinTimeInterval math.HexOrDecimal64                         `json:"feeRewardExportMinTimeInterval"`
+		KeepProxyStorage               bool                                        `json:"keepProxyStorage"`
 		Alloc                          map[common.UnprefixedAddress]GenesisAccount `json:"alloc"      gencodec:"required"`
 		Number                         math.HexOrDecimal64                         `json:"number"`
 		GasUsed                        math.HexOrDecimal64                         `json:"gasUsed"`
@@ -47,6 +48,7 @@ func (g Genesis) MarshalJSON() ([]byte, error) {
 	enc.InitialAdmin = g.InitialAdmin
 	enc.FeeRewardExportMinAmount = math.HexOrDecimal64(g.FeeRewardExportMinAmount)
 	enc.FeeRewardExportMinTimeInterval = math.HexOrDecimal64(g.FeeRewardExportMinTimeInterval)
+	enc.KeepProxyStorage = g.KeepProxyStorage
 	if g.Alloc != nil {
 		enc.Alloc = make(map[common.UnprefixedAddress]GenesisAccount, len(g.Alloc))
 		for k, v := range g.Alloc {
@@ -75,6 +77,7 @@ func (g *Genesis) UnmarshalJSON(input []byte) error {
 		Alloc                          map[common.UnprefixedAddress]GenesisAccount `json:"alloc"      gencodec:"required"`
 		FeeRewardExportMinAmount       *math.HexOrDecimal64                        `json:"feeRewardExportMinAmount"`
 		FeeRewardExportMinTimeInterval *math.HexOrDecimal64                        `json:"feeRewardExportMinTimeInterval"`
+		KeepProxyStorage               *bool                                       `json:"keepProxyStorage"`
 		Number                         *math.HexOrDecimal64                        `json:"number"`
 		GasUsed                        *math.HexOrDecimal64                        `json:"gasUsed"`
 		ParentHash                     *common.Hash                                `json:"parentHash"`
@@ -119,6 +122,9 @@ func (g *Genesis) UnmarshalJSON(input []byte) error {
 	if dec.FeeRewardExportMinTimeInterval != nil {
 		g.FeeRewardExportMinTimeInterval = uint64(*dec.FeeRewardExportMinTimeInterval)
 	}
+	if dec.KeepProxyStorage != nil {
+		g.KeepProxyStorage = *dec.KeepProxyStorage
+	}
 	if dec.Alloc == nil {
 		return errors.New("missing required field 'alloc' for Genesis")
 	}
@@ -79,6 +79,10 @@ type Genesis struct {
 	InitialAdmin                   common.Address `json:"initialAdmin"`
 	FeeRewardExportMinAmount       uint64         `json:"feeRewardExportMinAmount"`
 	FeeRewardExportMinTimeInterval uint64         `json:"feeRewardExportMinTimeInterval"`
+	// KeepProxyStorage keeps the storage allocated to the system contract
+	// proxies, e.g. predefined multisig aliases, instead of replacing it in
+	// PreDeploy
+	KeepProxyStorage bool `json:"keepProxyStorage"`
 
 	// These fields are used for consensus tests. Please don't use them
 	// in actual genesis blocks.
@@ -239,6 +243,13 @@ func SetupGenesisBlock(
 	}
 	height := lastBlock.NumberU64()
 	timestamp := lastBlock.Time()
+	// System contracts may be relocated after genesis, so check the ones
+	// active at the last accepted block in its state if it is available.
+	if statedb, err := state.New(lastBlock.Root(), state.NewDatabase(db), nil); err == nil {
+		if err := verifySystemContracts(newcfg, timestamp, statedb); err != nil {
+			return newcfg, err
+		}
+	}
 	if skipChainConfigCheckCompatible {
 		log.Info("skipping verifying activated network upgrades on chain config")
 	} else {
@@ -292,6 +303,8 @@ func (g *Genesis) ToBlock(db ethdb.Database) *types.Block {
 			}
 		}
 	}
+	// Upgrade the system contracts deployed above if enabled in the genesis
+	ApplyContractUpgrades(g.Config, nil, new(big.Int).SetUint64(head.Time), statedb)
 	root := statedb.IntermediateRoot(false)
 	head.Root = root
 
@@ -322,13 +335,14 @@ func (g *Genesis) PreDeploy() error {
 
 	// Deploy AdminProxy Contract
 	implAddress := common.HexToAddress("0x010000000000000000000000000000000000000b")
-	g.Alloc[common.HexToAddress("0x010000000000000000000000000000000000000a")] = GenesisAccount{
+	proxyAddress := common.HexToAddress("0x010000000000000000000000000000000000000a")
+	g.Alloc[proxyAddress] = GenesisAccount{
 		Balance: common.Big0,
 		Code:    common.Hex2Bytes("6080604052600436106100225760003560e01c8063d784d4261461004b57610039565b3661003957610037610032610074565b6100a5565b005b610049610044610074565b6100a5565b005b34801561005757600080fd5b50610072600480360381019061006d919061024d565b6100cb565b005b6000807f360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc60001b9050805491505090565b3660008037600080366000845af43d6000803e80600081146100c6573d6000f35b3d6000fd5b3073ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff1614610139576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610130906102e0565b60405180910390fd5b610142816101f3565b610181576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610178906102c0565b60405180910390fd5b60007f360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc60001b90508181558173ffffffffffffffffffffffffffffffffffffffff167fbc7cd75a20ee27fd9adebab32041f755214dbc6bffa90cc0225b39da2e5c2d3b60405160405180910390a25050565b600080823f90506000801b811415801561023057507fc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a47060001b8114155b915050919050565b6000813590506102478161039a565b92915050565b60006020828403121561026357610262610343565b5b600061027184828501610238565b91505092915050565b6000610287600e83610300565b915061029282610348565b602082019050919050565b60006102aa600d83610300565b91506102b582610371565b602082019050919050565b600060208201905081810360008301526102d98161027a565b9050919050565b600060208201905081810360008301526102f98161029d565b9050919050565b600082825260208201905092915050565b600061031c82610323565b9050919050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b600080fd5b7f4e6f74206120636f6e7472616374000000000000000000000000000000000000600082015250565b7f4163636573732064656e69656400000000000000000000000000000000000000600082015250565b6103a381610311565b81146103ae57600080fd5b5056fea2646970667358221220eaad273d24fb4c2d97d0a6ec9110de5905a399afa8c25558441ce29ac70ed7f964736f6c63430008070033"),
-		Storage: map[common.Hash]common.Hash{
+		Storage: g.proxyStorage(proxyAddress, map[common.Hash]common.Hash{
 			eip1967Key: implAddress.Hash(),
 			adminKey:   common.HexToHash("0x01"),
-		},
+		}),
 	}
 	// Deploy AdminImpl Contract
 	// compiled with 0.8.17+commit.8df45f5f
@@ -340,13 +354,14 @@ func (g *Genesis) PreDeploy() error {
 
 	// Deploy IncentiveProxy Contract
 	implAddress = common.HexToAddress("0x010000000000000000000000000000000000000d")
-	g.Alloc[common.HexToAddress("0x010000000000000000000000000000000000000c")] = GenesisAccount{
+	proxyAddress = common.HexToAddress("0x010000000000000000000000000000000000000c")
+	g.Alloc[proxyAddress] = GenesisAccount{
 		Balance: common.Big0,
 		Code:    common.Hex2Bytes("6080604052600436106100225760003560e01c8063d784d4261461004b57610039565b3661003957610037610032610074565b6100a5565b005b610049610044610074565b6100a5565b005b34801561005757600080fd5b50610072600480360381019061006d919061024d565b6100cb565b005b6000807f360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc60001b9050805491505090565b3660008037600080366000845af43d6000803e80600081146100c6573d6000f35b3d6000fd5b3073ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff1614610139576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610130906102e0565b60405180910390fd5b610142816101f3565b610181576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610178906102c0565b60405180910390fd5b60007f360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc60001b90508181558173ffffffffffffffffffffffffffffffffffffffff167fbc7cd75a20ee27fd9adebab32041f755214dbc6bffa90cc0225b39da2e5c2d3b60405160405180910390a25050565b600080823f90506000801b811415801561023057507fc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a47060001b8114155b915050919050565b6000813590506102478161039a565b92915050565b60006020828403121561026357610262610343565b5b600061027184828501610238565b91505092915050565b6000610287600e83610300565b915061029282610348565b602082019050919050565b60006102aa600d83610300565b91506102b582610371565b602082019050919050565b600060208201905081810360008301526102d98161027a565b9050919050565b600060208201905081810360008301526102f98161029d565b9050919050565b600082825260208201905092915050565b600061031c82610323565b9050919050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b600080fd5b7f4e6f74206120636f6e7472616374000000000000000000000000000000000000600082015250565b7f4163636573732064656e69656400000000000000000000000000000000000000600082015250565b6103a381610311565b81146103ae57600080fd5b5056fea2646970667358221220eaad273d24fb4c2d97d0a6ec9110de5905a399afa8c25558441ce29ac70ed7f964736f6c63430008070033"),
-		Storage: map[common.Hash]common.Hash{
+		Storage: g.proxyStorage(proxyAddress, map[common.Hash]common.Hash{
 			eip1967Key: implAddress.Hash(),
 			adminKey:   common.HexToHash("0x01"),
-		},
+		}),
 	}
 
 	// Deploy IncentiveImpl Contract
@@ -357,13 +372,14 @@ func (g *Genesis) PreDeploy() error {
 
 	// Deploy MultiSigProxy Contract
 	implAddress = common.HexToAddress("0x010000000000000000000000000000000000000f")
-	g.Alloc[common.HexToAddress("0x010000000000000000000000000000000000000e")] = GenesisAccount{
+	proxyAddress = common.HexToAddress("0x010000000000000000000000000000000000000e")
+	g.Alloc[proxyAddress] = GenesisAccount{
 		Balance: common.Big0,
 		Code:    common.Hex2Bytes("6080604052600436106100225760003560e01c8063d784d4261461004b57610039565b3661003957610037610032610074565b6100a5565b005b610049610044610074565b6100a5565b005b34801561005757600080fd5b50610072600480360381019061006d919061024d565b6100cb565b005b6000807f360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc60001b9050805491505090565b3660008037600080366000845af43d6000803e80600081146100c6573d6000f35b3d6000fd5b3073ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff1614610139576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610130906102e0565b60405180910390fd5b610142816101f3565b610181576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610178906102c0565b60405180910390fd5b60007f360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc60001b90508181558173ffffffffffffffffffffffffffffffffffffffff167fbc7cd75a20ee27fd9adebab32041f755214dbc6bffa90cc0225b39da2e5c2d3b60405160405180910390a25050565b600080823f90506000801b811415801561023057507fc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a47060001b8114155b915050919050565b6000813590506102478161039a565b92915050565b60006020828403121561026357610262610343565b5b600061027184828501610238565b91505092915050565b6000610287600e83610300565b915061029282610348565b602082019050919050565b60006102aa600d83610300565b91506102b582610371565b602082019050919050565b600060208201905081810360008301526102d98161027a565b9050919050565b600060208201905081810360008301526102f98161029d565b9050919050565b600082825260208201905092915050565b600061031c82610323565b9050919050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b600080fd5b7f4e6f74206120636f6e7472616374000000000000000000000000000000000000600082015250565b7f4163636573732064656e69656400000000000000000000000000000000000000600082015250565b6103a381610311565b81146103ae57600080fd5b5056fea2646970667358221220eaad273d24fb4c2d97d0a6ec9110de5905a399afa8c25558441ce29ac70ed7f964736f6c63430008070033"),
-		Storage: map[common.Hash]common.Hash{
+		Storage: g.proxyStorage(proxyAddress, map[common.Hash]common.Hash{
 			eip1967Key: implAddress.Hash(),
 			adminKey:   common.HexToHash("0x01"),
-		},
+		}),
 	}
 
 	// Deploy MultiSigImpl Contract
@@ -375,6 +391,20 @@ func (g *Genesis) PreDeploy() error {
 	return nil
 }
 
+// proxyStorage returns [storage] extended by the storage allocated to the
+// proxy at [proxyAddress] in the genesis if [g.KeepProxyStorage] is set
+func (g *Genesis) proxyStorage(proxyAddress common.Address, storage map[common.Hash]common.Hash) map[common.Hash]common.Hash {
+	if !g.KeepProxyStorage {
+		return storage
+	}
+	for key, value := range g.Alloc[proxyAddress].Storage {
+		if _, ok := storage[key]; !ok {
+			storage[key] = value
+		}
+	}
+	return storage
+}
+
 // Commit writes the block and state of a genesis specification to the database.
 // The block is committed as the canonical head block.
 func (g *Genesis) Commit(db ethdb.Database) (*types.Block, error) {
@@ -389,6 +419,13 @@ func (g *Genesis) Commit(db ethdb.Database) (*types.Block, error) {
 	if err := config.CheckConfigForkOrder(); err != nil {
 		return nil, err
 	}
+	statedb, err := state.New(block.Root(), state.NewDatabase(db), nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySystemContracts(config, block.Time(), statedb); err != nil {
+		return nil, err
+	}
 	rawdb.WriteBlock(db, block)
 	rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), nil)
 	rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
@@ -0,0 +1,32 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rawdb
+
+import (
+	"github.com/ava-labs/coreth/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ReadOnlinePruning retrieves the progress of the pending online pruning
+// session, if any.
+func ReadOnlinePruning(db ethdb.KeyValueReader) []byte {
+	data, _ := db.Get(onlinePruningKey)
+	return data
+}
+
+// WriteOnlinePruning stores the progress of the pending online pruning
+// session.
+func WriteOnlinePruning(db ethdb.KeyValueWriter, progress []byte) {
+	if err := db.Put(onlinePruningKey, progress); err != nil {
+		log.Crit("Failed to store online pruning progress", "err", err)
+	}
+}
+
+// DeleteOnlinePruning removes the progress of the online pruning session once
+// it completed.
+func DeleteOnlinePruning(db ethdb.KeyValueWriter) {
+	if err := db.Delete(onlinePruningKey); err != nil {
+		log.Crit("Failed to delete online pruning progress", "err", err)
+	}
+}
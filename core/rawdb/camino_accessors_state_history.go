@@ -0,0 +1,67 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rawdb
+
+import (
+	"bytes"
+
+	"github.com/ava-labs/coreth/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ReadStateHistory retrieves the state diff of the accepted block at [number].
+func ReadStateHistory(db ethdb.KeyValueReader, number uint64) []byte {
+	data, _ := db.Get(stateHistoryKey(number))
+	return data
+}
+
+// WriteStateHistory stores the state diff of the accepted block at [number].
+func WriteStateHistory(db ethdb.KeyValueWriter, number uint64, data []byte) {
+	if err := db.Put(stateHistoryKey(number), data); err != nil {
+		log.Crit("Failed to store state history", "err", err)
+	}
+}
+
+// DeleteStateHistory removes the state diff of the accepted block at [number].
+func DeleteStateHistory(db ethdb.KeyValueWriter, number uint64) {
+	if err := db.Delete(stateHistoryKey(number)); err != nil {
+		log.Crit("Failed to delete state history", "err", err)
+	}
+}
+
+// DeleteStateHistoryBelow removes the state diffs of all blocks below [number]
+// and returns the number of removed diffs.
+func DeleteStateHistoryBelow(db ethdb.KeyValueStore, number uint64) (int, error) {
+	var (
+		it    = db.NewIterator(stateHistoryPrefix, nil)
+		batch = db.NewBatch()
+		limit = stateHistoryKey(number)
+		count int
+	)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(limit) {
+			continue
+		}
+		if bytes.Compare(key, limit) >= 0 {
+			break
+		}
+		if err := batch.Delete(key); err != nil {
+			return count, err
+		}
+		count++
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return count, err
+			}
+			batch.Reset()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return count, err
+	}
+	return count, batch.Write()
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rawdb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/coreth/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	DBLeveldb = "leveldb"
+	DBPebble  = "pebble"
+)
+
+// OpenOptions contains the options to open a persistent key-value database
+type OpenOptions struct {
+	Type      string // "leveldb" | "pebble", empty to use the type of the existing database or leveldb
+	Directory string // the datadir
+	Namespace string // the namespace for database relevant metrics
+	Cache     int    // the capacity(in megabytes) of the data caching
+	Handles   int    // number of files to be open simultaneously
+	ReadOnly  bool
+}
+
+// Open opens a persistent key-value database without a freezer, of the type
+// selected by [o] or of the type of the database already in the directory.
+// Opening an existing database with another type fails.
+// It is meant for tools embedding coreth: the VM runs on the database provided
+// by avalanchego, so there is no flag or config selecting the backend.
+func Open(o OpenOptions) (ethdb.Database, error) {
+	existingDb := PreexistingDatabase(o.Directory)
+	if len(existingDb) != 0 && len(o.Type) != 0 && o.Type != existingDb {
+		return nil, fmt.Errorf("db type choice was %v but found pre-existing %v database in specified data directory", o.Type, existingDb)
+	}
+	dbType := o.Type
+	if len(dbType) == 0 {
+		dbType = existingDb
+	}
+	switch dbType {
+	case DBPebble:
+		log.Info("Using pebble as the backing database")
+		return NewPebbleDBDatabase(o.Directory, o.Cache, o.Handles, o.Namespace, o.ReadOnly)
+	case DBLeveldb, "":
+		log.Info("Using leveldb as the backing database")
+		return NewLevelDBDatabase(o.Directory, o.Cache, o.Handles, o.Namespace, o.ReadOnly)
+	default:
+		return nil, fmt.Errorf("unknown db type %q, expected %q or %q", dbType, DBLeveldb, DBPebble)
+	}
+}
+
+// PreexistingDatabase checks the given data directory whether a database is
+// already instantiated at that location, and if so, returns the type of
+// database (or the empty string).
+func PreexistingDatabase(path string) string {
+	if _, err := os.Stat(filepath.Join(path, "CURRENT")); err != nil {
+		return "" // No pre-existing db
+	}
+	// Pebble keeps an OPTIONS file along with the leveldb compatible ones
+	if matches, _ := filepath.Glob(filepath.Join(path, "OPTIONS*")); len(matches) > 0 {
+		return DBPebble
+	}
+	return DBLeveldb
+}
@@ -0,0 +1,19 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build !(arm64 || amd64)
+// +build !arm64,!amd64
+
+package rawdb
+
+import (
+	"errors"
+
+	"github.com/ava-labs/coreth/ethdb"
+)
+
+// NewPebbleDBDatabase is unavailable as pebble is only supported on 64-bit
+// platforms.
+func NewPebbleDBDatabase(string, int, int, string, bool) (ethdb.Database, error) {
+	return nil, errors.New("pebble is not supported on this platform")
+}
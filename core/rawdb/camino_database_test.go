@@ -0,0 +1,48 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:build arm64 || amd64
+// +build arm64 amd64
+
+package rawdb
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenDatabaseType(t *testing.T) {
+	for _, dbType := range []string{DBLeveldb, DBPebble} {
+		t.Run(dbType, func(t *testing.T) {
+			require := require.New(t)
+			dir := filepath.Join(t.TempDir(), "chaindata")
+			other := DBPebble
+			if dbType == DBPebble {
+				other = DBLeveldb
+			}
+
+			require.Empty(PreexistingDatabase(dir))
+			db, err := Open(OpenOptions{Type: dbType, Directory: dir})
+			require.NoError(err)
+			require.NoError(db.Put([]byte("key"), []byte("value")))
+			require.NoError(db.Close())
+			require.Equal(dbType, PreexistingDatabase(dir))
+
+			// The existing database is opened without a type
+			db, err = Open(OpenOptions{Directory: dir})
+			require.NoError(err)
+			value, err := db.Get([]byte("key"))
+			require.NoError(err)
+			require.Equal([]byte("value"), value)
+			require.NoError(db.Close())
+
+			_, err = Open(OpenOptions{Type: other, Directory: dir})
+			require.ErrorContains(err, "pre-existing")
+		})
+	}
+
+	_, err := Open(OpenOptions{Type: "rocksdb", Directory: t.TempDir()})
+	require.ErrorContains(t, err, "unknown db type")
+}
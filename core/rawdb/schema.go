@@ -65,6 +65,9 @@ var (
 	// offlinePruningKey tracks runs of offline pruning
 	offlinePruningKey = []byte("OfflinePruning")
 
+	// onlinePruningKey tracks the progress of an online pruning session
+	onlinePruningKey = []byte("OnlinePruning")
+
 	// populateMissingTriesKey tracks runs of trie backfills
 	populateMissingTriesKey = []byte("PopulateMissingTries")
 
@@ -89,6 +92,8 @@ var (
 	SnapshotStoragePrefix = []byte("o") // SnapshotStoragePrefix + account hash + storage hash -> storage trie value
 	CodePrefix            = []byte("c") // CodePrefix + code hash -> account code
 
+	stateHistoryPrefix = []byte("sh") // stateHistoryPrefix + num (uint64 big endian) -> state diff of the accepted block
+
 	// State sync progress keys and prefixes
 	syncRootKey            = []byte("sync_root")     // indicates the root of the main account trie currently being synced
 	syncStorageTriesPrefix = []byte("sync_storage")  // syncStorageTriesPrefix + trie root + account hash: indicates a storage trie must be fetched for the account
@@ -208,6 +213,11 @@ func IsCodeKey(key []byte) (bool, []byte) {
 	return false, nil
 }
 
+// stateHistoryKey = stateHistoryPrefix + num (uint64 big endian)
+func stateHistoryKey(number uint64) []byte {
+	return append(stateHistoryPrefix, encodeBlockNumber(number)...)
+}
+
 // configKey = configPrefix + hash
 func configKey(hash common.Hash) []byte {
 	return append(configPrefix, hash.Bytes()...)
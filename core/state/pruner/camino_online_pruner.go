@@ -0,0 +1,598 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pruner
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/ethdb"
+	"github.com/ava-labs/coreth/metrics"
+	"github.com/ava-labs/coreth/trie"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// markRetries is the number of attempts to mark the live state, as the
+	// tries kept in memory may be dereferenced while they are traversed.
+	markRetries = 5
+
+	// progressPrecision is the precision of the sweeping progress, which is
+	// reported in parts per million of the key space.
+	progressPrecision = 1_000_000
+
+	phaseIdle     = "idle"
+	phaseMarking  = "marking"
+	phaseSweeping = "sweeping"
+	phaseCompact  = "compacting"
+)
+
+var (
+	ErrOnlinePruningRunning = errors.New("online pruning is already running")
+
+	onlinePruningRunningGauge   = metrics.NewRegisteredGauge("state/pruner/online/running", nil)
+	onlinePruningProgressGauge  = metrics.NewRegisteredGauge("state/pruner/online/progress", nil)
+	onlinePruningMarkedCounter  = metrics.NewRegisteredCounter("state/pruner/online/marked", nil)
+	onlinePruningScannedCounter = metrics.NewRegisteredCounter("state/pruner/online/scanned", nil)
+	onlinePruningDeletedCounter = metrics.NewRegisteredCounter("state/pruner/online/deleted", nil)
+	onlinePruningDeletedSize    = metrics.NewRegisteredCounter("state/pruner/online/deleted/size", nil)
+)
+
+// OnlinePrunerConfig contains the configuration of the online pruner
+type OnlinePrunerConfig struct {
+	BloomSize uint64 // Size (MB) of the bloom filter of the live state
+	Rate      uint64 // Maximum number of state entries processed per second (0 = unlimited)
+}
+
+// LiveRoots returns the roots of the state tries which must be kept. The first
+// root must be fully available on disk, the other ones are traversed as a
+// difference to it.
+type LiveRoots func() ([]common.Hash, error)
+
+// OnlinePruningStatus is the progress of the online pruning session
+type OnlinePruningStatus struct {
+	Running      bool        `json:"running"`
+	Phase        string      `json:"phase"`
+	Root         common.Hash `json:"root"`
+	Started      time.Time   `json:"started"`
+	Marked       uint64      `json:"marked"`
+	Scanned      uint64      `json:"scanned"`
+	Deleted      uint64      `json:"deleted"`
+	DeletedBytes uint64      `json:"deletedBytes"`
+	Progress     float64     `json:"progress"` // Fraction of the key space swept
+	Error        string      `json:"error,omitempty"`
+}
+
+// onlinePruningProgress is persisted to resume the session after a restart
+type onlinePruningProgress struct {
+	Started uint64 // Unix timestamp of the start of the session
+	Cursor  []byte // Next key to sweep
+}
+
+// OnlinePruner deletes the stale state while the chain keeps running.
+//
+// The pruner first marks the live state in a bloom filter by traversing the
+// trie on disk and the tries kept in memory as a difference to it. All the
+// state entries written to the database returned by [Database] during the
+// session are marked as well, so that the nodes of the tries created meanwhile
+// are kept. It then sweeps the database, deleting the trie nodes and the codes
+// which are not marked.
+//
+// The progress is persisted, so that the session is resumed after a restart.
+// As the entries written while the node was down cannot be tracked, the live
+// state is marked again and the sweep continues where it stopped.
+type OnlinePruner struct {
+	db      ethdb.Database
+	config  OnlinePrunerConfig
+	limiter *rate.Limiter
+
+	// [lock] protects [bloom] and serializes the deletions with the writes
+	// of state entries.
+	lock   sync.Mutex
+	bloom  *stateBloom
+	active int32 // 1 while the written state entries are marked
+
+	statusLock sync.Mutex
+	status     OnlinePruningStatus
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+
+	marked, scanned, deleted, deletedBytes, progress uint64
+}
+
+// NewOnlinePruner returns an idle online pruner of the state in [db]
+func NewOnlinePruner(db ethdb.Database, config OnlinePrunerConfig) *OnlinePruner {
+	p := &OnlinePruner{
+		db:     db,
+		config: config,
+		status: OnlinePruningStatus{Phase: phaseIdle},
+	}
+	if config.Rate > 0 {
+		p.limiter = rate.NewLimiter(rate.Limit(config.Rate), int(config.Rate))
+	}
+	return p
+}
+
+// Database returns [db] wrapping the writes of state entries so that they are
+// kept by a running session. The state must be written through it.
+func (p *OnlinePruner) Database() ethdb.Database {
+	return &protectedDatabase{Database: p.db, pruner: p}
+}
+
+// Pending returns true if a session was interrupted before completing
+func (p *OnlinePruner) Pending() bool {
+	return len(rawdb.ReadOnlinePruning(p.db)) != 0
+}
+
+// Start starts a session in the background, or resumes the pending one,
+// keeping the tries of [roots] read through [triedb]
+func (p *OnlinePruner) Start(triedb *trie.Database, roots LiveRoots) error {
+	p.statusLock.Lock()
+	defer p.statusLock.Unlock()
+
+	if p.status.Running {
+		return ErrOnlinePruningRunning
+	}
+
+	progress := &onlinePruningProgress{Started: uint64(time.Now().Unix())}
+	if data := rawdb.ReadOnlinePruning(p.db); len(data) != 0 {
+		if err := rlp.DecodeBytes(data, progress); err != nil {
+			return fmt.Errorf("failed to decode online pruning progress: %w", err)
+		}
+		log.Info("Resuming online pruning", "started", time.Unix(int64(progress.Started), 0), "cursor", common.Bytes2Hex(progress.Cursor))
+	} else {
+		if err := p.writeProgress(p.db, progress); err != nil {
+			return err
+		}
+		log.Info("Starting online pruning")
+	}
+
+	for _, counter := range []*uint64{&p.marked, &p.scanned, &p.deleted, &p.deletedBytes, &p.progress} {
+		atomic.StoreUint64(counter, 0)
+	}
+	p.status = OnlinePruningStatus{
+		Running: true,
+		Phase:   phaseMarking,
+		Started: time.Unix(int64(progress.Started), 0),
+	}
+	onlinePruningRunningGauge.Update(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.run(ctx, triedb, roots, progress)
+	}()
+	return nil
+}
+
+// Stop interrupts the running session, if any, and waits for it to return.
+// The session is resumed on the next start.
+func (p *OnlinePruner) Stop() {
+	p.statusLock.Lock()
+	cancel := p.cancel
+	p.statusLock.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	p.wg.Wait()
+}
+
+// Status returns the progress of the current or last session
+func (p *OnlinePruner) Status() OnlinePruningStatus {
+	p.statusLock.Lock()
+	status := p.status
+	p.statusLock.Unlock()
+
+	status.Marked = atomic.LoadUint64(&p.marked)
+	status.Scanned = atomic.LoadUint64(&p.scanned)
+	status.Deleted = atomic.LoadUint64(&p.deleted)
+	status.DeletedBytes = atomic.LoadUint64(&p.deletedBytes)
+	status.Progress = float64(atomic.LoadUint64(&p.progress)) / progressPrecision
+	return status
+}
+
+func (p *OnlinePruner) setPhase(phase string) {
+	p.statusLock.Lock()
+	defer p.statusLock.Unlock()
+
+	p.status.Phase = phase
+}
+
+func (p *OnlinePruner) run(ctx context.Context, triedb *trie.Database, roots LiveRoots, progress *onlinePruningProgress) {
+	start := time.Now()
+	err := p.prune(ctx, triedb, roots, progress)
+
+	p.lock.Lock()
+	atomic.StoreInt32(&p.active, 0)
+	p.bloom = nil
+	p.lock.Unlock()
+
+	p.statusLock.Lock()
+	defer p.statusLock.Unlock()
+
+	p.status.Running = false
+	p.status.Phase = phaseIdle
+	p.cancel = nil
+	onlinePruningRunningGauge.Update(0)
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		log.Info("Online pruning interrupted", "deleted", atomic.LoadUint64(&p.deleted), "elapsed", common.PrettyDuration(time.Since(start)))
+	case err != nil:
+		p.status.Error = err.Error()
+		log.Error("Online pruning failed", "err", err)
+	default:
+		log.Info("Online pruning successful", "deleted", atomic.LoadUint64(&p.deleted), "size", common.StorageSize(atomic.LoadUint64(&p.deletedBytes)), "elapsed", common.PrettyDuration(time.Since(start)))
+	}
+}
+
+func (p *OnlinePruner) prune(ctx context.Context, triedb *trie.Database, liveRoots LiveRoots, progress *onlinePruningProgress) error {
+	bloom, err := newStateBloomWithSize(p.config.BloomSize)
+	if err != nil {
+		return err
+	}
+
+	// Mark the written state entries before reading the live state, so that
+	// the entries written after it is read are kept
+	p.lock.Lock()
+	p.bloom = bloom
+	atomic.StoreInt32(&p.active, 1)
+	p.lock.Unlock()
+
+	for attempt := 1; ; attempt++ {
+		roots, err := liveRoots()
+		if err != nil {
+			return err
+		}
+		err = p.markState(ctx, triedb, roots)
+		if err == nil {
+			break
+		}
+		var missingErr *trie.MissingNodeError
+		if !errors.As(err, &missingErr) || attempt == markRetries {
+			return err
+		}
+		log.Warn("Live state changed while being marked, retrying", "attempt", attempt, "err", err)
+	}
+	log.Info("Marked live state", "nodes", atomic.LoadUint64(&p.marked))
+
+	p.setPhase(phaseSweeping)
+	deleted, err := p.sweep(ctx, progress)
+	if err != nil {
+		return err
+	}
+	rawdb.DeleteOnlinePruning(p.db)
+	atomic.StoreUint64(&p.progress, progressPrecision)
+	onlinePruningProgressGauge.Update(progressPrecision)
+
+	if deleted >= rangeCompactionThreshold {
+		p.setPhase(phaseCompact)
+		return compactDatabase(p.db)
+	}
+	return nil
+}
+
+// markState marks the state entries of [roots], traversing all but the first
+// one as a difference to it
+func (p *OnlinePruner) markState(ctx context.Context, triedb *trie.Database, roots []common.Hash) error {
+	if len(roots) == 0 {
+		return errors.New("no live state to keep")
+	}
+	p.statusLock.Lock()
+	p.status.Root = roots[0]
+	p.statusLock.Unlock()
+
+	base, err := trie.New(common.Hash{}, roots[0], triedb)
+	if err != nil {
+		return err
+	}
+	if err := p.markTrie(ctx, triedb, nil, base); err != nil {
+		return err
+	}
+	for _, root := range roots[1:] {
+		if root == roots[0] {
+			continue
+		}
+		accTrie, err := trie.New(common.Hash{}, root, triedb)
+		if err != nil {
+			return err
+		}
+		if err := p.markTrie(ctx, triedb, base, accTrie); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markTrie marks the nodes of the account trie [accTrie] which are not in
+// [base], if any, along with the storage tries and codes of its accounts
+func (p *OnlinePruner) markTrie(ctx context.Context, triedb *trie.Database, base, accTrie *trie.Trie) error {
+	it := accTrie.NodeIterator(nil)
+	if base != nil {
+		it, _ = trie.NewDifferenceIterator(base.NodeIterator(nil), it)
+	}
+	for it.Next(true) {
+		if err := p.throttle(ctx); err != nil {
+			return err
+		}
+		if hash := it.Hash(); hash != (common.Hash{}) {
+			p.mark(hash[:])
+		}
+		if !it.Leaf() {
+			continue
+		}
+
+		var acc types.StateAccount
+		if err := rlp.DecodeBytes(it.LeafBlob(), &acc); err != nil {
+			return err
+		}
+		if !bytes.Equal(acc.CodeHash, emptyCode) {
+			p.mark(acc.CodeHash)
+		}
+		if acc.Root == emptyRoot {
+			continue
+		}
+
+		owner := common.BytesToHash(it.LeafKey())
+		var baseStorage *trie.Trie
+		if base != nil {
+			value, err := base.TryGet(it.LeafKey())
+			if err != nil {
+				return err
+			}
+			if len(value) != 0 {
+				var baseAcc types.StateAccount
+				if err := rlp.DecodeBytes(value, &baseAcc); err != nil {
+					return err
+				}
+				if baseAcc.Root == acc.Root {
+					// The storage trie was marked along with [base]
+					continue
+				}
+				if baseStorage, err = trie.New(owner, baseAcc.Root, triedb); err != nil {
+					return err
+				}
+			}
+		}
+		storageTrie, err := trie.New(owner, acc.Root, triedb)
+		if err != nil {
+			return err
+		}
+		storageIt := storageTrie.NodeIterator(nil)
+		if baseStorage != nil {
+			storageIt, _ = trie.NewDifferenceIterator(baseStorage.NodeIterator(nil), storageIt)
+		}
+		for storageIt.Next(true) {
+			if err := p.throttle(ctx); err != nil {
+				return err
+			}
+			if hash := storageIt.Hash(); hash != (common.Hash{}) {
+				p.mark(hash[:])
+			}
+		}
+		if err := storageIt.Error(); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// mark adds the hash of a trie node or code to the live state
+func (p *OnlinePruner) mark(hash []byte) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.bloom.bloom.Add(stateBloomHasher(hash))
+	atomic.AddUint64(&p.marked, 1)
+	onlinePruningMarkedCounter.Inc(1)
+}
+
+// protect marks the state entry of [key] if a session is marking the live
+// state
+func (p *OnlinePruner) protect(key []byte) {
+	if atomic.LoadInt32(&p.active) == 0 {
+		return
+	}
+	if isCode, codeKey := rawdb.IsCodeKey(key); isCode {
+		key = codeKey
+	} else if len(key) != common.HashLength {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.bloom != nil {
+		p.bloom.bloom.Add(stateBloomHasher(key))
+	}
+}
+
+// throttle enforces the configured rate of processed state entries
+func (p *OnlinePruner) throttle(ctx context.Context) error {
+	if p.limiter == nil {
+		return ctx.Err()
+	}
+	return p.limiter.Wait(ctx)
+}
+
+type pendingDeletion struct {
+	key, checkKey []byte
+	size          int
+}
+
+// sweep deletes the state entries which are not marked, starting from the
+// cursor of [progress], and returns the number of deleted entries
+func (p *OnlinePruner) sweep(ctx context.Context, progress *onlinePruningProgress) (int, error) {
+	var (
+		count   int
+		pending []pendingDeletion
+		size    int
+		logged  = time.Now()
+		batch   = p.db.NewBatch()
+		iter    = p.db.NewIterator(nil, progress.Cursor)
+	)
+	// We wrap iter.Release() in an anonymous function so that the [iter]
+	// value captured is the value of [iter] at the end of the function.
+	defer func() {
+		iter.Release()
+	}()
+
+	for iter.Next() {
+		if err := p.throttle(ctx); err != nil {
+			return count, err
+		}
+		key := iter.Key()
+		atomic.AddUint64(&p.scanned, 1)
+		onlinePruningScannedCounter.Inc(1)
+
+		isCode, codeKey := rawdb.IsCodeKey(key)
+		if len(key) != common.HashLength && !isCode {
+			continue
+		}
+		checkKey := key
+		if isCode {
+			checkKey = codeKey
+		}
+		if p.contains(checkKey) {
+			continue
+		}
+		entry := pendingDeletion{key: common.CopyBytes(key), size: len(key) + len(iter.Value())}
+		entry.checkKey = entry.key
+		if isCode {
+			entry.checkKey = entry.key[len(entry.key)-common.HashLength:]
+		}
+		pending = append(pending, entry)
+		size += entry.size
+
+		if size < ethdb.IdealBatchSize {
+			continue
+		}
+		deleted, err := p.deleteEntries(batch, pending, progress, entry.key)
+		if err != nil {
+			return count, err
+		}
+		count += deleted
+		pending, size = pending[:0], 0
+
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Pruning state data online", "deleted", count, "progress", fmt.Sprintf("%.2f%%", float64(atomic.LoadUint64(&p.progress))*100/progressPrecision))
+			logged = time.Now()
+		}
+
+		// Recreate the iterator after every batch commit in order to allow
+		// the underlying compactor to delete the entries.
+		iter.Release()
+		iter = p.db.NewIterator(nil, entry.key)
+	}
+	if err := iter.Error(); err != nil {
+		return count, fmt.Errorf("failed to iterate db during online pruning: %w", err)
+	}
+	deleted, err := p.deleteEntries(batch, pending, progress, nil)
+	count += deleted
+	return count, err
+}
+
+// contains returns true if the state entry of [key] is marked
+func (p *OnlinePruner) contains(key []byte) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.bloom.bloom.Contains(stateBloomHasher(key))
+}
+
+// deleteEntries deletes the [pending] entries which have not been written
+// since they were scanned and persists [cursor] as the progress.
+// The entries written after the deletion are kept, as the writes are marked
+// while holding the lock.
+func (p *OnlinePruner) deleteEntries(batch ethdb.Batch, pending []pendingDeletion, progress *onlinePruningProgress, cursor []byte) (int, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var count int
+	for _, entry := range pending {
+		if p.bloom.bloom.Contains(stateBloomHasher(entry.checkKey)) {
+			continue
+		}
+		if err := batch.Delete(entry.key); err != nil {
+			return count, err
+		}
+		count++
+		atomic.AddUint64(&p.deleted, 1)
+		atomic.AddUint64(&p.deletedBytes, uint64(entry.size))
+		onlinePruningDeletedCounter.Inc(1)
+		onlinePruningDeletedSize.Inc(int64(entry.size))
+	}
+
+	if cursor != nil {
+		progress.Cursor = cursor
+		if len(cursor) >= 8 {
+			done := binary.BigEndian.Uint64(cursor[:8])
+			atomic.StoreUint64(&p.progress, uint64(float64(done)/math.MaxUint64*progressPrecision))
+			onlinePruningProgressGauge.Update(int64(atomic.LoadUint64(&p.progress)))
+		}
+		if err := p.writeProgress(batch, progress); err != nil {
+			return count, err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return count, err
+	}
+	batch.Reset()
+	return count, nil
+}
+
+func (p *OnlinePruner) writeProgress(db ethdb.KeyValueWriter, progress *onlinePruningProgress) error {
+	data, err := rlp.EncodeToBytes(progress)
+	if err != nil {
+		return err
+	}
+	rawdb.WriteOnlinePruning(db, data)
+	return nil
+}
+
+// protectedDatabase marks the state entries written during an online pruning
+// session
+type protectedDatabase struct {
+	ethdb.Database
+	pruner *OnlinePruner
+}
+
+func (db *protectedDatabase) Put(key []byte, value []byte) error {
+	db.pruner.protect(key)
+	return db.Database.Put(key, value)
+}
+
+func (db *protectedDatabase) NewBatch() ethdb.Batch {
+	return ethdb.HookedBatch{
+		Batch: db.Database.NewBatch(),
+		OnPut: db.onPut,
+	}
+}
+
+func (db *protectedDatabase) NewBatchWithSize(size int) ethdb.Batch {
+	return ethdb.HookedBatch{
+		Batch: db.Database.NewBatchWithSize(size),
+		OnPut: db.onPut,
+	}
+}
+
+func (db *protectedDatabase) onPut(key []byte, _ []byte) {
+	db.pruner.protect(key)
+}
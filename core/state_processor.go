@@ -80,6 +80,8 @@ func (p *StateProcessor) Process(block *types.Block, parent *types.Header, state
 
 	// Configure any stateful precompiles that should go into effect during this block.
 	p.config.CheckConfigurePrecompiles(new(big.Int).SetUint64(parent.Time), block, statedb)
+	// Upgrade the system contracts whose network upgrade activates in this block.
+	ApplyContractUpgrades(p.config, new(big.Int).SetUint64(parent.Time), timestamp, statedb)
 
 	// Mutate the block and state according to any hard-fork specs
 	if p.config.DAOForkSupport && p.config.DAOForkBlock != nil && p.config.DAOForkBlock.Cmp(block.Number()) == 0 {
@@ -273,7 +273,8 @@ func (st *StateTransition) preCheck() error {
 				st.msg.From().Hex(), codeHash)
 		}
 		// Make sure the sender is not prohibited
-		if vm.IsProhibited(st.msg.From()) {
+		if vm.IsProhibited(st.msg.From()) ||
+			st.msg.From() == st.evm.ChainConfig().CaminoSystemContracts(st.evm.Context.Time).BlackholeAddress {
 			return fmt.Errorf("%w: address %v", vmerrs.ErrAddrProhibited, st.msg.From())
 		}
 	}
@@ -27,6 +27,7 @@ func (mb *mockBlockContext) Timestamp() *big.Int { return new(big.Int).SetUint64
 type mockAccessibleState struct {
 	state        *state.StateDB
 	blockContext *mockBlockContext
+	chainConfig  precompile.ChainConfig
 
 	// NativeAssetCall return values
 	ret          []byte
@@ -38,6 +39,8 @@ func (m *mockAccessibleState) GetStateDB() precompile.StateDB { return m.state }
 
 func (m *mockAccessibleState) GetBlockContext() precompile.BlockContext { return m.blockContext }
 
+func (m *mockAccessibleState) GetChainConfig() precompile.ChainConfig { return m.chainConfig }
+
 func (m *mockAccessibleState) NativeAssetCall(common.Address, []byte, uint64, uint64, bool) ([]byte, uint64, error) {
 	return m.ret, m.remainingGas, m.err
 }
@@ -381,6 +381,29 @@ func (l *txList) Filter(costLimit *big.Int, gasLimit uint64) (types.Transactions
 	return removed, invalids
 }
 
+// FilterFunc removes all transactions from the list for which [filter] returns
+// true. Like Filter, strict lists additionally return all transactions with a
+// nonce above the lowest removed one, as they are no longer executable.
+func (l *txList) FilterFunc(filter func(*types.Transaction) bool) (types.Transactions, types.Transactions) {
+	removed := l.txs.Filter(filter)
+	if len(removed) == 0 {
+		return nil, nil
+	}
+	var invalids types.Transactions
+	// If the list was strict, filter anything above the lowest nonce
+	if l.strict {
+		lowest := uint64(math.MaxUint64)
+		for _, tx := range removed {
+			if nonce := tx.Nonce(); lowest > nonce {
+				lowest = nonce
+			}
+		}
+		invalids = l.txs.filter(func(tx *types.Transaction) bool { return tx.Nonce() > lowest })
+	}
+	l.txs.reheap()
+	return removed, invalids
+}
+
 // Cap places a hard limit on the number of items, returning all transactions
 // exceeding that limit.
 func (l *txList) Cap(threshold int) types.Transactions {
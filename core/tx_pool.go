@@ -124,6 +124,7 @@ var (
 	pendingReplaceMeter   = metrics.NewRegisteredMeter("txpool/pending/replace", nil)
 	pendingRateLimitMeter = metrics.NewRegisteredMeter("txpool/pending/ratelimit", nil) // Dropped due to rate limiting
 	pendingNofundsMeter   = metrics.NewRegisteredMeter("txpool/pending/nofunds", nil)   // Dropped due to out-of-funds
+	pendingNoKycMeter     = metrics.NewRegisteredMeter("txpool/pending/nokyc", nil)     // Dropped due to missing KYC
 
 	// Metrics for the queued pool
 	queuedDiscardMeter   = metrics.NewRegisteredMeter("txpool/queued/discard", nil)
@@ -131,6 +132,7 @@ var (
 	queuedRateLimitMeter = metrics.NewRegisteredMeter("txpool/queued/ratelimit", nil) // Dropped due to rate limiting
 	queuedNofundsMeter   = metrics.NewRegisteredMeter("txpool/queued/nofunds", nil)   // Dropped due to out-of-funds
 	queuedEvictionMeter  = metrics.NewRegisteredMeter("txpool/queued/eviction", nil)  // Dropped due to lifetime
+	queuedNoKycMeter     = metrics.NewRegisteredMeter("txpool/queued/nokyc", nil)     // Dropped due to missing KYC
 
 	// General tx metrics
 	knownTxMeter       = metrics.NewRegisteredMeter("txpool/known", nil)
@@ -138,6 +140,7 @@ var (
 	invalidTxMeter     = metrics.NewRegisteredMeter("txpool/invalid", nil)
 	underpricedTxMeter = metrics.NewRegisteredMeter("txpool/underpriced", nil)
 	overflowedTxMeter  = metrics.NewRegisteredMeter("txpool/overflowed", nil)
+	noKycTxMeter       = metrics.NewRegisteredMeter("txpool/nokyc", nil)
 	// throttleTxMeter counts how many transactions are rejected due to too-many-changes between
 	// txpool reorgs.
 	throttleTxMeter = metrics.NewRegisteredMeter("txpool/throttle", nil)
@@ -275,7 +278,8 @@ type TxPool struct {
 	eip1559  bool // Fork indicator whether we are using EIP-1559 type transactions.
 	cortina  bool // Fork indicator whether cortina is activated. (equivalent to Shanghai in go-ethereum)
 
-	fixedBaseFee bool // Fork has introduced fixed base fee
+	fixedBaseFee bool             // Fork has introduced fixed base fee
+	kycPolicy    params.KycPolicy // KYC policy transactions have to fulfill
 
 	currentHead *types.Header
 	// [currentState] is the state of the blockchain head. It is reset whenever
@@ -371,9 +375,9 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig, chain block
 	pool.wg.Add(1)
 	go pool.loop()
 
-	if !chainconfig.IsSunrisePhase0(new(big.Int).SetUint64(chain.CurrentBlock().Header().Time)) {
-		pool.startPeriodicFeeUpdate()
-	}
+	// The fixed base fee of Sunrise Phase 0 is updated periodically as well,
+	// as a scheduled base fee may be activated between blocks
+	pool.startPeriodicFeeUpdate()
 	return pool
 }
 
@@ -677,6 +681,40 @@ func (pool *TxPool) checkTxState(from common.Address, tx *types.Transaction) err
 	return nil
 }
 
+// checkTxKyc checks that the sender is KYC verified if the transaction
+// requires it according to the current KYC policy.
+func (pool *TxPool) checkTxKyc(from common.Address, tx *types.Transaction) error {
+	if !pool.kycPolicy.Required(tx.To(), tx.Value()) {
+		return nil
+	}
+	ctrl := pool.chain.AdminController()
+	if ctrl == nil {
+		return nil
+	}
+
+	pool.currentStateLock.Lock()
+	defer pool.currentStateLock.Unlock()
+
+	if !ctrl.KycVerified(pool.currentHead, pool.currentState, from) {
+		noKycTxMeter.Mark(1)
+		return fmt.Errorf("%w: address %s", vmerrs.ErrNotKycVerified, from.Hex())
+	}
+	return nil
+}
+
+// kycFilter returns a filter matching all transactions of [addr] which require
+// KYC according to the current KYC policy, or nil if [addr] is KYC verified.
+// The caller must hold [pool.currentStateLock].
+func (pool *TxPool) kycFilter(addr common.Address) func(*types.Transaction) bool {
+	ctrl := pool.chain.AdminController()
+	if ctrl == nil || ctrl.KycVerified(pool.currentHead, pool.currentState, addr) {
+		return nil
+	}
+	return func(tx *types.Transaction) bool {
+		return pool.kycPolicy.Required(tx.To(), tx.Value())
+	}
+}
+
 // validateTx checks whether a transaction is valid according to the consensus
 // rules and adheres to some heuristic limits of the local node (price and size).
 func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
@@ -734,6 +772,10 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 	if err := pool.checkTxState(from, tx); err != nil {
 		return err
 	}
+	// Ensure the sender is KYC verified if required
+	if err := pool.checkTxKyc(from, tx); err != nil {
+		return err
+	}
 	// Transactor should have enough funds to cover the costs
 
 	// Ensure the transaction has more gas than the basic tx fee.
@@ -1432,11 +1474,34 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	pool.eip2718 = pool.chainconfig.IsApricotPhase2(timestamp)
 	pool.eip1559 = pool.chainconfig.IsApricotPhase3(timestamp)
 	pool.cortina = pool.chainconfig.IsCortina(timestamp)
-	if pool.chainconfig.IsSunrisePhase0(timestamp) {
+	pool.kycPolicy = pool.chainconfig.KycPolicy(timestamp)
+	if pool.chainconfig.IsSunrisePhase1(timestamp) {
+		// The dynamic base fee never drops below the floor
+		pool.fixedBaseFee = false
+		newMinimumFee := new(big.Int).SetUint64(params.SunrisePhase0BaseFee)
+		if ctrl := pool.chain.AdminController(); ctrl != nil {
+			if newMinimumFee, _, err = ctrl.GetBaseFeeBounds(newHead, statedb); err != nil {
+				// Keep the minimum fee of the previous head
+				log.Error("Failed to get the base fee bounds", "err", err)
+				return
+			}
+		}
+		pool.minimumFee = newMinimumFee
+	} else if pool.chainconfig.IsSunrisePhase0(timestamp) {
 		pool.fixedBaseFee = true
 		var newMinimumFee *big.Int
 		if ctrl := pool.chain.AdminController(); ctrl != nil {
-			newMinimumFee = ctrl.GetFixedBaseFee(newHead, statedb)
+			// The next block is built no earlier than now, respect a base fee
+			// change activated in the meantime
+			nextTimestamp := uint64(time.Now().Unix())
+			if nextTimestamp < newHead.Time {
+				nextTimestamp = newHead.Time
+			}
+			if newMinimumFee, err = ctrl.GetFixedBaseFee(newHead, statedb, nextTimestamp); err != nil {
+				// Keep the minimum fee of the previous head
+				log.Error("Failed to get the fixed base fee", "err", err)
+				return
+			}
 		} else {
 			newMinimumFee = new(big.Int).SetUint64(params.SunrisePhase0BaseFee)
 		}
@@ -1480,6 +1545,18 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) []*types.Trans
 		log.Trace("Removed unpayable queued transactions", "count", len(drops))
 		queuedNofundsMeter.Mark(int64(len(drops)))
 
+		// Drop all transactions of senders which are not KYC verified (anymore)
+		var noKycs types.Transactions
+		if filter := pool.kycFilter(addr); filter != nil {
+			noKycs, _ = list.FilterFunc(filter)
+			for _, tx := range noKycs {
+				hash := tx.Hash()
+				pool.all.Remove(hash)
+			}
+			log.Trace("Removed not KYC verified queued transactions", "count", len(noKycs))
+			queuedNoKycMeter.Mark(int64(len(noKycs)))
+		}
+
 		// Gather all executable transactions and promote them
 		readies := list.Ready(pool.pendingNonces.get(addr))
 		for _, tx := range readies {
@@ -1503,10 +1580,10 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) []*types.Trans
 			queuedRateLimitMeter.Mark(int64(len(caps)))
 		}
 		// Mark all the items dropped as removed
-		pool.priced.Removed(len(forwards) + len(drops) + len(caps))
-		queuedGauge.Dec(int64(len(forwards) + len(drops) + len(caps)))
+		pool.priced.Removed(len(forwards) + len(drops) + len(noKycs) + len(caps))
+		queuedGauge.Dec(int64(len(forwards) + len(drops) + len(noKycs) + len(caps)))
 		if pool.locals.contains(addr) {
-			localGauge.Dec(int64(len(forwards) + len(drops) + len(caps)))
+			localGauge.Dec(int64(len(forwards) + len(drops) + len(noKycs) + len(caps)))
 		}
 		// Delete the entire queue entry if it became empty.
 		if list.Empty() {
@@ -1687,9 +1764,29 @@ func (pool *TxPool) demoteUnexecutables() {
 			// Internal shuffle shouldn't touch the lookup set.
 			pool.enqueueTx(hash, tx, false, false)
 		}
-		pendingGauge.Dec(int64(len(olds) + len(drops) + len(invalids)))
+		// Drop all transactions of senders which lost KYC, and park the ones
+		// behind them in the queue
+		var noKycs, parked types.Transactions
+		if filter := pool.kycFilter(addr); filter != nil {
+			noKycs, parked = list.FilterFunc(filter)
+			for _, tx := range noKycs {
+				hash := tx.Hash()
+				log.Trace("Removed not KYC verified pending transaction", "hash", hash)
+				pool.all.Remove(hash)
+			}
+			pendingNoKycMeter.Mark(int64(len(noKycs)))
+
+			for _, tx := range parked {
+				hash := tx.Hash()
+				log.Trace("Demoting pending transaction", "hash", hash)
+
+				// Internal shuffle shouldn't touch the lookup set.
+				pool.enqueueTx(hash, tx, false, false)
+			}
+		}
+		pendingGauge.Dec(int64(len(olds) + len(drops) + len(invalids) + len(noKycs) + len(parked)))
 		if pool.locals.contains(addr) {
-			localGauge.Dec(int64(len(olds) + len(drops) + len(invalids)))
+			localGauge.Dec(int64(len(olds) + len(drops) + len(invalids) + len(noKycs) + len(parked)))
 		}
 		// If there's a gap in front, alert (should never happen) and postpone all transactions
 		if list.Len() > 0 && list.txs.Get(nonce) == nil {
@@ -1755,6 +1852,9 @@ func (pool *TxPool) updateBaseFee() {
 	_, baseFeeEstimate, err := dummy.EstimateNextBaseFee(pool.chainconfig, pool.chain.AdminController(), pool.currentHead, uint64(time.Now().Unix()))
 	if err == nil {
 		pool.priced.SetBaseFee(baseFeeEstimate)
+		if pool.fixedBaseFee {
+			pool.minimumFee = baseFeeEstimate
+		}
 	} else {
 		log.Error("failed to update base fee", "currentHead", pool.currentHead.Hash(), "err", err)
 	}
@@ -82,6 +82,7 @@ type testBlockChain struct {
 	statedb       *state.StateDB
 	gasLimit      uint64
 	chainHeadFeed *event.Feed
+	adminCtrl     admin.AdminController
 	lock          sync.Mutex
 }
 
@@ -134,7 +135,7 @@ func (bc *testBlockChain) SenderCacher() *TxSenderCacher {
 }
 
 func (bc *testBlockChain) AdminController() admin.AdminController {
-	return nil
+	return bc.adminCtrl
 }
 
 func transaction(nonce uint64, gaslimit uint64, key *ecdsa.PrivateKey) *types.Transaction {
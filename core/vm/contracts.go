@@ -33,7 +33,6 @@ import (
 	"fmt"
 	"math/big"
 
-	"github.com/ava-labs/coreth/constants"
 	"github.com/ava-labs/coreth/params"
 	"github.com/ava-labs/coreth/precompile"
 	"github.com/ava-labs/coreth/vmerrs"
@@ -212,9 +211,6 @@ func init() {
 		if _, ok := PrecompileAllNativeAddresses[k]; ok {
 			panic(fmt.Errorf("precompile address collides with existing native address: %s", k))
 		}
-		if k == constants.BlackholeAddr {
-			panic(fmt.Errorf("cannot use address %s for stateful precompile - overlaps with blackhole address", k))
-		}
 
 		// check that [k] belongs to at least one ReservedRange
 		found := false
@@ -232,18 +228,33 @@ func init() {
 
 // ActivePrecompiles returns the precompiles enabled with the current configuration.
 func ActivePrecompiles(rules params.Rules) []common.Address {
+	var precompiles []common.Address
 	switch {
 	case rules.IsBanff:
-		return PrecompiledAddressesBanff
+		precompiles = PrecompiledAddressesBanff
 	case rules.IsApricotPhase2:
-		return PrecompiledAddressesApricotPhase2
+		precompiles = PrecompiledAddressesApricotPhase2
 	case rules.IsIstanbul:
-		return PrecompiledAddressesIstanbul
+		precompiles = PrecompiledAddressesIstanbul
 	case rules.IsByzantium:
-		return PrecompiledAddressesByzantium
+		precompiles = PrecompiledAddressesByzantium
 	default:
-		return PrecompiledAddressesHomestead
+		precompiles = PrecompiledAddressesHomestead
+	}
+	if len(rules.Precompiles) == 0 {
+		return precompiles
+	}
+
+	// Add the stateful precompiles enabled by the chain config, ordered by
+	// address like the registered modules
+	active := make([]common.Address, 0, len(precompiles)+len(rules.Precompiles))
+	active = append(active, precompiles...)
+	for _, module := range precompile.RegisteredModules() {
+		if _, ok := rules.Precompiles[module.Address]; ok {
+			active = append(active, module.Address)
+		}
 	}
+	return active
 }
 
 // RunPrecompiledContract runs and evaluates the output of a precompiled contract.
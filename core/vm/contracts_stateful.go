@@ -21,8 +21,8 @@ import (
 
 var (
 	genesisContractAddr    = common.HexToAddress("0x0100000000000000000000000000000000000000")
-	NativeAssetBalanceAddr = common.HexToAddress("0x0100000000000000000000000000000000000001")
-	NativeAssetCallAddr    = common.HexToAddress("0x0100000000000000000000000000000000000002")
+	NativeAssetBalanceAddr = precompile.NativeAssetBalanceAddress
+	NativeAssetCallAddr    = precompile.NativeAssetCallAddress
 )
 
 // wrappedPrecompiledContract implements StatefulPrecompiledContract by wrapping stateless native precompiled contracts
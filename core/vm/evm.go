@@ -41,7 +41,6 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/ava-labs/coreth/constants"
 	"github.com/ava-labs/coreth/core/admin"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/params"
@@ -57,12 +56,10 @@ var (
 	_ precompile.BlockContext              = &BlockContext{}
 )
 
-// IsProhibited returns true if [addr] is the blackhole address or is
-// with a range reserved for precompiled contracts.
+// IsProhibited returns true if [addr] is within a range reserved for
+// precompiled contracts. The blackhole address configured in the chain
+// config is prohibited by the callers.
 func IsProhibited(addr common.Address) bool {
-	if addr == constants.BlackholeAddr {
-		return true
-	}
 	for _, reservedRange := range precompile.ReservedRanges {
 		if reservedRange.Contains(addr) {
 			return true
@@ -240,6 +237,11 @@ func (evm *EVM) GetBlockContext() precompile.BlockContext {
 	return &evm.Context
 }
 
+// GetChainConfig returns the evm's ChainConfig
+func (evm *EVM) GetChainConfig() precompile.ChainConfig {
+	return evm.chainConfig
+}
+
 // Interpreter returns the current interpreter
 func (evm *EVM) Interpreter() *EVMInterpreter {
 	return evm.interpreter
@@ -254,6 +256,14 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 	if evm.depth > int(params.CallCreateDepth) {
 		return nil, gas, vmerrs.ErrDepth
 	}
+	// Fail if the target or the called function is blacklisted
+	if evm.isBlacklisted(addr, input) {
+		return nil, gas, vmerrs.ErrBlacklisted
+	}
+	// Fail if the KYC policy requires a verified sender for this call
+	if !evm.kycVerified(caller, &addr, value) {
+		return nil, gas, vmerrs.ErrNotKycVerified
+	}
 	// Fail if we're trying to transfer more than the available balance
 	// Note: it is not possible for a negative value to be passed in here due to the fact
 	// that [value] will be popped from the stack and decoded to a *big.Int, which will
@@ -471,6 +481,10 @@ func (evm *EVM) DelegateCall(caller ContractRef, addr common.Address, input []by
 	if evm.depth > int(params.CallCreateDepth) {
 		return nil, gas, vmerrs.ErrDepth
 	}
+	// Fail if the target or the called function is blacklisted
+	if evm.isBlacklisted(addr, input) {
+		return nil, gas, vmerrs.ErrBlacklisted
+	}
 	var snapshot = evm.StateDB.Snapshot()
 
 	// Invoke tracer hooks that signal entering/exiting a call frame
@@ -510,6 +524,10 @@ func (evm *EVM) StaticCall(caller ContractRef, addr common.Address, input []byte
 	if evm.depth > int(params.CallCreateDepth) {
 		return nil, gas, vmerrs.ErrDepth
 	}
+	// Fail if the target or the called function is blacklisted
+	if evm.isBlacklisted(addr, input) {
+		return nil, gas, vmerrs.ErrBlacklisted
+	}
 	// We take a snapshot here. This is a bit counter-intuitive, and could probably be skipped.
 	// However, even a staticcall is considered a 'touch'. On mainnet, static calls were introduced
 	// after all empty accounts were deleted, so this is not required. However, if we omit this,
@@ -557,6 +575,47 @@ func (evm *EVM) StaticCall(caller ContractRef, addr common.Address, input []byte
 	return ret, gas, err
 }
 
+// adminHeader returns a header carrying the block information the
+// AdminController needs to evaluate its restrictions
+func (evm *EVM) adminHeader() *types.Header {
+	return &types.Header{
+		Number: evm.Context.BlockNumber,
+		Time:   evm.Context.Time.Uint64(),
+	}
+}
+
+// isBlacklisted returns true if the AdminController blacklists [addr]
+// either entirely or for the function selector in [input]
+func (evm *EVM) isBlacklisted(addr common.Address, input []byte) bool {
+	if evm.Context.AdminController == nil {
+		return false
+	}
+	var signature [4]byte
+	if len(input) >= len(signature) {
+		copy(signature[:], input)
+	}
+	return evm.Context.AdminController.IsBlacklisted(evm.adminHeader(), evm.StateDB, addr, signature)
+}
+
+// kycVerified returns false if the KYC policy requires a verified sender
+// for a call to [to] (nil for contract creation) transferring [value] and
+// the root caller is not KYC verified
+func (evm *EVM) kycVerified(caller ContractRef, to *common.Address, value *big.Int) bool {
+	if evm.Context.AdminController == nil || !evm.chainRules.KycPolicy.Required(to, value) {
+		return true
+	}
+	// Get root caller
+	rootCaller := caller
+	for {
+		if contract, isContract := rootCaller.(*Contract); isContract {
+			rootCaller = contract.caller
+		} else {
+			break
+		}
+	}
+	return evm.Context.AdminController.KycVerified(evm.adminHeader(), evm.StateDB, rootCaller.Address())
+}
+
 type codeAndHash struct {
 	code []byte
 	hash common.Hash
@@ -584,7 +643,7 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 	}
 	// If there is any collision with a prohibited address, return an error instead
 	// of allowing the contract to be created.
-	if IsProhibited(address) {
+	if IsProhibited(address) || address == evm.chainConfig.CaminoSystemContracts(evm.Context.Time).BlackholeAddress {
 		return nil, common.Address{}, gas, vmerrs.ErrAddrProhibited
 	}
 	nonce := evm.StateDB.GetNonce(caller.Address())
@@ -603,24 +662,8 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 		return nil, common.Address{}, 0, vmerrs.ErrContractAddressCollision
 	}
 
-	// Get root caller
-	rootCaller := caller
-	for {
-		if contract, isContract := rootCaller.(*Contract); isContract {
-			rootCaller = contract.caller
-		} else {
-			break
-		}
-	}
-
 	// Check AdminController restrictions
-	if evm.Context.AdminController != nil &&
-		!evm.Context.AdminController.KycVerified(
-			&types.Header{
-				Number: evm.Context.BlockNumber,
-				Time:   evm.Context.Time.Uint64(),
-			},
-			evm.StateDB, rootCaller.Address()) {
+	if !evm.kycVerified(caller, nil, value) {
 		return nil, common.Address{}, gas, vmerrs.ErrNotKycVerified
 	}
 
@@ -101,6 +101,7 @@ type Ethereum struct {
 	closeBloomHandler chan struct{}
 
 	APIBackend *EthAPIBackend
+	adminCtrl  *ethadmin.AdminController
 
 	miner     *miner.Miner
 	etherbase common.Address
@@ -226,6 +227,9 @@ func New(
 			Preimages:                       config.Preimages,
 			AcceptedCacheSize:               config.AcceptedCacheSize,
 			TxLookupLimit:                   config.TxLookupLimit,
+			StateHistoryWindow:              config.StateHistoryWindow,
+			OnlinePruningBloomSize:          config.OnlinePruningBloomFilterSize,
+			OnlinePruningRate:               config.OnlinePruningRate,
 		}
 	)
 
@@ -239,7 +243,8 @@ func New(
 		eth:                 eth,
 	}
 
-	vmConfig.AdminContoller = ethadmin.NewController(eth.APIBackend, chainConfig)
+	eth.adminCtrl = ethadmin.NewController(eth.APIBackend, chainConfig)
+	vmConfig.AdminContoller = eth.adminCtrl
 
 	var err error
 	eth.blockchain, err = core.NewBlockChain(chainDb, cacheConfig, chainConfig, eth.engine, vmConfig, lastAcceptedHash)
@@ -252,7 +257,7 @@ func New(
 	}
 
 	eth.bloomIndexer.Start(eth.blockchain)
-	vmConfig.AdminContoller.Start()
+	eth.adminCtrl.Start()
 
 	config.TxPool.Journal = ""
 	eth.txPool = core.NewTxPool(config.TxPool, chainConfig, eth.blockchain)
@@ -369,6 +374,8 @@ func (s *Ethereum) EventMux() *event.TypeMux          { return s.eventMux }
 func (s *Ethereum) Engine() consensus.Engine          { return s.engine }
 func (s *Ethereum) ChainDb() ethdb.Database           { return s.chainDb }
 
+func (s *Ethereum) AdminController() *ethadmin.AdminController { return s.adminCtrl }
+
 func (s *Ethereum) NetVersion() uint64               { return s.networkID }
 func (s *Ethereum) ArchiveMode() bool                { return !s.config.Pruning }
 func (s *Ethereum) BloomIndexer() *core.ChainIndexer { return s.bloomIndexer }
@@ -476,6 +483,9 @@ func (s *Ethereum) handleOfflinePruning(cacheConfig *core.CacheConfig, chainConf
 	}
 	// Note: Time Marker is written inside of [Prune] before compaction begins
 	// (considered an optional optimization)
+
+	// The interrupted online pruning, if any, is superseded
+	rawdb.DeleteOnlinePruning(s.chainDb)
 	s.blockchain, err = core.NewBlockChain(s.chainDb, cacheConfig, chainConfig, s.engine, vmConfig, lastAcceptedHash)
 	if err != nil {
 		return fmt.Errorf("failed to re-initialize blockchain after offline pruning: %w", err)
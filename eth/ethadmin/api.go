@@ -0,0 +1,247 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ethadmin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/rpc"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+// maxBaseFeeHistory is the maximum number of blocks which can be requested
+// by a single camino_getBaseFeeHistory call
+const maxBaseFeeHistory = 1024
+
+var (
+	errInvalidSignature = errors.New("signature must be empty or 4 bytes long")
+	errInvalidCount     = errors.New("block count must be positive")
+)
+
+// APIBackend provides the chain access required by the camino API
+type APIBackend interface {
+	StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error)
+	HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error)
+}
+
+// API offers the admin contract state decoded, served under the camino
+// namespace
+type API struct {
+	b    APIBackend
+	ctrl *AdminController
+}
+
+// NewAPI creates a new camino API
+func NewAPI(b APIBackend, ctrl *AdminController) *API {
+	return &API{b: b, ctrl: ctrl}
+}
+
+// GetBaseFee returns the fixed base fee configured in the admin contract in
+// the state of the given block, including a scheduled change activated at
+// the timestamp of the block. The rpc.LatestBlockNumber, rpc.PendingBlockNumber,
+// and rpc.AcceptedBlockNumber meta block numbers are also allowed.
+func (api *API) GetBaseFee(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	state, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	baseFee, err := api.ctrl.GetFixedBaseFee(header, state, header.Time)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(baseFee), state.Error()
+}
+
+// PendingBaseFee is the result of camino_getPendingBaseFee
+type PendingBaseFee struct {
+	BaseFee   *hexutil.Big   `json:"baseFee"`
+	Timestamp hexutil.Uint64 `json:"timestamp"`
+}
+
+// GetPendingBaseFee returns the fixed base fee scheduled in the admin
+// contract in the state of the given block and its activation timestamp.
+// It returns null if no change is scheduled after the given block. The
+// rpc.LatestBlockNumber, rpc.PendingBlockNumber, and rpc.AcceptedBlockNumber
+// meta block numbers are also allowed.
+func (api *API) GetPendingBaseFee(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*PendingBaseFee, error) {
+	state, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	baseFee, timestamp := api.ctrl.GetPendingBaseFee(header, state)
+	if baseFee == nil {
+		return nil, state.Error()
+	}
+	return &PendingBaseFee{
+		BaseFee:   (*hexutil.Big)(baseFee),
+		Timestamp: hexutil.Uint64(timestamp),
+	}, state.Error()
+}
+
+// BaseFeeBounds is the result of camino_getBaseFeeBounds
+type BaseFeeBounds struct {
+	Floor   *hexutil.Big `json:"floor"`
+	Ceiling *hexutil.Big `json:"ceiling"`
+}
+
+// GetBaseFeeBounds returns the bounds of the dynamic base fee configured in
+// the admin contract in the state of the given block. The ceiling is null if
+// the base fee is unbounded. The rpc.LatestBlockNumber, rpc.PendingBlockNumber,
+// and rpc.AcceptedBlockNumber meta block numbers are also allowed.
+func (api *API) GetBaseFeeBounds(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*BaseFeeBounds, error) {
+	state, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	floor, ceiling, err := api.ctrl.GetBaseFeeBounds(header, state)
+	if err != nil {
+		return nil, err
+	}
+	return &BaseFeeBounds{
+		Floor:   (*hexutil.Big)(floor),
+		Ceiling: (*hexutil.Big)(ceiling),
+	}, state.Error()
+}
+
+// BaseFeeHistory is the result of camino_getBaseFeeHistory
+type BaseFeeHistory struct {
+	OldestBlock *hexutil.Big   `json:"oldestBlock"`
+	BaseFee     []*hexutil.Big `json:"baseFeePerGas"`
+	// Pending is the change scheduled after the last block, if any
+	Pending *PendingBaseFee `json:"pending,omitempty"`
+}
+
+// GetBaseFeeHistory returns the fixed base fee configured in the admin
+// contract for the [blockCount] blocks up to and including [lastBlock] and
+// the change scheduled after [lastBlock].
+// The range is truncated at genesis and at [maxBaseFeeHistory] blocks. It
+// fails if the state of a block outside of the event index is not available.
+func (api *API) GetBaseFeeHistory(ctx context.Context, blockCount math.HexOrDecimal64, lastBlock rpc.BlockNumber) (*BaseFeeHistory, error) {
+	if blockCount == 0 {
+		return nil, errInvalidCount
+	}
+	if blockCount > maxBaseFeeHistory {
+		blockCount = maxBaseFeeHistory
+	}
+	head, err := api.b.HeaderByNumber(ctx, lastBlock)
+	if err != nil {
+		return nil, err
+	}
+	if head == nil {
+		return nil, fmt.Errorf("block %d not found", lastBlock)
+	}
+	last := head.Number.Uint64()
+	if uint64(blockCount) > last+1 {
+		blockCount = math.HexOrDecimal64(last + 1)
+	}
+	oldest := last + 1 - uint64(blockCount)
+
+	history := &BaseFeeHistory{
+		OldestBlock: (*hexutil.Big)(new(big.Int).SetUint64(oldest)),
+		BaseFee:     make([]*hexutil.Big, 0, blockCount),
+	}
+	for number := oldest; number < last; number++ {
+		header, err := api.b.HeaderByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return nil, err
+		}
+		if header == nil {
+			return nil, fmt.Errorf("block %d not found", number)
+		}
+		fees, err := api.ctrl.historicBaseFees(header)
+		if err != nil {
+			return nil, err
+		}
+		history.BaseFee = append(history.BaseFee, (*hexutil.Big)(fees.effectiveBaseFee(header.Time)))
+	}
+	fees, err := api.ctrl.historicBaseFees(head)
+	if err != nil {
+		return nil, err
+	}
+	history.BaseFee = append(history.BaseFee, (*hexutil.Big)(fees.effectiveBaseFee(head.Time)))
+	if baseFee, timestamp := fees.pendingBaseFee(head.Time); baseFee != nil {
+		history.Pending = &PendingBaseFee{
+			BaseFee:   (*hexutil.Big)(baseFee),
+			Timestamp: hexutil.Uint64(timestamp),
+		}
+	}
+	return history, nil
+}
+
+// KycState is the result of camino_getKycState
+type KycState struct {
+	State    hexutil.Uint64 `json:"state"`
+	Verified bool           `json:"verified"`
+	Expired  bool           `json:"expired"`
+}
+
+// GetKycState returns the KYC state of [address] in the state of the given
+// block, served from the index for the last accepted block. The
+// rpc.LatestBlockNumber, rpc.PendingBlockNumber, and rpc.AcceptedBlockNumber
+// meta block numbers are also allowed.
+func (api *API) GetKycState(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*KycState, error) {
+	state, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	kycState := api.ctrl.KycState(header, state, address)
+	return &KycState{
+		State:    hexutil.Uint64(kycState),
+		Verified: (kycState & KYC_VERIFIED) != 0,
+		Expired:  (kycState & KYC_EXPIRED) != 0,
+	}, state.Error()
+}
+
+// GetRoles returns the role bitmask of [address] in the state of the given
+// block. The rpc.LatestBlockNumber, rpc.PendingBlockNumber, and
+// rpc.AcceptedBlockNumber meta block numbers are also allowed.
+func (api *API) GetRoles(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	state, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(getRoles(api.ctrl.systemContracts(header), state, address)), state.Error()
+}
+
+// BlacklistState is the result of camino_getBlacklistState
+type BlacklistState struct {
+	// State is the entry stored for exactly (address, signature)
+	State hexutil.Uint64 `json:"state"`
+	// Blacklisted is true if calls are rejected in the given block, either
+	// by this entry or by the entry of the address without signature
+	Blacklisted bool `json:"blacklisted"`
+}
+
+// GetBlacklistState returns the blacklist entry of [address] and the 4 byte
+// function [signature] in the state of the given block. An empty signature
+// queries the entry covering all functions. The rpc.LatestBlockNumber,
+// rpc.PendingBlockNumber, and rpc.AcceptedBlockNumber meta block numbers are
+// also allowed.
+func (api *API) GetBlacklistState(ctx context.Context, address common.Address, signature hexutil.Bytes, blockNrOrHash rpc.BlockNumberOrHash) (*BlacklistState, error) {
+	var sig [4]byte
+	switch len(signature) {
+	case 0:
+	case len(sig):
+		copy(sig[:], signature)
+	default:
+		return nil, errInvalidSignature
+	}
+
+	state, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	return &BlacklistState{
+		State:       hexutil.Uint64(getBlacklistState(api.ctrl.systemContracts(header), state, address, sig)),
+		Blacklisted: api.ctrl.IsBlacklisted(header, state, address, sig),
+	}, state.Error()
+}
@@ -3,9 +3,10 @@
 package ethadmin
 
 import (
-	"bytes"
 	"context"
+	"fmt"
 	"math/big"
+	"sort"
 	"sync"
 
 	"github.com/ava-labs/coreth/core"
@@ -13,43 +14,106 @@ import (
 	"github.com/ava-labs/coreth/core/state"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/rpc"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 )
 
-var (
-	contractAddr = common.Address{
-		1, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-		0, 0, 0, 0, 0, 0, 0, 0, 0, 10,
-	}
-	baseFeeSlot = common.Hash{
-		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1,
-	}
-)
-
 const (
 	KYC_VERIFIED = 1
 	KYC_EXPIRED  = 2
+
+	BLACKLISTED = 1
+)
+
+var (
+	gasFeeSetTopic        = crypto.Keccak256Hash([]byte("GasFeeSet(uint256)"))
+	baseFeeBoundsSetTopic = crypto.Keccak256Hash([]byte("BaseFeeBoundsSet(uint256,uint256)"))
+	baseFeeScheduledTopic = crypto.Keccak256Hash([]byte("BaseFeeScheduled(uint256,uint256)"))
+	kycStateChangedTopic  = crypto.Keccak256Hash([]byte("KycStateChanged(address,uint256,uint256)"))
 )
 
 type AdminControllerBackend interface {
 	StateByHeader(ctx context.Context, header *types.Header) (*state.StateDB, error)
-	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+	HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error)
+	LastAcceptedBlock() *types.Block
+	SubscribeChainAcceptedEvent(ch chan<- core.ChainEvent) event.Subscription
+}
+
+// baseFeeChange records the base fee settings as of the block at height.
+// Unset values are zero.
+type baseFeeChange struct {
+	height      uint64
+	baseFee     *big.Int // Fixed base fee
+	floor       *big.Int // Minimum dynamic base fee
+	ceiling     *big.Int // Maximum dynamic base fee
+	pending     *big.Int // Scheduled fixed base fee
+	pendingTime uint64   // Activation timestamp of the scheduled fixed base fee
+}
+
+// fixedBaseFee returns the fixed base fee applying at [timestamp], which is
+// the scheduled fixed base fee once it is activated
+func (c *baseFeeChange) fixedBaseFee(timestamp uint64) *big.Int {
+	if c.pendingTime != 0 && timestamp >= c.pendingTime {
+		return c.pending
+	}
+	return c.baseFee
+}
+
+// effectiveBaseFee returns the fixed base fee applying at [timestamp] or the
+// default base fee if none is set
+func (c *baseFeeChange) effectiveBaseFee(timestamp uint64) *big.Int {
+	baseFee := c.fixedBaseFee(timestamp)
+	if baseFee.Sign() == 0 {
+		return new(big.Int).SetUint64(params.SunrisePhase0BaseFee)
+	}
+	return new(big.Int).Set(baseFee)
+}
+
+// bounds returns the floor and ceiling of the dynamic base fee at
+// [timestamp]. The fixed base fee is the floor if no floor is set, the base
+// fee is unbounded (nil ceiling) if no ceiling is set.
+func (c *baseFeeChange) bounds(timestamp uint64) (*big.Int, *big.Int) {
+	floor := c.floor
+	if floor.Sign() == 0 {
+		floor = c.effectiveBaseFee(timestamp)
+	}
+	if c.ceiling.Sign() == 0 {
+		return new(big.Int).Set(floor), nil
+	}
+	// The floor takes precedence over a lower ceiling
+	return new(big.Int).Set(floor), new(big.Int).Set(math.BigMax(floor, c.ceiling))
+}
+
+// pendingBaseFee returns the scheduled fixed base fee which is not yet
+// activated at [timestamp] and its activation timestamp, nil if no change is
+// scheduled
+func (c *baseFeeChange) pendingBaseFee(timestamp uint64) (*big.Int, uint64) {
+	if c.pendingTime == 0 || timestamp >= c.pendingTime {
+		return nil, 0
+	}
+	if c.pending.Sign() == 0 {
+		return new(big.Int).SetUint64(params.SunrisePhase0BaseFee), c.pendingTime
+	}
+	return new(big.Int).Set(c.pending), c.pendingTime
 }
 
 type AdminController struct {
-	ctx              context.Context
-	backend          AdminControllerBackend
-	cfg              *params.ChainConfig
-	lastHead         common.Hash // Last block hash used for validating sequence
-	scanHeight       big.Int     // Last scanned block height
-	lastChangeHeight big.Int     // Last known block where changes were made
+	ctx     context.Context
+	backend AdminControllerBackend
+	cfg     *params.ChainConfig
 
-	baseFee *big.Int // BaseFee valid from lastChangeHeight to
+	// The index below covers the accepted blocks from startHeight to
+	// lastAccepted. It is empty as long as lastAccepted is nil. It only
+	// serves the APIs, consensus always reads the state.
+	lastAccepted *types.Header             // Last indexed accepted block
+	startHeight  uint64                    // First indexed accepted block
+	baseFees     []baseFeeChange           // Base fee history, ordered by height
+	kycStates    map[common.Address]uint64 // KYC states as of lastAccepted
 
 	lock sync.RWMutex
 }
@@ -57,98 +121,325 @@ type AdminController struct {
 // NewAdmin returns a new Admin instance used for fast admi state retrieval
 func NewController(backend AdminControllerBackend, config *params.ChainConfig) *AdminController {
 	admin := &AdminController{
-		ctx:     context.Background(),
-		backend: backend,
-		cfg:     config,
+		ctx:       context.Background(),
+		backend:   backend,
+		cfg:       config,
+		kycStates: make(map[common.Address]uint64),
 	}
 
 	return admin
 }
 
 func (a *AdminController) Start() {
-	headEvent := make(chan core.ChainHeadEvent, 1)
-	a.backend.SubscribeChainHeadEvent(headEvent)
+	acceptedEvent := make(chan core.ChainEvent, 1)
+	a.backend.SubscribeChainAcceptedEvent(acceptedEvent)
+
+	a.lock.Lock()
+	a.reset(a.backend.LastAcceptedBlock().Header())
+	a.lock.Unlock()
+
 	go func() {
-		for ev := range headEvent {
+		for ev := range acceptedEvent {
 			a.consume(&ev)
 		}
 	}()
 }
 
-func (a *AdminController) consume(ev *core.ChainHeadEvent) {
+func (a *AdminController) consume(ev *core.ChainEvent) {
 	// Acquire write lock
 	a.lock.Lock()
 	defer a.lock.Unlock()
 
-	changed := true
-	if a.lastHead == ev.Block.ParentHash() {
-		// We are in order, scan for admin tx
-		changed = a.process(ev)
+	header := ev.Block.Header()
+	switch {
+	case a.lastAccepted != nil && header.Number.Cmp(a.lastAccepted.Number) <= 0:
+		// Already covered by the index
+	case a.lastAccepted != nil && header.ParentHash == a.lastAccepted.Hash() &&
+		*a.systemContracts(header) == *a.systemContracts(a.lastAccepted):
+		// We are in order, index the admin events
+		a.process(header, ev.Logs)
+	default:
+		// We missed blocks or the system contracts were relocated, restart
+		// indexing at this block
+		a.reset(header)
 	}
-	a.lastHead = ev.Block.Hash()
-	blockHeight := ev.Block.Number()
+}
+
+// systemContracts returns the system contracts active at [header]
+func (a *AdminController) systemContracts(header *types.Header) *params.SystemContracts {
+	return a.cfg.CaminoSystemContracts(new(big.Int).SetUint64(header.Time))
+}
+
+// reset drops the index and restarts it at [header] using its state.
+// The caller must hold the write lock.
+func (a *AdminController) reset(header *types.Header) {
+	a.lastAccepted = nil
+	a.baseFees = nil
+	a.kycStates = make(map[common.Address]uint64)
 
-	// If a relevant admin TX found, reset cache
-	if changed {
-		a.lastChangeHeight = *blockHeight
-		a.baseFee = nil
+	state, err := a.backend.StateByHeader(a.ctx, header)
+	if err != nil {
+		log.Debug("cannot get stateDB -> admin index disabled until next block", "err", err)
+		return
 	}
-	a.scanHeight = *blockHeight
+	a.lastAccepted = header
+	a.startHeight = header.Number.Uint64()
+	fees := a.getBaseFeeSettings(header, state)
+	fees.height = a.startHeight
+	a.baseFees = []baseFeeChange{fees}
 }
 
-func (a *AdminController) process(ev *core.ChainHeadEvent) bool {
-	changed := false
-	for _, tx := range ev.Block.Transactions() {
-		if tx.To() != nil && bytes.Equal(tx.To().Bytes(), contractAddr[:]) {
-			changed = true
+// process indexes the admin events in [logs] emitted by the accepted block
+// [header]. The caller must hold the write lock.
+func (a *AdminController) process(header *types.Header, logs []*types.Log) {
+	height := header.Number.Uint64()
+	contractAddr := a.systemContracts(header).AdminAddress
+	for _, l := range logs {
+		if l.Address != contractAddr || len(l.Topics) == 0 {
+			continue
 		}
+		switch l.Topics[0] {
+		case gasFeeSetTopic:
+			if len(l.Data) != common.HashLength {
+				continue
+			}
+			a.updateBaseFees(height, func(change *baseFeeChange) {
+				// Setting the fixed base fee drops a scheduled change
+				change.baseFee = new(big.Int).SetBytes(l.Data)
+				change.pending = new(big.Int)
+				change.pendingTime = 0
+			})
+		case baseFeeScheduledTopic:
+			if len(l.Data) != 2*common.HashLength || !a.cfg.IsSunrisePhase2(new(big.Int).SetUint64(header.Time)) {
+				continue
+			}
+			a.updateBaseFees(height, func(change *baseFeeChange) {
+				// An activated change becomes the fixed base fee before it
+				// is replaced by the new one
+				change.baseFee = change.fixedBaseFee(header.Time)
+				change.pending = new(big.Int).SetBytes(l.Data[:common.HashLength])
+				change.pendingTime = new(big.Int).SetBytes(l.Data[common.HashLength:]).Uint64()
+			})
+		case baseFeeBoundsSetTopic:
+			if len(l.Data) != 2*common.HashLength {
+				continue
+			}
+			a.updateBaseFees(height, func(change *baseFeeChange) {
+				change.floor = new(big.Int).SetBytes(l.Data[:common.HashLength])
+				change.ceiling = new(big.Int).SetBytes(l.Data[common.HashLength:])
+			})
+		case kycStateChangedTopic:
+			if len(l.Topics) != 2 || len(l.Data) != 2*common.HashLength {
+				continue
+			}
+			account := common.BytesToAddress(l.Topics[1].Bytes())
+			a.kycStates[account] = new(big.Int).SetBytes(l.Data[common.HashLength:]).Uint64()
+		}
+	}
+	a.lastAccepted = header
+}
+
+// updateBaseFees applies [update] to the latest indexed base fee settings and
+// records them as set by the block at [height]. The caller must hold the
+// write lock.
+func (a *AdminController) updateBaseFees(height uint64, update func(*baseFeeChange)) {
+	last := len(a.baseFees) - 1
+	change := a.baseFees[last]
+	change.height = height
+	update(&change)
+	if a.baseFees[last].height == height {
+		// Only the last change within a block is relevant
+		a.baseFees[last] = change
+	} else {
+		a.baseFees = append(a.baseFees, change)
 	}
-	return changed
 }
 
-func (a *AdminController) GetFixedBaseFee(head *types.Header, state admin.StateDB) *big.Int {
+// indexedBaseFees returns the base fee settings at [head] if [head] is an
+// accepted block covered by the index, nil otherwise.
+func (a *AdminController) indexedBaseFees(head *types.Header) *baseFeeChange {
 	a.lock.RLock()
-	if a.baseFee != nil && a.inScanRange(head) {
+	if a.lastAccepted == nil || head.Number == nil ||
+		head.Number.Cmp(a.lastAccepted.Number) > 0 || head.Number.Uint64() < a.startHeight {
 		a.lock.RUnlock()
-		return a.baseFee
+		return nil
 	}
+	height := head.Number.Uint64()
+	i := sort.Search(len(a.baseFees), func(i int) bool {
+		return a.baseFees[i].height > height
+	})
+	fees := a.baseFees[i-1]
 	a.lock.RUnlock()
 
+	// Rejected blocks are never indexed, so ensure [head] is accepted
+	if canonical, err := a.backend.HeaderByNumber(a.ctx, rpc.BlockNumber(height)); err != nil ||
+		canonical == nil || canonical.Hash() != head.Hash() {
+		return nil
+	}
+	return &fees
+}
+
+// historicBaseFees returns the base fee settings at [head] from the index or,
+// if [head] is not indexed, from the state of [head].
+func (a *AdminController) historicBaseFees(head *types.Header) (*baseFeeChange, error) {
+	if fees := a.indexedBaseFees(head); fees != nil {
+		return fees, nil
+	}
+	return a.baseFeeSettings(head, nil)
+}
+
+// baseFeeSettings returns the base fee settings in [state] or, if [state] is
+// nil, in the state of [head].
+func (a *AdminController) baseFeeSettings(head *types.Header, state admin.StateDB) (*baseFeeChange, error) {
 	if state == nil {
 		var err error
 		if state, err = a.backend.StateByHeader(a.ctx, head); err != nil {
-			log.Debug("cannot get stateDB -> using default: %s", err)
-			return new(big.Int).SetUint64(params.SunrisePhase0BaseFee)
+			return nil, fmt.Errorf("cannot read the base fee settings at block %d: %w", head.Number, err)
 		}
 	}
+	fees := a.getBaseFeeSettings(head, state)
+	return &fees, nil
+}
+
+// GetFixedBaseFee returns the fixed base fee of the blocks following [head]
+// at [timestamp]. It fails if the state of [head] is not available.
+func (a *AdminController) GetFixedBaseFee(head *types.Header, state admin.StateDB, timestamp uint64) (*big.Int, error) {
+	fees, err := a.baseFeeSettings(head, state)
+	if err != nil {
+		return nil, err
+	}
+	return fees.effectiveBaseFee(timestamp), nil
+}
 
-	baseFee := new(big.Int).SetBytes(state.GetState(contractAddr, baseFeeSlot).Bytes())
+// GetPendingBaseFee returns the fixed base fee scheduled at [head] which is
+// not yet activated at the timestamp of [head] and its activation timestamp.
+// It returns nil if no change is scheduled.
+func (a *AdminController) GetPendingBaseFee(head *types.Header, state admin.StateDB) (*big.Int, uint64) {
+	fees, err := a.baseFeeSettings(head, state)
+	if err != nil {
+		log.Debug("cannot get stateDB -> no pending base fee", "err", err)
+		return nil, 0
+	}
+	return fees.pendingBaseFee(head.Time)
+}
 
-	if baseFee.Cmp(common.Big0) == 0 {
-		baseFee.SetUint64(params.SunrisePhase0BaseFee)
+// GetBaseFeeBounds returns the floor and ceiling of the dynamic base fee.
+// The fixed base fee is the floor if no floor is set, the base fee is
+// unbounded (nil ceiling) if no ceiling is set. It fails if the state of
+// [head] is not available.
+func (a *AdminController) GetBaseFeeBounds(head *types.Header, state admin.StateDB) (*big.Int, *big.Int, error) {
+	fees, err := a.baseFeeSettings(head, state)
+	if err != nil {
+		return nil, nil, err
 	}
+	floor, ceiling := fees.bounds(head.Time)
+	return floor, ceiling, nil
+}
 
-	a.lock.Lock()
-	defer a.lock.Unlock()
-	if a.baseFee == nil && a.inScanRange(head) {
-		a.baseFee = baseFee
+// KycState returns the KYC state of [addr] in [state], the state of [head].
+// The state of the last accepted block is served from the index.
+func (a *AdminController) KycState(head *types.Header, state admin.StateDB, addr common.Address) uint64 {
+	a.lock.RLock()
+	kycState, ok := a.kycStates[addr]
+	indexed := a.lastAccepted != nil && a.lastAccepted.Hash() == head.Hash()
+	a.lock.RUnlock()
+	if indexed && ok {
+		return kycState
 	}
 
-	return baseFee
+	kycState = getKycState(a.systemContracts(head), state, addr)
+	if indexed {
+		a.lock.Lock()
+		defer a.lock.Unlock()
+		// Cache the state only if no block was indexed in the meantime
+		if a.lastAccepted != nil && a.lastAccepted.Hash() == head.Hash() {
+			if _, ok := a.kycStates[addr]; !ok {
+				a.kycStates[addr] = kycState
+			}
+		}
+	}
+	return kycState
 }
 
 func (a *AdminController) KycVerified(head *types.Header, state admin.StateDB, addr common.Address) bool {
-	if a.cfg.IsSunrisePhase0(big.NewInt(int64(head.Time))) {
-		// Calculate storage position
-		storagePos := crypto.Keccak256Hash(append(addr.Hash().Bytes(), common.HexToHash("0x2").Bytes()...))
-		// Get the KYC states
-		kycStates := new(big.Int).SetBytes(state.GetState(contractAddr, storagePos).Bytes()).Uint64()
+	timestamp := new(big.Int).SetUint64(head.Time)
+	if a.cfg.IsSunrisePhase0(timestamp) {
+		kycStates := getKycState(a.cfg.CaminoSystemContracts(timestamp), state, addr)
+		// Expired KYC states are only rejected by scheduled KYC policies
+		if policy := a.cfg.KycPolicy(timestamp); !policy.AcceptsExpired() && (kycStates&KYC_EXPIRED) != 0 {
+			return false
+		}
 		// Return true if KYC flag is set
 		return (kycStates & KYC_VERIFIED) != 0
 	}
 	return true
 }
 
-func (a *AdminController) inScanRange(head *types.Header) bool {
-	return head.Number.Cmp(&a.lastChangeHeight) >= 0 && head.Number.Cmp(&a.scanHeight) <= 0
+// IsBlacklisted returns true if calls of [signature] into [addr] are
+// blacklisted at [head]. Blacklists are enforced as of Sunrise Phase 2 and
+// never apply to the admin contract, so that they can always be lifted.
+func (a *AdminController) IsBlacklisted(head *types.Header, state admin.StateDB, addr common.Address, signature [4]byte) bool {
+	timestamp := new(big.Int).SetUint64(head.Time)
+	if !a.cfg.IsSunrisePhase2(timestamp) {
+		return false
+	}
+	contracts := a.cfg.CaminoSystemContracts(timestamp)
+	if addr == contracts.AdminAddress {
+		return false
+	}
+	// A zero signature blacklists every call into addr
+	if signature != ([4]byte{}) && blacklisted(contracts, state, addr, [4]byte{}) {
+		return true
+	}
+	return blacklisted(contracts, state, addr, signature)
+}
+
+// getBaseFeeSettings reads the fixed base fee, the dynamic base fee bounds
+// and, as of Sunrise Phase 2, the scheduled fixed base fee in the state of
+// [head]
+func (a *AdminController) getBaseFeeSettings(head *types.Header, state admin.StateDB) baseFeeChange {
+	contracts := a.systemContracts(head)
+	fees := baseFeeChange{
+		baseFee: state.GetState(contracts.AdminAddress, contracts.AdminBaseFeeSlot).Big(),
+		floor:   state.GetState(contracts.AdminAddress, contracts.AdminBaseFeeFloorSlot).Big(),
+		ceiling: state.GetState(contracts.AdminAddress, contracts.AdminBaseFeeCeilingSlot).Big(),
+		pending: new(big.Int),
+	}
+	if a.cfg.IsSunrisePhase2(new(big.Int).SetUint64(head.Time)) {
+		fees.pending = state.GetState(contracts.AdminAddress, contracts.AdminPendingBaseFeeSlot).Big()
+		fees.pendingTime = state.GetState(contracts.AdminAddress, contracts.AdminPendingBaseFeeTimeSlot).Big().Uint64()
+	}
+	return fees
+}
+
+// getKycState reads the KYC states of addr
+func getKycState(contracts *params.SystemContracts, state admin.StateDB, addr common.Address) uint64 {
+	// Calculate storage position
+	storagePos := crypto.Keccak256Hash(addr.Hash().Bytes(), contracts.AdminKycSlot.Bytes())
+	// Get the KYC states
+	return new(big.Int).SetBytes(state.GetState(contracts.AdminAddress, storagePos).Bytes()).Uint64()
+}
+
+// getRoles reads the role bitmask of addr stored by SimpleAccessControl
+func getRoles(contracts *params.SystemContracts, state admin.StateDB, addr common.Address) *big.Int {
+	// Calculate storage position
+	storagePos := crypto.Keccak256Hash(addr.Hash().Bytes(), contracts.AdminRolesSlot.Bytes())
+	return state.GetState(contracts.AdminAddress, storagePos).Big()
+}
+
+// getBlacklistState reads the blacklist states of (addr, signature) which are
+// stored with the key uint256(addr) | uint256(signature) << 160
+func getBlacklistState(contracts *params.SystemContracts, state admin.StateDB, addr common.Address, signature [4]byte) uint64 {
+	var key common.Hash
+	copy(key[common.HashLength-common.AddressLength-4:], signature[:])
+	copy(key[common.HashLength-common.AddressLength:], addr[:])
+	// Calculate storage position
+	storagePos := crypto.Keccak256Hash(key.Bytes(), contracts.AdminBlacklistSlot.Bytes())
+	// Get the blacklist states
+	return new(big.Int).SetBytes(state.GetState(contracts.AdminAddress, storagePos).Bytes()).Uint64()
+}
+
+// blacklisted returns true if (addr, signature) is blacklisted
+func blacklisted(contracts *params.SystemContracts, state admin.StateDB, addr common.Address, signature [4]byte) bool {
+	return (getBlacklistState(contracts, state, addr, signature) & BLACKLISTED) != 0
 }
@@ -0,0 +1,465 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ethadmin
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/stretchr/testify/require"
+)
+
+var contracts = params.TestSunrisePhase0Config.CaminoSystemContracts(common.Big0)
+
+type testBackend struct {
+	state     *state.StateDB
+	stateErr  error // Returned instead of the state if set
+	canonical map[uint64]*types.Header
+	accepted  event.Feed
+}
+
+func (b *testBackend) StateByHeader(context.Context, *types.Header) (*state.StateDB, error) {
+	if b.stateErr != nil {
+		return nil, b.stateErr
+	}
+	return b.state, nil
+}
+
+func (b *testBackend) HeaderByNumber(_ context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	if number < 0 {
+		return b.LastAcceptedBlock().Header(), nil
+	}
+	return b.canonical[uint64(number)], nil
+}
+
+func (b *testBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
+	number, _ := blockNrOrHash.Number()
+	header, err := b.HeaderByNumber(ctx, number)
+	return b.state, header, err
+}
+
+func (b *testBackend) LastAcceptedBlock() *types.Block {
+	var last *types.Header
+	for _, header := range b.canonical {
+		if last == nil || header.Number.Cmp(last.Number) > 0 {
+			last = header
+		}
+	}
+	return types.NewBlockWithHeader(last)
+}
+
+func (b *testBackend) SubscribeChainAcceptedEvent(ch chan<- core.ChainEvent) event.Subscription {
+	return b.accepted.Subscribe(ch)
+}
+
+// accept adds a canonical block on top of [parent], 10 seconds later, and
+// returns its event
+func (b *testBackend) accept(parent *types.Header, logs ...*types.Log) core.ChainEvent {
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		Time:       parent.Time + 10,
+	}
+	b.canonical[header.Number.Uint64()] = header
+	block := types.NewBlockWithHeader(header)
+	return core.ChainEvent{Block: block, Hash: block.Hash(), Logs: logs}
+}
+
+func gasFeeSetLog(baseFee uint64) *types.Log {
+	return &types.Log{
+		Address: contracts.AdminAddress,
+		Topics:  []common.Hash{gasFeeSetTopic},
+		Data:    common.BigToHash(new(big.Int).SetUint64(baseFee)).Bytes(),
+	}
+}
+
+func baseFeeBoundsSetLog(floor, ceiling uint64) *types.Log {
+	return &types.Log{
+		Address: contracts.AdminAddress,
+		Topics:  []common.Hash{baseFeeBoundsSetTopic},
+		Data: append(
+			common.BigToHash(new(big.Int).SetUint64(floor)).Bytes(),
+			common.BigToHash(new(big.Int).SetUint64(ceiling)).Bytes()...,
+		),
+	}
+}
+
+func baseFeeScheduledLog(baseFee, activationTime uint64) *types.Log {
+	return &types.Log{
+		Address: contracts.AdminAddress,
+		Topics:  []common.Hash{baseFeeScheduledTopic},
+		Data: append(
+			common.BigToHash(new(big.Int).SetUint64(baseFee)).Bytes(),
+			common.BigToHash(new(big.Int).SetUint64(activationTime)).Bytes()...,
+		),
+	}
+}
+
+func kycStateChangedLog(addr common.Address, oldState, newState uint64) *types.Log {
+	return &types.Log{
+		Address: contracts.AdminAddress,
+		Topics:  []common.Hash{kycStateChangedTopic, addr.Hash()},
+		Data: append(
+			common.BigToHash(new(big.Int).SetUint64(oldState)).Bytes(),
+			common.BigToHash(new(big.Int).SetUint64(newState)).Bytes()...,
+		),
+	}
+}
+
+func newTestController(t *testing.T) (*AdminController, *testBackend, *types.Header) {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	statedb.SetState(contracts.AdminAddress, contracts.AdminBaseFeeSlot, common.BigToHash(big.NewInt(100)))
+
+	genesis := &types.Header{Number: common.Big0}
+	backend := &testBackend{
+		state:     statedb,
+		canonical: map[uint64]*types.Header{0: genesis},
+	}
+	ctrl := NewController(backend, params.TestSunrisePhase2Config)
+	ctrl.lock.Lock()
+	ctrl.reset(genesis)
+	ctrl.lock.Unlock()
+	return ctrl, backend, genesis
+}
+
+// historicBaseFees returns the base fee settings served for the history of
+// [head]
+func historicBaseFees(t *testing.T, ctrl *AdminController, head *types.Header) *baseFeeChange {
+	fees, err := ctrl.historicBaseFees(head)
+	require.NoError(t, err)
+	return fees
+}
+
+// indexedBaseFee returns the fixed base fee at [timestamp] in the base fee
+// settings served for the history of [head]
+func indexedBaseFee(t *testing.T, ctrl *AdminController, head *types.Header, timestamp uint64) *big.Int {
+	return historicBaseFees(t, ctrl, head).effectiveBaseFee(timestamp)
+}
+
+func TestBaseFeeHistory(t *testing.T) {
+	ctrl, backend, genesis := newTestController(t)
+
+	ev1 := backend.accept(genesis, gasFeeSetLog(200))
+	ctrl.consume(&ev1)
+	ev2 := backend.accept(ev1.Block.Header())
+	ctrl.consume(&ev2)
+	ev3 := backend.accept(ev2.Block.Header(), gasFeeSetLog(300), gasFeeSetLog(400))
+	ctrl.consume(&ev3)
+
+	// The state is no longer consulted for the history of indexed blocks
+	backend.state.SetState(contracts.AdminAddress, contracts.AdminBaseFeeSlot, common.BigToHash(big.NewInt(1)))
+
+	require.Equal(t, big.NewInt(100), indexedBaseFee(t, ctrl, genesis, 0))
+	require.Equal(t, big.NewInt(200), indexedBaseFee(t, ctrl, ev1.Block.Header(), 0))
+	require.Equal(t, big.NewInt(200), indexedBaseFee(t, ctrl, ev2.Block.Header(), 0))
+	require.Equal(t, big.NewInt(400), indexedBaseFee(t, ctrl, ev3.Block.Header(), 0))
+
+	// Blocks which are not indexed are read from state
+	rejected := &types.Header{ParentHash: genesis.Hash(), Number: common.Big1, Extra: []byte{1}}
+	require.Equal(t, big.NewInt(1), indexedBaseFee(t, ctrl, rejected, 0))
+	processing := &types.Header{ParentHash: ev3.Block.Hash(), Number: big.NewInt(4)}
+	require.Equal(t, big.NewInt(1), indexedBaseFee(t, ctrl, processing, 0))
+
+	// Consensus always reads the state
+	baseFee, err := ctrl.GetFixedBaseFee(ev3.Block.Header(), nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1), baseFee)
+}
+
+func TestIndexReset(t *testing.T) {
+	ctrl, backend, genesis := newTestController(t)
+
+	ev1 := backend.accept(genesis, gasFeeSetLog(200))
+	ev2 := backend.accept(ev1.Block.Header())
+
+	// Missing ev1 restarts the index at ev2 from its state
+	ctrl.consume(&ev2)
+	require.Equal(t, big.NewInt(100), indexedBaseFee(t, ctrl, ev2.Block.Header(), 0))
+
+	backend.state.SetState(contracts.AdminAddress, contracts.AdminBaseFeeSlot, common.BigToHash(big.NewInt(1)))
+	require.Equal(t, big.NewInt(1), indexedBaseFee(t, ctrl, ev1.Block.Header(), 0))
+
+	// Already indexed blocks are ignored
+	ctrl.consume(&ev2)
+	require.Equal(t, big.NewInt(100), indexedBaseFee(t, ctrl, ev2.Block.Header(), 0))
+}
+
+func TestBaseFeeBounds(t *testing.T) {
+	ctrl, backend, genesis := newTestController(t)
+
+	// Without bounds the fixed base fee is the floor
+	floor, ceiling, err := ctrl.GetBaseFeeBounds(genesis, nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), floor)
+	require.Nil(t, ceiling)
+
+	ev1 := backend.accept(genesis, baseFeeBoundsSetLog(50, 500))
+	ctrl.consume(&ev1)
+	ev2 := backend.accept(ev1.Block.Header(), gasFeeSetLog(200))
+	ctrl.consume(&ev2)
+	ev3 := backend.accept(ev2.Block.Header(), baseFeeBoundsSetLog(0, 150))
+	ctrl.consume(&ev3)
+
+	floor, ceiling = historicBaseFees(t, ctrl, ev1.Block.Header()).bounds(0)
+	require.Equal(t, big.NewInt(50), floor)
+	require.Equal(t, big.NewInt(500), ceiling)
+
+	// Setting the fixed base fee keeps the bounds
+	floor, ceiling = historicBaseFees(t, ctrl, ev2.Block.Header()).bounds(0)
+	require.Equal(t, big.NewInt(50), floor)
+	require.Equal(t, big.NewInt(500), ceiling)
+	require.Equal(t, big.NewInt(200), indexedBaseFee(t, ctrl, ev2.Block.Header(), 0))
+
+	// The fixed base fee replaces the missing floor, even above the ceiling
+	floor, ceiling = historicBaseFees(t, ctrl, ev3.Block.Header()).bounds(0)
+	require.Equal(t, big.NewInt(200), floor)
+	require.Equal(t, big.NewInt(200), ceiling)
+
+	// Consensus reads the bounds from state
+	backend.state.SetState(contracts.AdminAddress, contracts.AdminBaseFeeFloorSlot, common.BigToHash(big.NewInt(10)))
+	backend.state.SetState(contracts.AdminAddress, contracts.AdminBaseFeeCeilingSlot, common.BigToHash(big.NewInt(20)))
+	processing := &types.Header{ParentHash: ev3.Block.Hash(), Number: big.NewInt(4)}
+	floor, ceiling, err = ctrl.GetBaseFeeBounds(processing, nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(10), floor)
+	require.Equal(t, big.NewInt(20), ceiling)
+}
+
+func TestScheduledBaseFee(t *testing.T) {
+	ctrl, backend, genesis := newTestController(t)
+	api := NewAPI(backend, ctrl)
+
+	ev1 := backend.accept(genesis, baseFeeScheduledLog(300, 25))
+	ctrl.consume(&ev1)
+	ev2 := backend.accept(ev1.Block.Header())
+	ctrl.consume(&ev2)
+
+	// The scheduled base fee applies from its activation on
+	require.Equal(t, big.NewInt(100), indexedBaseFee(t, ctrl, ev1.Block.Header(), 24))
+	require.Equal(t, big.NewInt(300), indexedBaseFee(t, ctrl, ev1.Block.Header(), 25))
+	for _, ev := range []core.ChainEvent{ev1, ev2} {
+		baseFee, timestamp := historicBaseFees(t, ctrl, ev.Block.Header()).pendingBaseFee(ev.Block.Time())
+		require.Equal(t, big.NewInt(300), baseFee)
+		require.Equal(t, uint64(25), timestamp)
+	}
+
+	ev3 := backend.accept(ev2.Block.Header())
+	ctrl.consume(&ev3)
+	baseFee, _ := historicBaseFees(t, ctrl, ev3.Block.Header()).pendingBaseFee(ev3.Block.Time())
+	require.Nil(t, baseFee)
+	require.Equal(t, big.NewInt(300), indexedBaseFee(t, ctrl, ev3.Block.Header(), ev3.Block.Time()))
+
+	// Scheduling again keeps the activated base fee
+	ev4 := backend.accept(ev3.Block.Header(), baseFeeScheduledLog(500, 60))
+	ctrl.consume(&ev4)
+	require.Equal(t, big.NewInt(300), indexedBaseFee(t, ctrl, ev4.Block.Header(), 59))
+	require.Equal(t, big.NewInt(500), indexedBaseFee(t, ctrl, ev4.Block.Header(), 60))
+
+	history, err := api.GetBaseFeeHistory(context.Background(), 1, 4)
+	require.NoError(t, err)
+	require.Equal(t, []*hexutil.Big{(*hexutil.Big)(big.NewInt(300))}, history.BaseFee)
+	require.Equal(t, &PendingBaseFee{BaseFee: (*hexutil.Big)(big.NewInt(500)), Timestamp: 60}, history.Pending)
+
+	// Setting the base fee drops the scheduled change
+	ev5 := backend.accept(ev4.Block.Header(), gasFeeSetLog(200))
+	ctrl.consume(&ev5)
+	require.Equal(t, big.NewInt(200), indexedBaseFee(t, ctrl, ev5.Block.Header(), 60))
+	history, err = api.GetBaseFeeHistory(context.Background(), 1, 5)
+	require.NoError(t, err)
+	require.Nil(t, history.Pending)
+
+	// Consensus reads the scheduled change from state
+	backend.state.SetState(contracts.AdminAddress, contracts.AdminPendingBaseFeeSlot, common.BigToHash(big.NewInt(2)))
+	backend.state.SetState(contracts.AdminAddress, contracts.AdminPendingBaseFeeTimeSlot, common.BigToHash(big.NewInt(100)))
+	processing := &types.Header{ParentHash: ev5.Block.Hash(), Number: big.NewInt(6), Time: 60}
+	fixedBaseFee, err := ctrl.GetFixedBaseFee(processing, nil, 99)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), fixedBaseFee)
+	fixedBaseFee, err = ctrl.GetFixedBaseFee(processing, nil, 100)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2), fixedBaseFee)
+	baseFee, timestamp := ctrl.GetPendingBaseFee(processing, nil)
+	require.Equal(t, big.NewInt(2), baseFee)
+	require.Equal(t, uint64(100), timestamp)
+	pending, err := api.GetPendingBaseFee(context.Background(), rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	require.NoError(t, err)
+	require.Equal(t, &PendingBaseFee{BaseFee: (*hexutil.Big)(big.NewInt(2)), Timestamp: 100}, pending)
+}
+
+func TestScheduledBaseFeeBeforeSunrisePhase2(t *testing.T) {
+	ctrl, backend, genesis := newTestController(t)
+	config := *params.TestSunrisePhase1Config
+	config.SunrisePhase2BlockTimestamp = big.NewInt(1_000)
+	ctrl.cfg = &config
+
+	// Neither the scheduled change of the index nor of the state applies
+	ev1 := backend.accept(genesis, baseFeeScheduledLog(300, 15))
+	ctrl.consume(&ev1)
+	require.Equal(t, big.NewInt(100), indexedBaseFee(t, ctrl, ev1.Block.Header(), 20))
+
+	backend.state.SetState(contracts.AdminAddress, contracts.AdminPendingBaseFeeSlot, common.BigToHash(big.NewInt(2)))
+	backend.state.SetState(contracts.AdminAddress, contracts.AdminPendingBaseFeeTimeSlot, common.BigToHash(big.NewInt(15)))
+	processing := &types.Header{ParentHash: ev1.Block.Hash(), Number: big.NewInt(2), Time: 10}
+	fixedBaseFee, err := ctrl.GetFixedBaseFee(processing, nil, 20)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), fixedBaseFee)
+	baseFee, _ := ctrl.GetPendingBaseFee(processing, nil)
+	require.Nil(t, baseFee)
+}
+
+func TestBaseFeeHistoryMissingState(t *testing.T) {
+	ctrl, backend, genesis := newTestController(t)
+	api := NewAPI(backend, ctrl)
+	ctx := context.Background()
+
+	// The block is accepted but not indexed and its state is pruned
+	backend.accept(genesis)
+	backend.stateErr = errors.New("missing trie node")
+
+	_, err := api.GetBaseFeeHistory(ctx, 2, 1)
+	require.ErrorIs(t, err, backend.stateErr)
+
+	// Consensus fails instead of assuming a base fee
+	processing := &types.Header{ParentHash: genesis.Hash(), Number: big.NewInt(3)}
+	_, err = ctrl.GetFixedBaseFee(processing, nil, 0)
+	require.ErrorIs(t, err, backend.stateErr)
+	_, _, err = ctrl.GetBaseFeeBounds(processing, nil)
+	require.ErrorIs(t, err, backend.stateErr)
+
+	history, err := api.GetBaseFeeHistory(ctx, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, []*hexutil.Big{(*hexutil.Big)(big.NewInt(100))}, history.BaseFee)
+}
+
+func TestKycStateIndex(t *testing.T) {
+	ctrl, backend, genesis := newTestController(t)
+	addr := common.Address{1}
+	kycSlot := crypto.Keccak256Hash(addr.Hash().Bytes(), contracts.AdminKycSlot.Bytes())
+
+	require.Zero(t, ctrl.KycState(genesis, backend.state, addr))
+
+	// The state of the last accepted block is served from the index
+	ev1 := backend.accept(genesis, kycStateChangedLog(addr, 0, KYC_VERIFIED))
+	ctrl.consume(&ev1)
+	require.Equal(t, uint64(KYC_VERIFIED), ctrl.KycState(ev1.Block.Header(), backend.state, addr))
+
+	ev2 := backend.accept(ev1.Block.Header(), kycStateChangedLog(addr, KYC_VERIFIED, KYC_VERIFIED|KYC_EXPIRED))
+	ctrl.consume(&ev2)
+	require.Equal(t, uint64(KYC_VERIFIED|KYC_EXPIRED), ctrl.KycState(ev2.Block.Header(), backend.state, addr))
+
+	// Other blocks and consensus read the state
+	require.Zero(t, ctrl.KycState(ev1.Block.Header(), backend.state, addr))
+	require.False(t, ctrl.KycVerified(ev2.Block.Header(), backend.state, addr))
+
+	// Missing a block restarts the index from the state
+	backend.state.SetState(contracts.AdminAddress, kycSlot, common.BigToHash(big.NewInt(KYC_VERIFIED)))
+	ev3 := backend.accept(ev2.Block.Header())
+	ev4 := backend.accept(ev3.Block.Header())
+	ctrl.consume(&ev4)
+	require.Equal(t, uint64(KYC_VERIFIED), ctrl.KycState(ev4.Block.Header(), backend.state, addr))
+
+	// The state read for the last accepted block is cached
+	backend.state.SetState(contracts.AdminAddress, kycSlot, common.Hash{})
+	require.Equal(t, uint64(KYC_VERIFIED), ctrl.KycState(ev4.Block.Header(), backend.state, addr))
+}
+
+func TestAPI(t *testing.T) {
+	ctrl, backend, genesis := newTestController(t)
+	api := NewAPI(backend, ctrl)
+	ctx := context.Background()
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	addr := common.Address{1}
+
+	ev1 := backend.accept(genesis, gasFeeSetLog(200))
+	ctrl.consume(&ev1)
+	ev2 := backend.accept(ev1.Block.Header(), gasFeeSetLog(300))
+	ctrl.consume(&ev2)
+
+	history, err := api.GetBaseFeeHistory(ctx, 5, rpc.LatestBlockNumber)
+	require.NoError(t, err)
+	require.Equal(t, (*hexutil.Big)(common.Big0), history.OldestBlock)
+	require.Equal(t, []*hexutil.Big{
+		(*hexutil.Big)(big.NewInt(100)),
+		(*hexutil.Big)(big.NewInt(200)),
+		(*hexutil.Big)(big.NewInt(300)),
+	}, history.BaseFee)
+
+	history, err = api.GetBaseFeeHistory(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, (*hexutil.Big)(common.Big1), history.OldestBlock)
+	require.Equal(t, []*hexutil.Big{(*hexutil.Big)(big.NewInt(200))}, history.BaseFee)
+
+	_, err = api.GetBaseFeeHistory(ctx, 0, rpc.LatestBlockNumber)
+	require.ErrorIs(t, err, errInvalidCount)
+
+	bounds, err := api.GetBaseFeeBounds(ctx, latest)
+	require.NoError(t, err)
+	require.Equal(t, &BaseFeeBounds{Floor: (*hexutil.Big)(big.NewInt(100))}, bounds)
+
+	backend.state.SetState(contracts.AdminAddress, crypto.Keccak256Hash(addr.Hash().Bytes(), contracts.AdminKycSlot.Bytes()),
+		common.BigToHash(big.NewInt(KYC_VERIFIED|KYC_EXPIRED)))
+	kycState, err := api.GetKycState(ctx, addr, latest)
+	require.NoError(t, err)
+	require.Equal(t, &KycState{State: KYC_VERIFIED | KYC_EXPIRED, Verified: true, Expired: true}, kycState)
+
+	// The last accepted block is served from the index
+	ev3 := backend.accept(ev2.Block.Header(), kycStateChangedLog(addr, KYC_VERIFIED|KYC_EXPIRED, KYC_VERIFIED))
+	ctrl.consume(&ev3)
+	kycState, err = api.GetKycState(ctx, addr, latest)
+	require.NoError(t, err)
+	require.Equal(t, &KycState{State: KYC_VERIFIED, Verified: true}, kycState)
+
+	backend.state.SetState(contracts.AdminAddress, crypto.Keccak256Hash(addr.Hash().Bytes(), contracts.AdminRolesSlot.Bytes()),
+		common.BigToHash(big.NewInt(5)))
+	roles, err := api.GetRoles(ctx, addr, latest)
+	require.NoError(t, err)
+	require.Equal(t, (*hexutil.Big)(big.NewInt(5)), roles)
+
+	sig := hexutil.Bytes{1, 2, 3, 4}
+	blacklistState, err := api.GetBlacklistState(ctx, addr, sig, latest)
+	require.NoError(t, err)
+	require.Equal(t, &BlacklistState{}, blacklistState)
+
+	// The entry without signature blacklists all functions
+	var key common.Hash
+	copy(key[common.HashLength-common.AddressLength:], addr[:])
+	backend.state.SetState(contracts.AdminAddress, crypto.Keccak256Hash(key.Bytes(), contracts.AdminBlacklistSlot.Bytes()),
+		common.BigToHash(big.NewInt(BLACKLISTED)))
+	blacklistState, err = api.GetBlacklistState(ctx, addr, sig, latest)
+	require.NoError(t, err)
+	require.Equal(t, &BlacklistState{Blacklisted: true}, blacklistState)
+	blacklistState, err = api.GetBlacklistState(ctx, addr, nil, latest)
+	require.NoError(t, err)
+	require.Equal(t, &BlacklistState{State: BLACKLISTED, Blacklisted: true}, blacklistState)
+
+	// Blacklists are enforced as of Sunrise Phase 2
+	sunrisePhase0API := NewAPI(backend, NewController(backend, params.TestSunrisePhase0Config))
+	blacklistState, err = sunrisePhase0API.GetBlacklistState(ctx, addr, nil, latest)
+	require.NoError(t, err)
+	require.Equal(t, &BlacklistState{State: BLACKLISTED}, blacklistState)
+
+	// The admin contract is never blacklisted
+	adminKey := contracts.AdminAddress.Hash()
+	backend.state.SetState(contracts.AdminAddress, crypto.Keccak256Hash(adminKey.Bytes(), contracts.AdminBlacklistSlot.Bytes()),
+		common.BigToHash(big.NewInt(BLACKLISTED)))
+	blacklistState, err = api.GetBlacklistState(ctx, contracts.AdminAddress, nil, latest)
+	require.NoError(t, err)
+	require.Equal(t, &BlacklistState{State: BLACKLISTED}, blacklistState)
+
+	_, err = api.GetBlacklistState(ctx, addr, hexutil.Bytes{1}, latest)
+	require.ErrorIs(t, err, errInvalidSignature)
+}
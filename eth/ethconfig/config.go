@@ -155,4 +155,16 @@ type Config struct {
 	//  * 0:   means no limit
 	//  * N:   means N block limit [HEAD-N+1, HEAD] and delete extra indexes
 	TxLookupLimit uint64
+
+	// StateHistoryWindow is the number of recent accepted blocks for which
+	// state diffs are kept with pruning enabled, to serve historical state
+	// which is not available as a trie anymore (0 = disabled).
+	StateHistoryWindow uint64
+
+	// OnlinePruningBloomFilterSize is the size (MB) of the bloom filter of the
+	// live state used by online pruning, which is started through the admin API.
+	OnlinePruningBloomFilterSize uint64
+	// OnlinePruningRate is the maximum number of state entries processed per
+	// second by online pruning (0 = unlimited).
+	OnlinePruningRate uint64
 }
@@ -317,7 +317,8 @@ func (oracle *Oracle) suggestDynamicFees(ctx context.Context) (*big.Int, *big.In
 	lastHead, lastPrice, lastBaseFee := oracle.lastHead, oracle.lastPrice, oracle.lastBaseFee
 	oracle.cacheLock.RUnlock()
 	if headHash == lastHead {
-		return new(big.Int).Set(lastPrice), new(big.Int).Set(lastBaseFee), nil
+		baseFee, err := oracle.cachedBaseFee(head, lastBaseFee)
+		return new(big.Int).Set(lastPrice), baseFee, err
 	}
 	oracle.fetchLock.Lock()
 	defer oracle.fetchLock.Unlock()
@@ -327,7 +328,8 @@ func (oracle *Oracle) suggestDynamicFees(ctx context.Context) (*big.Int, *big.In
 	lastHead, lastPrice, lastBaseFee = oracle.lastHead, oracle.lastPrice, oracle.lastBaseFee
 	oracle.cacheLock.RUnlock()
 	if headHash == lastHead {
-		return new(big.Int).Set(lastPrice), new(big.Int).Set(lastBaseFee), nil
+		baseFee, err := oracle.cachedBaseFee(head, lastBaseFee)
+		return new(big.Int).Set(lastPrice), baseFee, err
 	}
 	var (
 		latestBlockNumber     = head.Number.Uint64()
@@ -372,8 +374,11 @@ func (oracle *Oracle) suggestDynamicFees(ctx context.Context) (*big.Int, *big.In
 		price = tipResults[(len(tipResults)-1)*oracle.percentile/100]
 	}
 
-	if oracle.backend.ChainConfig().IsSunrisePhase0(new(big.Int).SetUint64(head.Time)) {
-		baseFee = oracle.fixedBaseFee(head)
+	if oracle.isFixedBaseFee(head) {
+		var err error
+		if baseFee, err = oracle.fixedBaseFee(head); err != nil {
+			return new(big.Int).Set(lastPrice), new(big.Int).Set(lastBaseFee), err
+		}
 	} else if len(baseFeeResults) > 0 {
 		sort.Sort(bigIntArray(baseFeeResults))
 		baseFee = baseFeeResults[(len(baseFeeResults)-1)*oracle.percentile/100]
@@ -409,11 +414,53 @@ func (oracle *Oracle) getFeeInfo(ctx context.Context, number uint64) (*feeInfo,
 	return oracle.feeInfoProvider.addHeader(ctx, header)
 }
 
-func (oracle *Oracle) fixedBaseFee(head *types.Header) *big.Int {
+// cachedBaseFee returns the copy of [lastBaseFee] cached for [head], unless
+// the base fee is fixed, as a scheduled fixed base fee may be activated after
+// [head]
+func (oracle *Oracle) cachedBaseFee(head *types.Header, lastBaseFee *big.Int) (*big.Int, error) {
+	if oracle.isFixedBaseFee(head) {
+		return oracle.fixedBaseFee(head)
+	}
+	return new(big.Int).Set(lastBaseFee), nil
+}
+
+// PendingBaseFee returns the scheduled fixed base fee as of the latest block
+// and its activation timestamp. It returns nil if the base fee is not fixed or
+// no change is scheduled.
+func (oracle *Oracle) PendingBaseFee(ctx context.Context) (*big.Int, uint64, error) {
+	ctrl := oracle.backend.AdminController()
+	if ctrl == nil {
+		return nil, 0, nil
+	}
+	head, err := oracle.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !oracle.isFixedBaseFee(head) {
+		return nil, 0, nil
+	}
+	baseFee, timestamp := ctrl.GetPendingBaseFee(head, nil)
+	return baseFee, timestamp, nil
+}
+
+// isFixedBaseFee returns true if the base fee following [head] is fixed, which
+// is the case from Sunrise Phase 0 until Sunrise Phase 1 bounds the dynamic
+// base fee instead
+func (oracle *Oracle) isFixedBaseFee(head *types.Header) bool {
+	config := oracle.backend.ChainConfig()
+	timestamp := new(big.Int).SetUint64(head.Time)
+	return config.IsSunrisePhase0(timestamp) && !config.IsSunrisePhase1(timestamp)
+}
+
+func (oracle *Oracle) fixedBaseFee(head *types.Header) (*big.Int, error) {
 	if ctrl := oracle.backend.AdminController(); ctrl != nil {
-		return ctrl.GetFixedBaseFee(head, nil)
+		timestamp := oracle.clock.Unix()
+		if timestamp < head.Time {
+			timestamp = head.Time
+		}
+		return ctrl.GetFixedBaseFee(head, nil, timestamp)
 	} else {
-		return new(big.Int).SetUint64(params.SunrisePhase0BaseFee)
+		return new(big.Int).SetUint64(params.SunrisePhase0BaseFee), nil
 	}
 }
 
@@ -100,6 +100,11 @@ func (eth *Ethereum) StateAtBlock(block *types.Block, reexec uint64, base *state
 				return statedb, nil
 			}
 		}
+		// Try to rebuild the state from the state history before reexecuting
+		// blocks
+		if historyDb, historyErr := eth.blockchain.StateHistoryAt(block.Header()); historyErr == nil {
+			return historyDb, nil
+		}
 		// Database does not have the state for the given block, try to regenerate
 		for i := uint64(0); i < reexec; i++ {
 			if current.NumberU64() == 0 {
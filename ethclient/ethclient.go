@@ -563,6 +563,9 @@ type feeHistoryResultMarshaling struct {
 	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
 	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
 	GasUsedRatio []float64        `json:"gasUsedRatio"`
+
+	PendingBaseFee     *hexutil.Big   `json:"pendingBaseFeePerGas,omitempty"`
+	PendingBaseFeeTime hexutil.Uint64 `json:"pendingBaseFeeTimestamp,omitempty"`
 }
 
 // FeeHistory retrieves the fee market history.
@@ -587,6 +590,9 @@ func (ec *client) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *
 		Reward:       reward,
 		BaseFee:      baseFee,
 		GasUsedRatio: res.GasUsedRatio,
+
+		PendingBaseFee:     (*big.Int)(res.PendingBaseFee),
+		PendingBaseFeeTime: uint64(res.PendingBaseFeeTime),
 	}, nil
 }
 
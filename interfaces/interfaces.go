@@ -183,6 +183,9 @@ type FeeHistory struct {
 	Reward       [][]*big.Int // list every txs priority fee per block
 	BaseFee      []*big.Int   // list of each block's base fee
 	GasUsedRatio []float64    // ratio of gas used out of the total available limit
+
+	PendingBaseFee     *big.Int // scheduled fixed base fee, nil if none is scheduled
+	PendingBaseFeeTime uint64   // activation timestamp of the scheduled fixed base fee
 }
 
 // An AcceptedStateReceiver provides access to the accepted state ie. the state of the
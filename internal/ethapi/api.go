@@ -109,9 +109,14 @@ type feeHistoryResult struct {
 	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
 	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
 	GasUsedRatio []float64        `json:"gasUsedRatio"`
+
+	// Scheduled fixed base fee and its activation timestamp, if any
+	PendingBaseFee     *hexutil.Big    `json:"pendingBaseFeePerGas,omitempty"`
+	PendingBaseFeeTime *hexutil.Uint64 `json:"pendingBaseFeeTimestamp,omitempty"`
 }
 
-// FeeHistory returns the fee market history.
+// FeeHistory returns the fee market history. If the base fee is fixed, it also
+// reports the scheduled change of the fixed base fee.
 func (s *EthereumAPI) FeeHistory(ctx context.Context, blockCount rpc.DecimalOrHex, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*feeHistoryResult, error) {
 	oldest, reward, baseFee, gasUsed, err := s.b.FeeHistory(ctx, int(blockCount), lastBlock, rewardPercentiles)
 	if err != nil {
@@ -136,6 +141,14 @@ func (s *EthereumAPI) FeeHistory(ctx context.Context, blockCount rpc.DecimalOrHe
 			results.BaseFee[i] = (*hexutil.Big)(v)
 		}
 	}
+	pendingBaseFee, pendingTime, err := s.b.PendingBaseFee(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if pendingBaseFee != nil {
+		results.PendingBaseFee = (*hexutil.Big)(pendingBaseFee)
+		results.PendingBaseFeeTime = (*hexutil.Uint64)(&pendingTime)
+	}
 	return results, nil
 }
 
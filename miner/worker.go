@@ -166,10 +166,9 @@ func (w *worker) commitNewWork() (*types.Block, error) {
 			return nil, fmt.Errorf("failed to calculate new base fee: %w", err)
 		}
 	}
-	if w.coinbase == (common.Address{}) {
-		return nil, errors.New("cannot mine without etherbase")
-	}
-	header.Coinbase = w.coinbase
+	// The coinbase must be the blackhole address active at the block timestamp
+	coinbase := w.chainConfig.CaminoSystemContracts(bigTimestamp).BlackholeAddress
+	header.Coinbase = coinbase
 	if err := w.engine.Prepare(w.chain, header); err != nil {
 		return nil, fmt.Errorf("failed to prepare header for mining: %w", err)
 	}
@@ -183,6 +182,8 @@ func (w *worker) commitNewWork() (*types.Block, error) {
 	}
 	// Configure any stateful precompiles that should go into effect during this block.
 	w.chainConfig.CheckConfigurePrecompiles(new(big.Int).SetUint64(parent.Time()), types.NewBlockWithHeader(header), env.state)
+	// Upgrade the system contracts whose network upgrade activates in this block.
+	core.ApplyContractUpgrades(w.chainConfig, new(big.Int).SetUint64(parent.Time()), new(big.Int).SetUint64(header.Time), env.state)
 
 	// Fill the block with all available pending transactions.
 	pending := w.eth.TxPool().Pending(true)
@@ -198,11 +199,11 @@ func (w *worker) commitNewWork() (*types.Block, error) {
 	}
 	if len(localTxs) > 0 {
 		txs := types.NewTransactionsByPriceAndNonce(env.signer, localTxs, header.BaseFee)
-		w.commitTransactions(env, txs, w.coinbase)
+		w.commitTransactions(env, txs, coinbase)
 	}
 	if len(remoteTxs) > 0 {
 		txs := types.NewTransactionsByPriceAndNonce(env.signer, remoteTxs, header.BaseFee)
-		w.commitTransactions(env, txs, w.coinbase)
+		w.commitTransactions(env, txs, coinbase)
 	}
 
 	return w.commit(env)
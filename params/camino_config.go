@@ -4,8 +4,13 @@
 package params
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 
+	"github.com/ava-labs/coreth/constants"
+	"github.com/ava-labs/coreth/precompile"
+	"github.com/ava-labs/coreth/utils"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -15,6 +20,157 @@ const (
 	SunrisePhase0BaseFee       uint64 = 200_000_000_000
 )
 
+// DefaultKycPolicy is the KYC policy applied if no other policy is scheduled.
+// KYC is only enforced from Sunrise Phase 0 on. Like before KYC policies were
+// scheduled, it accepts expired KYC states.
+var DefaultKycPolicy = KycPolicy{ContractCreation: true, acceptExpired: true}
+
+// KycPolicy defines the operations which require the sender of a
+// transaction to be KYC verified (and not expired)
+type KycPolicy struct {
+	// ContractCreation requires KYC to deploy contracts
+	ContractCreation bool `json:"contractCreation,omitempty"`
+	// ValueTransfer requires KYC to transfer a non zero native value
+	ValueTransfer bool `json:"valueTransfer,omitempty"`
+	// Contracts requires KYC to call into one of the listed contracts
+	Contracts []common.Address `json:"contracts,omitempty"`
+
+	// acceptExpired treats expired KYC states as verified. It is only set by
+	// [DefaultKycPolicy], scheduled policies treat them as unverified.
+	acceptExpired bool
+}
+
+// KycPolicyUpgrade activates a KYC policy at a block timestamp
+type KycPolicyUpgrade struct {
+	BlockTimestamp *big.Int `json:"blockTimestamp"`
+	KycPolicy
+}
+
+// Required returns true if a call to [to] (nil for contract creation)
+// transferring [value] requires a KYC verified sender
+func (p *KycPolicy) Required(to *common.Address, value *big.Int) bool {
+	if to == nil {
+		return p.ContractCreation
+	}
+	if p.ValueTransfer && value != nil && value.Sign() != 0 {
+		return true
+	}
+	for _, contract := range p.Contracts {
+		if contract == *to {
+			return true
+		}
+	}
+	return false
+}
+
+// AcceptsExpired returns true if expired KYC states are treated as verified
+func (p *KycPolicy) AcceptsExpired() bool {
+	return p.acceptExpired
+}
+
+// FeeRewardRateDenominator is the denominator of the fee reward rates
+const FeeRewardRateDenominator uint64 = 1_000_000
+
+// DefaultFeeRewardRates is the fee reward split applied if no other split is
+// scheduled: 30% are exported to the P-Chain, 30% are paid to the incentive
+// pool and the remainder is burned.
+var DefaultFeeRewardRates = FeeRewardRates{
+	ExportRate:        300_000,
+	IncentivePoolRate: 300_000,
+}
+
+// FeeRewardRates splits the collected fees, in parts of
+// [FeeRewardRateDenominator]. The part not covered by both rates is burned.
+type FeeRewardRates struct {
+	// ExportRate is the part exported to the fee reward owner on the P-Chain
+	ExportRate uint64 `json:"exportRate"`
+	// IncentivePoolRate is the part paid to the fee reward contract
+	IncentivePoolRate uint64 `json:"incentivePoolRate"`
+}
+
+// FeeRewardUpgrade activates a fee reward split at a block timestamp
+type FeeRewardUpgrade struct {
+	BlockTimestamp *big.Int `json:"blockTimestamp"`
+	FeeRewardRates
+}
+
+// DefaultSystemContracts is the system contract layout deployed by the
+// Camino genesis
+var DefaultSystemContracts = SystemContracts{
+	BlackholeAddress:       constants.BlackholeAddr,
+	FeeRewardBalanceSlot:   common.Hash{0x01},
+	FeeRewardTimestampSlot: common.Hash{0x02},
+
+	AdminAddress:       common.HexToAddress("0x010000000000000000000000000000000000000a"),
+	AdminRolesSlot:     common.BigToHash(big.NewInt(0)),
+	AdminBaseFeeSlot:   common.BigToHash(big.NewInt(1)),
+	AdminKycSlot:       common.BigToHash(big.NewInt(2)),
+	AdminBlacklistSlot: common.BigToHash(big.NewInt(3)),
+
+	AdminBaseFeeFloorSlot:   common.BigToHash(big.NewInt(4)),
+	AdminBaseFeeCeilingSlot: common.BigToHash(big.NewInt(5)),
+
+	AdminPendingBaseFeeSlot:     common.BigToHash(big.NewInt(6)),
+	AdminPendingBaseFeeTimeSlot: common.BigToHash(big.NewInt(7)),
+
+	FeeRewardAddress: common.HexToAddress("0x010000000000000000000000000000000000000c"),
+
+	MultisigAddress:     common.HexToAddress("0x010000000000000000000000000000000000000e"),
+	MultisigAliasesSlot: common.BigToHash(big.NewInt(0)),
+	MultisigNoncesSlot:  common.BigToHash(big.NewInt(1)),
+}
+
+// SystemContracts locates the Camino system contracts and the storage
+// slots the node reads from them
+type SystemContracts struct {
+	// BlackholeAddress is the coinbase of every block, it collects the fees
+	BlackholeAddress common.Address `json:"blackholeAddress"`
+	// FeeRewardBalanceSlot of the blackhole stores the fees already paid out
+	FeeRewardBalanceSlot common.Hash `json:"feeRewardBalanceSlot"`
+	// FeeRewardTimestampSlot of the blackhole stores the earliest time
+	// of the next fee reward collection
+	FeeRewardTimestampSlot common.Hash `json:"feeRewardTimestampSlot"`
+
+	// AdminAddress is the address of the admin contract proxy
+	AdminAddress common.Address `json:"adminAddress"`
+	// AdminRolesSlot is the slot of the role mapping (address => uint256)
+	AdminRolesSlot common.Hash `json:"adminRolesSlot"`
+	// AdminBaseFeeSlot is the slot of the fixed base fee (uint256)
+	AdminBaseFeeSlot common.Hash `json:"adminBaseFeeSlot"`
+	// AdminKycSlot is the slot of the KYC state mapping (address => uint256)
+	AdminKycSlot common.Hash `json:"adminKycSlot"`
+	// AdminBlacklistSlot is the slot of the blacklist mapping (uint256 => uint256)
+	AdminBlacklistSlot common.Hash `json:"adminBlacklistSlot"`
+	// AdminBaseFeeFloorSlot is the slot of the minimum dynamic base fee (uint256)
+	AdminBaseFeeFloorSlot common.Hash `json:"adminBaseFeeFloorSlot"`
+	// AdminBaseFeeCeilingSlot is the slot of the maximum dynamic base fee (uint256)
+	AdminBaseFeeCeilingSlot common.Hash `json:"adminBaseFeeCeilingSlot"`
+	// AdminPendingBaseFeeSlot is the slot of the scheduled fixed base fee (uint256)
+	AdminPendingBaseFeeSlot common.Hash `json:"adminPendingBaseFeeSlot"`
+	// AdminPendingBaseFeeTimeSlot is the slot of the activation timestamp of
+	// the scheduled fixed base fee (uint256, 0 = none scheduled)
+	AdminPendingBaseFeeTimeSlot common.Hash `json:"adminPendingBaseFeeTimeSlot"`
+
+	// FeeRewardAddress is the address of the fee reward (incentive pool)
+	// contract proxy
+	FeeRewardAddress common.Address `json:"feeRewardAddress"`
+
+	// MultisigAddress is the address of the multisig alias contract proxy.
+	// Multisig aliases are not read from the EVM state if it is zero.
+	MultisigAddress common.Address `json:"multisigAddress"`
+	// MultisigAliasesSlot is the slot of the alias mapping (address => Owners)
+	MultisigAliasesSlot common.Hash `json:"multisigAliasesSlot"`
+	// MultisigNoncesSlot is the slot of the alias nonce mapping (address => uint256)
+	MultisigNoncesSlot common.Hash `json:"multisigNoncesSlot"`
+}
+
+// SystemContractsUpgrade relocates the system contracts at a block timestamp.
+// The contracts must be deployed at the new location before it is activated.
+type SystemContractsUpgrade struct {
+	BlockTimestamp *big.Int `json:"blockTimestamp"`
+	SystemContracts
+}
+
 var (
 	// CaminoChainConfig is the configuration for Camino Main Network
 	CaminoChainConfig = &ChainConfig{
@@ -42,6 +198,7 @@ var (
 		ApricotPhasePost6BlockTimestamp: common.Big0,
 		BanffBlockTimestamp:             common.Big0,
 		// TODO Add Cortina timestamps
+		SystemContracts: &DefaultSystemContracts,
 	}
 
 	// ColumbusChainConfig is the configuration for Columbus Test Network
@@ -70,6 +227,7 @@ var (
 		ApricotPhasePost6BlockTimestamp: common.Big0,
 		BanffBlockTimestamp:             common.Big0,
 		// TODO Add Cortina timestamps
+		SystemContracts: &DefaultSystemContracts,
 	}
 
 	// KopernikusChainConfig is the configuration for Kopernikus Dev Network
@@ -98,6 +256,7 @@ var (
 		ApricotPhasePost6BlockTimestamp: common.Big0,
 		BanffBlockTimestamp:             common.Big0,
 		// TODO Add Cortina timestamps
+		SystemContracts: &DefaultSystemContracts,
 	}
 )
 
@@ -107,5 +266,293 @@ func (c *ChainConfig) CaminoRules(blockNum, blockTimestamp *big.Int) Rules {
 	rules := c.AvalancheRules(blockNum, blockTimestamp)
 
 	rules.IsSunrisePhase0 = c.IsSunrisePhase0(blockTimestamp)
+	rules.IsSunrisePhase1 = c.IsSunrisePhase1(blockTimestamp)
+	rules.IsSunrisePhase2 = c.IsSunrisePhase2(blockTimestamp)
+	rules.KycPolicy = c.KycPolicy(blockTimestamp)
+	rules.SystemContracts = c.CaminoSystemContracts(blockTimestamp)
 	return rules
 }
+
+// CaminoSystemContracts returns the system contracts active at
+// [blockTimestamp]: the latest activated upgrade, or the genesis layout
+// which is [DefaultSystemContracts] if none is configured
+func (c *ChainConfig) CaminoSystemContracts(blockTimestamp *big.Int) *SystemContracts {
+	contracts := c.genesisSystemContracts()
+	for i := range c.SystemContractsUpgrades {
+		if utils.IsForked(c.SystemContractsUpgrades[i].BlockTimestamp, blockTimestamp) {
+			contracts = &c.SystemContractsUpgrades[i].SystemContracts
+		}
+	}
+	return contracts
+}
+
+// genesisSystemContracts returns the system contracts active from genesis on
+func (c *ChainConfig) genesisSystemContracts() *SystemContracts {
+	if c.SystemContracts == nil {
+		return &DefaultSystemContracts
+	}
+	return c.SystemContracts
+}
+
+// CaminoAdminState returns the location of the admin contract state active
+// at [blockTimestamp] which is read by the KYC admin precompile
+func (c *ChainConfig) CaminoAdminState(blockTimestamp *big.Int) precompile.AdminState {
+	contracts := c.CaminoSystemContracts(blockTimestamp)
+	return precompile.AdminState{
+		Address:        contracts.AdminAddress,
+		BaseFeeSlot:    contracts.AdminBaseFeeSlot,
+		KycSlot:        contracts.AdminKycSlot,
+		BlacklistSlot:  contracts.AdminBlacklistSlot,
+		DefaultBaseFee: new(big.Int).SetUint64(SunrisePhase0BaseFee),
+
+		PendingBaseFeeSlot:     contracts.AdminPendingBaseFeeSlot,
+		PendingBaseFeeTimeSlot: contracts.AdminPendingBaseFeeTimeSlot,
+	}
+}
+
+// checkSystemContracts checks that the configured system contracts have an
+// address and that upgrades are scheduled in strictly increasing timestamp
+// order
+func (c *ChainConfig) checkSystemContracts() error {
+	if err := c.genesisSystemContracts().verify(); err != nil {
+		return fmt.Errorf("system contracts: %w", err)
+	}
+	var lastTimestamp *big.Int
+	for i, upgrade := range c.SystemContractsUpgrades {
+		if upgrade.BlockTimestamp == nil {
+			return fmt.Errorf("system contracts upgrade %d has no block timestamp", i)
+		}
+		if lastTimestamp != nil && lastTimestamp.Cmp(upgrade.BlockTimestamp) >= 0 {
+			return fmt.Errorf("system contracts upgrade %d at %v is not after previous upgrade at %v",
+				i, upgrade.BlockTimestamp, lastTimestamp)
+		}
+		if err := upgrade.SystemContracts.verify(); err != nil {
+			return fmt.Errorf("system contracts upgrade %d: %w", i, err)
+		}
+		lastTimestamp = upgrade.BlockTimestamp
+	}
+	return nil
+}
+
+// checkSystemContractsCompatible returns an error if [newcfg] changes the
+// genesis system contracts of a started chain or a system contracts upgrade
+// which was already activated at [lastTimestamp]
+func (c *ChainConfig) checkSystemContractsCompatible(newcfg *ChainConfig, lastHeight *big.Int, lastTimestamp *big.Int) *ConfigCompatError {
+	if lastHeight != nil && lastHeight.Sign() > 0 && *c.genesisSystemContracts() != *newcfg.genesisSystemContracts() {
+		return newCompatError("SystemContracts", common.Big0, common.Big0)
+	}
+	for i := 0; i < len(c.SystemContractsUpgrades) || i < len(newcfg.SystemContractsUpgrades); i++ {
+		var stored, next *SystemContractsUpgrade
+		if i < len(c.SystemContractsUpgrades) {
+			stored = &c.SystemContractsUpgrades[i]
+		}
+		if i < len(newcfg.SystemContractsUpgrades) {
+			next = &newcfg.SystemContractsUpgrades[i]
+		}
+		var storedTimestamp, newTimestamp *big.Int
+		if stored != nil {
+			storedTimestamp = stored.BlockTimestamp
+		}
+		if next != nil {
+			newTimestamp = next.BlockTimestamp
+		}
+		if isForkIncompatible(storedTimestamp, newTimestamp, lastTimestamp) {
+			return newCompatError(fmt.Sprintf("SystemContracts upgrade %d block timestamp", i), storedTimestamp, newTimestamp)
+		}
+		if utils.IsForked(storedTimestamp, lastTimestamp) && stored.SystemContracts != next.SystemContracts {
+			return newCompatError(fmt.Sprintf("SystemContracts upgrade %d", i), storedTimestamp, newTimestamp)
+		}
+	}
+	return nil
+}
+
+// verify checks that the system contracts have an address and that the
+// blackhole address is not used by a stateful precompile
+func (s *SystemContracts) verify() error {
+	switch {
+	case s.BlackholeAddress == (common.Address{}):
+		return errors.New("missing blackhole address")
+	case s.AdminAddress == (common.Address{}):
+		return errors.New("missing admin address")
+	case s.FeeRewardAddress == (common.Address{}):
+		return errors.New("missing fee reward address")
+	}
+	if _, ok := precompile.GetModuleByAddress(s.BlackholeAddress); ok {
+		return fmt.Errorf("blackhole address %s overlaps with a stateful precompile", s.BlackholeAddress)
+	}
+	return nil
+}
+
+// checkSunriseForkOrder checks that Sunrise Phase 1 and 2 are not activated
+// before Sunrise Phase 0, as they rely on the admin and multisig contracts,
+// and that Sunrise Phase 2 is not activated before Sunrise Phase 1
+func (c *ChainConfig) checkSunriseForkOrder() error {
+	if c.SunrisePhase2BlockTimestamp != nil {
+		switch {
+		case c.SunrisePhase0BlockTimestamp == nil:
+			return fmt.Errorf("unsupported fork ordering: sunrisePhase0BlockTimestamp not enabled, but sunrisePhase2BlockTimestamp enabled at %v",
+				c.SunrisePhase2BlockTimestamp)
+		case c.SunrisePhase0BlockTimestamp.Cmp(c.SunrisePhase2BlockTimestamp) > 0:
+			return fmt.Errorf("unsupported fork ordering: sunrisePhase0BlockTimestamp enabled at %v, but sunrisePhase2BlockTimestamp enabled at %v",
+				c.SunrisePhase0BlockTimestamp, c.SunrisePhase2BlockTimestamp)
+		case c.SunrisePhase1BlockTimestamp != nil && c.SunrisePhase1BlockTimestamp.Cmp(c.SunrisePhase2BlockTimestamp) > 0:
+			return fmt.Errorf("unsupported fork ordering: sunrisePhase1BlockTimestamp enabled at %v, but sunrisePhase2BlockTimestamp enabled at %v",
+				c.SunrisePhase1BlockTimestamp, c.SunrisePhase2BlockTimestamp)
+		}
+	}
+	switch {
+	case c.SunrisePhase1BlockTimestamp == nil:
+		return nil
+	case c.SunrisePhase0BlockTimestamp == nil:
+		return fmt.Errorf("unsupported fork ordering: sunrisePhase0BlockTimestamp not enabled, but sunrisePhase1BlockTimestamp enabled at %v",
+			c.SunrisePhase1BlockTimestamp)
+	case c.SunrisePhase0BlockTimestamp.Cmp(c.SunrisePhase1BlockTimestamp) > 0:
+		return fmt.Errorf("unsupported fork ordering: sunrisePhase0BlockTimestamp enabled at %v, but sunrisePhase1BlockTimestamp enabled at %v",
+			c.SunrisePhase0BlockTimestamp, c.SunrisePhase1BlockTimestamp)
+	}
+	return nil
+}
+
+// KycPolicy returns the KYC policy active at [blockTimestamp]
+func (c *ChainConfig) KycPolicy(blockTimestamp *big.Int) KycPolicy {
+	policy := DefaultKycPolicy
+	for _, upgrade := range c.KycPolicyUpgrades {
+		if utils.IsForked(upgrade.BlockTimestamp, blockTimestamp) {
+			policy = upgrade.KycPolicy
+		}
+	}
+	return policy
+}
+
+// checkKycPolicyUpgrades checks that KYC policy upgrades are scheduled
+// in strictly increasing timestamp order
+func (c *ChainConfig) checkKycPolicyUpgrades() error {
+	var lastTimestamp *big.Int
+	for i, upgrade := range c.KycPolicyUpgrades {
+		if upgrade.BlockTimestamp == nil {
+			return fmt.Errorf("kyc policy upgrade %d has no block timestamp", i)
+		}
+		if lastTimestamp != nil && lastTimestamp.Cmp(upgrade.BlockTimestamp) >= 0 {
+			return fmt.Errorf("kyc policy upgrade %d at %v is not after previous upgrade at %v",
+				i, upgrade.BlockTimestamp, lastTimestamp)
+		}
+		lastTimestamp = upgrade.BlockTimestamp
+	}
+	return nil
+}
+
+// checkKycPolicyCompatible returns an error if [newcfg] changes a KYC policy
+// which was already activated at [lastTimestamp]
+func (c *ChainConfig) checkKycPolicyCompatible(newcfg *ChainConfig, lastTimestamp *big.Int) *ConfigCompatError {
+	for i := 0; i < len(c.KycPolicyUpgrades) || i < len(newcfg.KycPolicyUpgrades); i++ {
+		var stored, next *KycPolicyUpgrade
+		if i < len(c.KycPolicyUpgrades) {
+			stored = &c.KycPolicyUpgrades[i]
+		}
+		if i < len(newcfg.KycPolicyUpgrades) {
+			next = &newcfg.KycPolicyUpgrades[i]
+		}
+		var storedTimestamp, newTimestamp *big.Int
+		if stored != nil {
+			storedTimestamp = stored.BlockTimestamp
+		}
+		if next != nil {
+			newTimestamp = next.BlockTimestamp
+		}
+		if isForkIncompatible(storedTimestamp, newTimestamp, lastTimestamp) {
+			return newCompatError(fmt.Sprintf("KycPolicy upgrade %d block timestamp", i), storedTimestamp, newTimestamp)
+		}
+		if utils.IsForked(storedTimestamp, lastTimestamp) && !stored.KycPolicy.equal(&next.KycPolicy) {
+			return newCompatError(fmt.Sprintf("KycPolicy upgrade %d", i), storedTimestamp, newTimestamp)
+		}
+	}
+	return nil
+}
+
+// FeeRewardRates returns the fee reward split active at [blockTimestamp]
+func (c *ChainConfig) FeeRewardRates(blockTimestamp *big.Int) FeeRewardRates {
+	rates := DefaultFeeRewardRates
+	for _, upgrade := range c.FeeRewardUpgrades {
+		if utils.IsForked(upgrade.BlockTimestamp, blockTimestamp) {
+			rates = upgrade.FeeRewardRates
+		}
+	}
+	return rates
+}
+
+// checkFeeRewardUpgrades checks that fee reward upgrades are scheduled in
+// strictly increasing timestamp order and split at most the collected fees
+func (c *ChainConfig) checkFeeRewardUpgrades() error {
+	var lastTimestamp *big.Int
+	for i, upgrade := range c.FeeRewardUpgrades {
+		if upgrade.BlockTimestamp == nil {
+			return fmt.Errorf("fee reward upgrade %d has no block timestamp", i)
+		}
+		if lastTimestamp != nil && lastTimestamp.Cmp(upgrade.BlockTimestamp) >= 0 {
+			return fmt.Errorf("fee reward upgrade %d at %v is not after previous upgrade at %v",
+				i, upgrade.BlockTimestamp, lastTimestamp)
+		}
+		if err := upgrade.FeeRewardRates.Verify(); err != nil {
+			return fmt.Errorf("fee reward upgrade %d is invalid: %w", i, err)
+		}
+		lastTimestamp = upgrade.BlockTimestamp
+	}
+	return nil
+}
+
+// checkFeeRewardCompatible returns an error if [newcfg] changes a fee reward
+// split which was already activated at [lastTimestamp]
+func (c *ChainConfig) checkFeeRewardCompatible(newcfg *ChainConfig, lastTimestamp *big.Int) *ConfigCompatError {
+	for i := 0; i < len(c.FeeRewardUpgrades) || i < len(newcfg.FeeRewardUpgrades); i++ {
+		var stored, next *FeeRewardUpgrade
+		if i < len(c.FeeRewardUpgrades) {
+			stored = &c.FeeRewardUpgrades[i]
+		}
+		if i < len(newcfg.FeeRewardUpgrades) {
+			next = &newcfg.FeeRewardUpgrades[i]
+		}
+		var storedTimestamp, newTimestamp *big.Int
+		if stored != nil {
+			storedTimestamp = stored.BlockTimestamp
+		}
+		if next != nil {
+			newTimestamp = next.BlockTimestamp
+		}
+		if isForkIncompatible(storedTimestamp, newTimestamp, lastTimestamp) {
+			return newCompatError(fmt.Sprintf("FeeReward upgrade %d block timestamp", i), storedTimestamp, newTimestamp)
+		}
+		if utils.IsForked(storedTimestamp, lastTimestamp) && stored.FeeRewardRates != next.FeeRewardRates {
+			return newCompatError(fmt.Sprintf("FeeReward upgrade %d", i), storedTimestamp, newTimestamp)
+		}
+	}
+	return nil
+}
+
+// Verify checks that the rates export a part of the collected fees without
+// exceeding them. Without export rate the rewards could never be collected,
+// as a collection exports at least the minimum export amount.
+func (r FeeRewardRates) Verify() error {
+	switch {
+	case r.ExportRate > FeeRewardRateDenominator ||
+		r.IncentivePoolRate > FeeRewardRateDenominator-r.ExportRate:
+		return fmt.Errorf("fee reward rates exceed %d", FeeRewardRateDenominator)
+	case r.ExportRate == 0:
+		return errors.New("fee reward export rate is zero")
+	}
+	return nil
+}
+
+func (p *KycPolicy) equal(other *KycPolicy) bool {
+	if p.ContractCreation != other.ContractCreation ||
+		p.ValueTransfer != other.ValueTransfer ||
+		p.acceptExpired != other.acceptExpired ||
+		len(p.Contracts) != len(other.Contracts) {
+		return false
+	}
+	for i, contract := range p.Contracts {
+		if contract != other.Contracts[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,226 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package params
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKycPolicy(t *testing.T) {
+	contract := common.Address{1}
+	config := &ChainConfig{
+		KycPolicyUpgrades: []KycPolicyUpgrade{
+			{
+				BlockTimestamp: big.NewInt(20),
+				KycPolicy:      KycPolicy{ValueTransfer: true, Contracts: []common.Address{contract}},
+			},
+		},
+	}
+	require.NoError(t, config.checkKycPolicyUpgrades())
+
+	// Without any scheduled policy the default policy applies
+	policy := config.KycPolicy(big.NewInt(10))
+	require.True(t, policy.Required(nil, common.Big0))
+	require.False(t, policy.Required(&contract, common.Big1))
+	require.True(t, policy.AcceptsExpired())
+
+	// The scheduled policy replaces the default one
+	policy = config.KycPolicy(big.NewInt(20))
+	require.False(t, policy.Required(nil, common.Big0))
+	require.True(t, policy.Required(&common.Address{2}, common.Big1))
+	require.False(t, policy.Required(&common.Address{2}, common.Big0))
+	require.True(t, policy.Required(&contract, common.Big0))
+	require.False(t, policy.AcceptsExpired())
+}
+
+func TestCheckKycPolicyUpgrades(t *testing.T) {
+	config := &ChainConfig{
+		KycPolicyUpgrades: []KycPolicyUpgrade{
+			{BlockTimestamp: big.NewInt(20)},
+			{BlockTimestamp: big.NewInt(20)},
+		},
+	}
+	require.Error(t, config.checkKycPolicyUpgrades())
+
+	config.KycPolicyUpgrades[1].BlockTimestamp = nil
+	require.Error(t, config.checkKycPolicyUpgrades())
+
+	config.KycPolicyUpgrades[1].BlockTimestamp = big.NewInt(30)
+	require.NoError(t, config.checkKycPolicyUpgrades())
+}
+
+func TestCheckKycPolicyCompatible(t *testing.T) {
+	stored := &ChainConfig{
+		KycPolicyUpgrades: []KycPolicyUpgrade{
+			{BlockTimestamp: big.NewInt(20), KycPolicy: KycPolicy{ValueTransfer: true}},
+		},
+	}
+	changed := &ChainConfig{
+		KycPolicyUpgrades: []KycPolicyUpgrade{
+			{BlockTimestamp: big.NewInt(20), KycPolicy: KycPolicy{ContractCreation: true}},
+		},
+	}
+	require.Nil(t, stored.CheckCompatible(changed, 0, 10))
+	require.NotNil(t, stored.CheckCompatible(changed, 0, 20))
+	require.Nil(t, stored.CheckCompatible(stored, 0, 20))
+}
+
+func TestFeeRewardRates(t *testing.T) {
+	rates := FeeRewardRates{ExportRate: 500_000, IncentivePoolRate: 200_000}
+	config := &ChainConfig{
+		FeeRewardUpgrades: []FeeRewardUpgrade{
+			{BlockTimestamp: big.NewInt(20), FeeRewardRates: rates},
+		},
+	}
+	require.NoError(t, config.checkFeeRewardUpgrades())
+	require.Equal(t, DefaultFeeRewardRates, config.FeeRewardRates(big.NewInt(10)))
+	require.Equal(t, rates, config.FeeRewardRates(big.NewInt(20)))
+
+	changed := &ChainConfig{
+		FeeRewardUpgrades: []FeeRewardUpgrade{
+			{BlockTimestamp: big.NewInt(20), FeeRewardRates: DefaultFeeRewardRates},
+		},
+	}
+	require.Nil(t, config.CheckCompatible(changed, 0, 10))
+	require.NotNil(t, config.CheckCompatible(changed, 0, 20))
+	require.Nil(t, config.CheckCompatible(config, 0, 20))
+}
+
+func TestCheckFeeRewardUpgrades(t *testing.T) {
+	config := &ChainConfig{
+		FeeRewardUpgrades: []FeeRewardUpgrade{
+			{BlockTimestamp: big.NewInt(20), FeeRewardRates: DefaultFeeRewardRates},
+			{BlockTimestamp: big.NewInt(20), FeeRewardRates: DefaultFeeRewardRates},
+		},
+	}
+	require.Error(t, config.checkFeeRewardUpgrades())
+
+	config.FeeRewardUpgrades[1].BlockTimestamp = big.NewInt(30)
+	require.NoError(t, config.checkFeeRewardUpgrades())
+
+	config.FeeRewardUpgrades[1].FeeRewardRates = FeeRewardRates{}
+	require.Error(t, config.checkFeeRewardUpgrades())
+
+	config.FeeRewardUpgrades[1].FeeRewardRates = FeeRewardRates{ExportRate: FeeRewardRateDenominator, IncentivePoolRate: 1}
+	require.Error(t, config.checkFeeRewardUpgrades())
+
+	config.FeeRewardUpgrades[1].FeeRewardRates = FeeRewardRates{IncentivePoolRate: FeeRewardRateDenominator}
+	require.Error(t, config.checkFeeRewardUpgrades())
+
+	config.FeeRewardUpgrades[1].FeeRewardRates = FeeRewardRates{ExportRate: FeeRewardRateDenominator}
+	require.NoError(t, config.checkFeeRewardUpgrades())
+}
+
+func TestSystemContracts(t *testing.T) {
+	config := &ChainConfig{}
+	require.Equal(t, &DefaultSystemContracts, config.CaminoSystemContracts(common.Big0))
+	require.NoError(t, config.checkSystemContracts())
+
+	contracts := DefaultSystemContracts
+	contracts.AdminAddress = common.Address{}
+	config.SystemContracts = &contracts
+	require.Error(t, config.checkSystemContracts())
+
+	contracts.AdminAddress = common.Address{1}
+	require.NoError(t, config.checkSystemContracts())
+	require.Equal(t, common.Address{1}, config.CaminoRules(common.Big0, common.Big0).SystemContracts.AdminAddress)
+
+	// The JSON layout round trips
+	data, err := json.Marshal(config)
+	require.NoError(t, err)
+	decoded := &ChainConfig{}
+	require.NoError(t, json.Unmarshal(data, decoded))
+	require.Equal(t, &contracts, decoded.SystemContracts)
+}
+
+func TestSystemContractsUpgrades(t *testing.T) {
+	relocated := DefaultSystemContracts
+	relocated.BlackholeAddress = common.Address{1}
+	config := &ChainConfig{
+		SystemContractsUpgrades: []SystemContractsUpgrade{
+			{BlockTimestamp: big.NewInt(20), SystemContracts: relocated},
+		},
+	}
+	require.NoError(t, config.checkSystemContracts())
+	require.Equal(t, &DefaultSystemContracts, config.CaminoSystemContracts(big.NewInt(19)))
+	require.Equal(t, &relocated, config.CaminoSystemContracts(big.NewInt(20)))
+	require.Equal(t, common.Address{1}, config.CaminoRules(common.Big0, big.NewInt(20)).SystemContracts.BlackholeAddress)
+
+	config.SystemContractsUpgrades = append(config.SystemContractsUpgrades, SystemContractsUpgrade{BlockTimestamp: big.NewInt(20), SystemContracts: relocated})
+	require.Error(t, config.checkSystemContracts())
+
+	config.SystemContractsUpgrades[1] = SystemContractsUpgrade{BlockTimestamp: big.NewInt(30)}
+	require.Error(t, config.checkSystemContracts())
+}
+
+func TestCheckSystemContractsCompatible(t *testing.T) {
+	relocated := DefaultSystemContracts
+	relocated.AdminAddress = common.Address{1}
+	stored := &ChainConfig{
+		SystemContractsUpgrades: []SystemContractsUpgrade{
+			{BlockTimestamp: big.NewInt(20), SystemContracts: relocated},
+		},
+	}
+
+	// The genesis layout cannot change once the chain started
+	changed := &ChainConfig{SystemContracts: &relocated, SystemContractsUpgrades: stored.SystemContractsUpgrades}
+	require.Nil(t, stored.CheckCompatible(changed, 0, 0))
+	require.NotNil(t, stored.CheckCompatible(changed, 1, 10))
+	require.Nil(t, stored.CheckCompatible(&ChainConfig{SystemContracts: &DefaultSystemContracts, SystemContractsUpgrades: stored.SystemContractsUpgrades}, 1, 10))
+
+	// Activated upgrades cannot change
+	moved := relocated
+	moved.FeeRewardAddress = common.Address{2}
+	changed = &ChainConfig{
+		SystemContractsUpgrades: []SystemContractsUpgrade{
+			{BlockTimestamp: big.NewInt(20), SystemContracts: moved},
+		},
+	}
+	require.Nil(t, stored.CheckCompatible(changed, 1, 10))
+	require.NotNil(t, stored.CheckCompatible(changed, 2, 20))
+	require.NotNil(t, stored.CheckCompatible(&ChainConfig{}, 2, 20))
+	require.Nil(t, stored.CheckCompatible(stored, 2, 20))
+}
+
+func TestCheckSunriseForkOrder(t *testing.T) {
+	config := &ChainConfig{SunrisePhase1BlockTimestamp: big.NewInt(20)}
+	require.Error(t, config.checkSunriseForkOrder())
+
+	config.SunrisePhase0BlockTimestamp = big.NewInt(30)
+	require.Error(t, config.checkSunriseForkOrder())
+
+	config.SunrisePhase0BlockTimestamp = big.NewInt(10)
+	require.NoError(t, config.checkSunriseForkOrder())
+	require.False(t, config.CaminoRules(common.Big0, big.NewInt(10)).IsSunrisePhase1)
+	require.True(t, config.CaminoRules(common.Big0, big.NewInt(20)).IsSunrisePhase1)
+
+	config.SunrisePhase2BlockTimestamp = big.NewInt(15)
+	require.Error(t, config.checkSunriseForkOrder())
+
+	config.SunrisePhase2BlockTimestamp = big.NewInt(30)
+	require.NoError(t, config.checkSunriseForkOrder())
+	require.False(t, config.CaminoRules(common.Big0, big.NewInt(20)).IsSunrisePhase2)
+	require.True(t, config.CaminoRules(common.Big0, big.NewInt(30)).IsSunrisePhase2)
+
+	config.SunrisePhase1BlockTimestamp = nil
+	require.NoError(t, config.checkSunriseForkOrder())
+
+	config.SunrisePhase0BlockTimestamp = nil
+	require.Error(t, config.checkSunriseForkOrder())
+}
+
+func TestCheckSunrisePhase2Compatible(t *testing.T) {
+	stored := &ChainConfig{SunrisePhase2BlockTimestamp: big.NewInt(20)}
+	changed := &ChainConfig{SunrisePhase2BlockTimestamp: big.NewInt(30)}
+
+	require.Nil(t, stored.CheckCompatible(changed, 1, 10))
+	require.NotNil(t, stored.CheckCompatible(changed, 2, 20))
+	require.NotNil(t, stored.CheckCompatible(&ChainConfig{}, 2, 20))
+	require.Nil(t, stored.CheckCompatible(stored, 2, 20))
+}
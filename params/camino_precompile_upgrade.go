@@ -0,0 +1,155 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package params
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/coreth/precompile"
+	"github.com/ava-labs/coreth/utils"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PrecompileUpgrade enables or disables a registered stateful precompile at a
+// block timestamp. It is encoded as an object with the config key of the
+// precompile module as its only key, e.g.
+//
+//	{"kycAdminConfig": {"blockTimestamp": 1000}}
+type PrecompileUpgrade struct {
+	precompile.StatefulPrecompileConfig
+}
+
+// UnmarshalJSON decodes the upgrade into the config of the registered module
+func (u *PrecompileUpgrade) UnmarshalJSON(data []byte) error {
+	raw := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 1 {
+		return fmt.Errorf("precompile upgrade must contain exactly one precompile config, found %d", len(raw))
+	}
+	for key, value := range raw {
+		module, ok := precompile.GetModule(key)
+		if !ok {
+			return fmt.Errorf("unknown precompile config key: %s", key)
+		}
+		config := module.NewConfig()
+		if err := json.Unmarshal(value, config); err != nil {
+			return fmt.Errorf("invalid %s precompile upgrade: %w", key, err)
+		}
+		u.StatefulPrecompileConfig = config
+	}
+	return nil
+}
+
+// MarshalJSON encodes the upgrade keyed by the config key of its module
+func (u PrecompileUpgrade) MarshalJSON() ([]byte, error) {
+	if u.StatefulPrecompileConfig == nil {
+		return nil, fmt.Errorf("empty precompile upgrade")
+	}
+	module, ok := precompile.GetModuleByAddress(u.Address())
+	if !ok {
+		return nil, fmt.Errorf("no precompile module registered at %s", u.Address())
+	}
+	return json.Marshal(map[string]precompile.StatefulPrecompileConfig{
+		module.ConfigKey: u.StatefulPrecompileConfig,
+	})
+}
+
+// checkPrecompileUpgrades checks that every upgrade belongs to a registered
+// module and that upgrades are scheduled in timestamp order. The upgrades of
+// each precompile must have strictly increasing timestamps and alternate
+// between enabling and disabling, starting with enabling.
+func (c *ChainConfig) checkPrecompileUpgrades() error {
+	var lastTimestamp *big.Int
+	// enabled tracks the state of each precompile after the upgrades checked so far
+	enabled := make(map[string]bool)
+	lastTimestamps := make(map[string]*big.Int)
+	for i, upgrade := range c.PrecompileUpgrades {
+		if upgrade.StatefulPrecompileConfig == nil {
+			return fmt.Errorf("precompile upgrade %d is empty", i)
+		}
+		address := upgrade.Address()
+		if precompile.IsNativeAddress(address) {
+			return fmt.Errorf("precompile upgrade %d conflicts with native address %s", i, address)
+		}
+		module, ok := precompile.GetModuleByAddress(address)
+		if !ok {
+			return fmt.Errorf("precompile upgrade %d: no precompile module registered at %s", i, address)
+		}
+		timestamp := upgrade.Timestamp()
+		if timestamp == nil {
+			return fmt.Errorf("precompile upgrade %d (%s) has no block timestamp", i, module.ConfigKey)
+		}
+		if lastTimestamp != nil && lastTimestamp.Cmp(timestamp) > 0 {
+			return fmt.Errorf("precompile upgrade %d (%s) at %v is before previous upgrade at %v",
+				i, module.ConfigKey, timestamp, lastTimestamp)
+		}
+		if last := lastTimestamps[module.ConfigKey]; last != nil && last.Cmp(timestamp) >= 0 {
+			return fmt.Errorf("precompile upgrade %d (%s) at %v is not after previous %s upgrade at %v",
+				i, module.ConfigKey, timestamp, module.ConfigKey, last)
+		}
+		lastTimestamp = timestamp
+		lastTimestamps[module.ConfigKey] = timestamp
+
+		disable := upgrade.IsDisabled()
+		if enabled[module.ConfigKey] == !disable {
+			if disable {
+				return fmt.Errorf("precompile upgrade %d disables %s which is not enabled", i, module.ConfigKey)
+			}
+			return fmt.Errorf("precompile upgrade %d enables %s which is already enabled", i, module.ConfigKey)
+		}
+		enabled[module.ConfigKey] = !disable
+
+		if !disable {
+			if err := upgrade.Verify(); err != nil {
+				return fmt.Errorf("precompile upgrade %d (%s) is invalid: %w", i, module.ConfigKey, err)
+			}
+		}
+	}
+	return nil
+}
+
+// checkPrecompilesCompatible returns an error if [newcfg] changes a precompile
+// upgrade which was already activated at [lastTimestamp]
+func (c *ChainConfig) checkPrecompilesCompatible(newcfg *ChainConfig, lastTimestamp *big.Int) *ConfigCompatError {
+	for i := 0; i < len(c.PrecompileUpgrades) || i < len(newcfg.PrecompileUpgrades); i++ {
+		var storedTimestamp, newTimestamp *big.Int
+		var stored, next precompile.StatefulPrecompileConfig
+		if i < len(c.PrecompileUpgrades) {
+			stored = c.PrecompileUpgrades[i].StatefulPrecompileConfig
+			storedTimestamp = stored.Timestamp()
+		}
+		if i < len(newcfg.PrecompileUpgrades) {
+			next = newcfg.PrecompileUpgrades[i].StatefulPrecompileConfig
+			newTimestamp = next.Timestamp()
+		}
+		if isForkIncompatible(storedTimestamp, newTimestamp, lastTimestamp) {
+			return newCompatError(fmt.Sprintf("PrecompileUpgrade %d block timestamp", i), storedTimestamp, newTimestamp)
+		}
+		if utils.IsForked(storedTimestamp, lastTimestamp) && (next == nil || !stored.Equal(next)) {
+			return newCompatError(fmt.Sprintf("PrecompileUpgrade %d", i), storedTimestamp, newTimestamp)
+		}
+	}
+	return nil
+}
+
+// activePrecompileConfig returns the config of the precompile at [address]
+// active at [blockTimestamp] or nil if it is not enabled
+func (c *ChainConfig) activePrecompileConfig(address common.Address, blockTimestamp *big.Int) precompile.StatefulPrecompileConfig {
+	var active precompile.StatefulPrecompileConfig
+	for _, upgrade := range c.PrecompileUpgrades {
+		if upgrade.Address() != address || !utils.IsForked(upgrade.Timestamp(), blockTimestamp) {
+			continue
+		}
+		if upgrade.IsDisabled() {
+			active = nil
+		} else {
+			active = upgrade.StatefulPrecompileConfig
+		}
+	}
+	return active
+}
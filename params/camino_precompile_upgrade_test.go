@@ -0,0 +1,149 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package params
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/precompile"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var testPrecompileAddr = common.HexToAddress("0x01000000000000000000000000000000000000f0")
+
+type testPrecompileConfig struct {
+	precompile.UpgradeableConfig
+	Value uint64 `json:"value,omitempty"`
+}
+
+func (c *testPrecompileConfig) Address() common.Address { return testPrecompileAddr }
+
+func (c *testPrecompileConfig) Equal(other precompile.StatefulPrecompileConfig) bool {
+	o, ok := other.(*testPrecompileConfig)
+	return ok && c.UpgradeableConfig.Equal(&o.UpgradeableConfig) && c.Value == o.Value
+}
+
+func (c *testPrecompileConfig) Verify() error { return nil }
+
+func (c *testPrecompileConfig) Configure(precompile.ChainConfig, precompile.StateDB, precompile.BlockContext) {
+}
+
+func (c *testPrecompileConfig) Contract() precompile.StatefulPrecompiledContract {
+	return testPrecompile{}
+}
+
+type testPrecompile struct{}
+
+func (testPrecompile) Run(precompile.PrecompileAccessibleState, common.Address, common.Address, []byte, uint64, bool) ([]byte, uint64, error) {
+	return nil, 0, nil
+}
+
+func init() {
+	if err := precompile.RegisterModule(precompile.Module{
+		ConfigKey: "testConfig",
+		Address:   testPrecompileAddr,
+		NewConfig: func() precompile.StatefulPrecompileConfig { return &testPrecompileConfig{} },
+	}); err != nil {
+		panic(err)
+	}
+}
+
+func testUpgrade(timestamp int64, disable bool) PrecompileUpgrade {
+	return PrecompileUpgrade{&testPrecompileConfig{
+		UpgradeableConfig: precompile.UpgradeableConfig{BlockTimestamp: big.NewInt(timestamp), Disable: disable},
+	}}
+}
+
+func TestPrecompileUpgradeJSON(t *testing.T) {
+	data := []byte(`{"precompileUpgrades":[{"testConfig":{"blockTimestamp":10,"value":5}},{"testConfig":{"blockTimestamp":20,"disable":true}}]}`)
+	config := &ChainConfig{}
+	require.NoError(t, json.Unmarshal(data, config))
+	require.Len(t, config.PrecompileUpgrades, 2)
+	require.Equal(t, &testPrecompileConfig{
+		UpgradeableConfig: precompile.UpgradeableConfig{BlockTimestamp: big.NewInt(10)},
+		Value:             5,
+	}, config.PrecompileUpgrades[0].StatefulPrecompileConfig)
+	require.True(t, config.PrecompileUpgrades[1].IsDisabled())
+
+	encoded, err := json.Marshal(config)
+	require.NoError(t, err)
+	decoded := &ChainConfig{}
+	require.NoError(t, json.Unmarshal(encoded, decoded))
+	require.Equal(t, config.PrecompileUpgrades, decoded.PrecompileUpgrades)
+
+	require.ErrorContains(t, json.Unmarshal([]byte(`{"precompileUpgrades":[{"unknownConfig":{}}]}`), &ChainConfig{}), "unknown precompile config key")
+	require.ErrorContains(t, json.Unmarshal([]byte(`{"precompileUpgrades":[{}]}`), &ChainConfig{}), "exactly one")
+}
+
+func TestCheckPrecompileUpgrades(t *testing.T) {
+	tests := []struct {
+		name     string
+		upgrades []PrecompileUpgrade
+		err      string
+	}{
+		{
+			name:     "enable and disable",
+			upgrades: []PrecompileUpgrade{testUpgrade(10, false), testUpgrade(20, true), testUpgrade(30, false)},
+		},
+		{
+			name:     "disable first",
+			upgrades: []PrecompileUpgrade{testUpgrade(10, true)},
+			err:      "which is not enabled",
+		},
+		{
+			name:     "enable twice",
+			upgrades: []PrecompileUpgrade{testUpgrade(10, false), testUpgrade(20, false)},
+			err:      "which is already enabled",
+		},
+		{
+			name:     "same timestamp",
+			upgrades: []PrecompileUpgrade{testUpgrade(10, false), testUpgrade(10, true)},
+			err:      "is not after previous",
+		},
+		{
+			name:     "out of order",
+			upgrades: []PrecompileUpgrade{testUpgrade(20, false), testUpgrade(10, true)},
+			err:      "is before previous",
+		},
+		{
+			name:     "missing timestamp",
+			upgrades: []PrecompileUpgrade{{&testPrecompileConfig{}}},
+			err:      "has no block timestamp",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := &ChainConfig{PrecompileUpgrades: test.upgrades}
+			err := config.checkPrecompileUpgrades()
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestPrecompileUpgradeRules(t *testing.T) {
+	config := &ChainConfig{
+		PrecompileUpgrades: []PrecompileUpgrade{testUpgrade(10, false), testUpgrade(20, true)},
+	}
+	require.NotContains(t, config.AvalancheRules(common.Big0, big.NewInt(9)).Precompiles, testPrecompileAddr)
+	require.Contains(t, config.AvalancheRules(common.Big0, big.NewInt(10)).Precompiles, testPrecompileAddr)
+	require.NotContains(t, config.AvalancheRules(common.Big0, big.NewInt(20)).Precompiles, testPrecompileAddr)
+}
+
+func TestCheckPrecompilesCompatible(t *testing.T) {
+	stored := &ChainConfig{PrecompileUpgrades: []PrecompileUpgrade{testUpgrade(10, false)}}
+	changed := &ChainConfig{PrecompileUpgrades: []PrecompileUpgrade{testUpgrade(15, false)}}
+	extended := &ChainConfig{PrecompileUpgrades: []PrecompileUpgrade{testUpgrade(10, false), testUpgrade(20, true)}}
+
+	require.Nil(t, stored.checkPrecompilesCompatible(changed, big.NewInt(5)))
+	require.NotNil(t, stored.checkPrecompilesCompatible(changed, big.NewInt(10)))
+	require.Nil(t, stored.checkPrecompilesCompatible(extended, big.NewInt(15)))
+	require.NotNil(t, extended.checkPrecompilesCompatible(stored, big.NewInt(20)))
+}
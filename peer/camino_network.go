@@ -5,7 +5,6 @@ package peer
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/ethereum/go-ethereum/log"
 
@@ -14,9 +13,12 @@ import (
 	"github.com/ava-labs/coreth/plugin/evm/message"
 )
 
+// RequestCrossChain sends [msg] to [chainID]. Without [handler] the message
+// is sent without expecting a response, otherwise [handler] is notified
+// about the response or the failure of the request.
 func (n *network) RequestCrossChain(chainID ids.ID, msg []byte, handler message.ResponseHandler) error {
 	if handler != nil {
-		return fmt.Errorf("ResponseHandler not yet supported")
+		return n.SendCrossChainRequest(chainID, msg, handler)
 	}
 	log.Debug("sending request to chain", "chainID", chainID, "requestLen", len(msg))
 	return n.appSender.SendCrossChainAppRequest(context.TODO(), chainID, 0, msg)
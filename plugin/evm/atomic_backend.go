@@ -55,6 +55,11 @@ type AtomicBackend interface {
 	// will not have been executed on shared memory.
 	MarkApplyToSharedMemoryCursor(previousLastAcceptedHeight uint64) error
 
+	// ApplyToSharedMemoryCursor returns the height from which atomic operations
+	// are still to be applied to shared memory. [pending] is false if all
+	// operations have been applied.
+	ApplyToSharedMemoryCursor() (height uint64, pending bool, err error)
+
 	// Syncer creates and returns a new Syncer object that can be used to sync the
 	// state of the atomic trie from peers
 	Syncer(client syncclient.LeafClient, targetRoot common.Hash, targetHeight uint64) (Syncer, error)
@@ -323,6 +328,22 @@ func (a *atomicBackend) MarkApplyToSharedMemoryCursor(previousLastAcceptedHeight
 	return database.PutUInt64(a.metadataDB, appliedSharedMemoryCursorKey, previousLastAcceptedHeight+1)
 }
 
+// ApplyToSharedMemoryCursor returns the height from which atomic operations
+// are still to be applied to shared memory. [pending] is false if all
+// operations have been applied.
+func (a *atomicBackend) ApplyToSharedMemoryCursor() (uint64, bool, error) {
+	sharedMemoryCursor, err := a.metadataDB.Get(appliedSharedMemoryCursorKey)
+	switch {
+	case err == database.ErrNotFound:
+		return 0, false, nil
+	case err != nil:
+		return 0, false, err
+	case len(sharedMemoryCursor) < wrappers.LongLen:
+		return 0, false, fmt.Errorf("invalid shared memory cursor length %d", len(sharedMemoryCursor))
+	}
+	return binary.BigEndian.Uint64(sharedMemoryCursor[:wrappers.LongLen]), true, nil
+}
+
 // Syncer creates and returns a new Syncer object that can be used to sync the
 // state of the atomic trie from peers
 func (a *atomicBackend) Syncer(client syncclient.LeafClient, targetRoot common.Hash, targetHeight uint64) (Syncer, error) {
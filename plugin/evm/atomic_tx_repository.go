@@ -13,11 +13,13 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
@@ -45,6 +47,9 @@ type AtomicTxRepository interface {
 
 	IterateByHeight(start uint64) database.Iterator
 	Codec() codec.Manager
+
+	GetFeeRewards(startHeight, endHeight uint64, limit int) ([]*FeeReward, error)
+	GetByAddress(addr ids.ShortID, startHeight uint64, startTxID ids.ID, limit int) ([]*Tx, []uint64, error)
 }
 
 // atomicTxRepository is a prefixdb implementation of the AtomicTxRepository interface
@@ -55,15 +60,26 @@ type atomicTxRepository struct {
 	// [acceptedAtomicTxByHeightDB] maintains an index of [height] => [atomic txs] for all accepted block heights.
 	acceptedAtomicTxByHeightDB database.Database
 
+	// [acceptedAtomicTxByAddressDB] maintains an index of [address]+[height]+[txID] => nil for
+	// all addresses touched by accepted atomic txs.
+	acceptedAtomicTxByAddressDB database.Database
+
 	// [atomicRepoMetadataDB] maintains a single key-value pair which tracks the height up to which the atomic repository
 	// has indexed.
 	atomicRepoMetadataDB database.Database
 
+	// [feeRewardDB] maintains an index of [height]+[txID] => [FeeReward] for all accepted
+	// UnsignedCollectRewardsTxs.
+	feeRewardDB database.Database
+
 	// [db] is used to commit to the underlying versiondb.
 	db *versiondb.Database
 
 	// Use this codec for serializing
 	codec codec.Manager
+
+	// [secpFactory] recovers the owners of the UTXOs consumed by import txs
+	secpFactory secp256k1.Factory
 }
 
 func NewAtomicTxRepository(
@@ -72,15 +88,28 @@ func NewAtomicTxRepository(
 	getAtomicTxFromBlockByHeight func(height uint64) (*Tx, error),
 ) (*atomicTxRepository, error) {
 	repo := &atomicTxRepository{
-		acceptedAtomicTxDB:         prefixdb.New(atomicTxIDDBPrefix, db),
-		acceptedAtomicTxByHeightDB: prefixdb.New(atomicHeightTxDBPrefix, db),
-		atomicRepoMetadataDB:       prefixdb.New(atomicRepoMetadataDBPrefix, db),
-		codec:                      codec,
-		db:                         db,
+		acceptedAtomicTxDB:          prefixdb.New(atomicTxIDDBPrefix, db),
+		acceptedAtomicTxByHeightDB:  prefixdb.New(atomicHeightTxDBPrefix, db),
+		acceptedAtomicTxByAddressDB: prefixdb.New(atomicAddressTxDBPrefix, db),
+		atomicRepoMetadataDB:        prefixdb.New(atomicRepoMetadataDBPrefix, db),
+		feeRewardDB:                 prefixdb.New(feeRewardDBPrefix, db),
+		codec:                       codec,
+		db:                          db,
+		secpFactory: secp256k1.Factory{
+			Cache: cache.LRU[ids.ID, *secp256k1.PublicKey]{
+				Size: secpFactoryCacheSize,
+			},
+		},
 	}
 	if err := repo.initializeHeightIndex(lastAcceptedHeight); err != nil {
 		return nil, err
 	}
+	if err := repo.initializeFeeRewardIndex(); err != nil {
+		return nil, err
+	}
+	if err := repo.initializeAddressIndex(); err != nil {
+		return nil, err
+	}
 
 	// TODO: remove post banff as all network participants will have applied the repair script.
 	repairHeights := getAtomicRepositoryRepairHeights(bonusBlocks, canonicalBlocks)
@@ -295,10 +324,16 @@ func (a *atomicTxRepository) write(height uint64, txs []*Tx, bonus bool) error {
 			if err := a.indexTxByID(heightBytes, tx); err != nil {
 				return err
 			}
+			if err := a.indexTxByAddresses(heightBytes, tx); err != nil {
+				return err
+			}
 		}
 		if err := a.indexTxsAtHeight(heightBytes, txs); err != nil {
 			return err
 		}
+		if err := a.indexFeeRewards(heightBytes, txs); err != nil {
+			return err
+		}
 	}
 
 	// Update the index height regardless of if any atomic transactions
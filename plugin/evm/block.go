@@ -204,6 +204,10 @@ func (b *Block) Accept(context.Context) error {
 		// should never occur since [b] must be verified before calling Accept
 		return err
 	}
+	// Persist the reward notifications of the P-Chain with the block
+	if err := vm.trackRewardNotifications(b); err != nil {
+		return err
+	}
 	commitBatch, err := b.vm.db.CommitBatch()
 	if err != nil {
 		return fmt.Errorf("could not create commit batch processing block[%s]: %w", b.ID(), err)
@@ -11,7 +11,6 @@ import (
 
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 
-	"github.com/ava-labs/coreth/constants"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/params"
 	"github.com/ava-labs/coreth/trie"
@@ -154,9 +153,9 @@ func (v blockValidator) SyntacticVerify(b *Block, rules params.Rules) error {
 	if uncleHash != ethHeader.UncleHash {
 		return fmt.Errorf("invalid uncle hash %v does not match calculated uncle hash %v", ethHeader.UncleHash, uncleHash)
 	}
-	// Coinbase must match the BlackholeAddr on C-Chain
-	if ethHeader.Coinbase != constants.BlackholeAddr {
-		return fmt.Errorf("invalid coinbase %v does not match required blackhole address %v", ethHeader.Coinbase, constants.BlackholeAddr)
+	// Coinbase must match the configured blackhole address on C-Chain
+	if blackholeAddr := rules.SystemContracts.BlackholeAddress; ethHeader.Coinbase != blackholeAddr {
+		return fmt.Errorf("invalid coinbase %v does not match required blackhole address %v", ethHeader.Coinbase, blackholeAddr)
 	}
 	// Block must not have any uncles
 	if len(b.ethBlock.Uncles()) > 0 {
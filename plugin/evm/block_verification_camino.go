@@ -8,7 +8,6 @@ import (
 	"math/big"
 
 	safemath "github.com/ava-labs/avalanchego/utils/math"
-	"github.com/ava-labs/coreth/constants"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/params"
 	"github.com/ava-labs/coreth/trie"
@@ -68,10 +67,15 @@ func (bv blockValidatorCamino) SyntacticVerify(b *Block, rules params.Rules) err
 	if hash := types.CalcExtDataHash(b.ethBlock.ExtData()); ethHeader.ExtDataHash != hash {
 		return fmt.Errorf("extra data hash mismatch: have %x, want %x", ethHeader.ExtDataHash, hash)
 	}
-	if headerExtraDataSize := len(ethHeader.Extra); headerExtraDataSize != params.SunrisePhase0ExtraDataSize {
+	// Sunrise Phase 1 encodes the rollup window of the dynamic base fee again
+	expectedExtraDataSize := params.SunrisePhase0ExtraDataSize
+	if rules.IsSunrisePhase1 {
+		expectedExtraDataSize = int(params.ApricotPhase3ExtraDataSize)
+	}
+	if headerExtraDataSize := len(ethHeader.Extra); headerExtraDataSize != expectedExtraDataSize {
 		return fmt.Errorf(
 			"expected header ExtraData to be %d but got %d",
-			params.SunrisePhase0ExtraDataSize, headerExtraDataSize,
+			expectedExtraDataSize, headerExtraDataSize,
 		)
 	}
 	if ethHeader.BaseFee == nil {
@@ -94,9 +98,9 @@ func (bv blockValidatorCamino) SyntacticVerify(b *Block, rules params.Rules) err
 	if uncleHash != ethHeader.UncleHash {
 		return fmt.Errorf("invalid uncle hash %v does not match calculated uncle hash %v", ethHeader.UncleHash, uncleHash)
 	}
-	// Coinbase must be zero on C-Chain
-	if b.ethBlock.Coinbase() != constants.BlackholeAddr {
-		return fmt.Errorf("invalid coinbase %v does not match required blackhole address %v", ethHeader.Coinbase, constants.BlackholeAddr)
+	// Coinbase must match the configured blackhole address on C-Chain
+	if blackholeAddr := rules.SystemContracts.BlackholeAddress; b.ethBlock.Coinbase() != blackholeAddr {
+		return fmt.Errorf("invalid coinbase %v does not match required blackhole address %v", ethHeader.Coinbase, blackholeAddr)
 	}
 	// Block must not have any uncles
 	if len(b.ethBlock.Uncles()) > 0 {
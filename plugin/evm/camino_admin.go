@@ -0,0 +1,43 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ava-labs/coreth/core/state/pruner"
+)
+
+// StartStatePruning starts deleting the stale state in the background while
+// the node keeps running, or resumes the interrupted session
+func (p *Admin) StartStatePruning(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
+	log.Info("Admin: StartStatePruning called")
+
+	return p.vm.blockChain.StartStatePruning()
+}
+
+// StopStatePruning interrupts the running state pruning, which is resumed on
+// the next start
+func (p *Admin) StopStatePruning(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
+	log.Info("Admin: StopStatePruning called")
+
+	return p.vm.blockChain.StopStatePruning()
+}
+
+type StatePruningStatusReply struct {
+	pruner.OnlinePruningStatus
+}
+
+// StatePruningStatus returns the progress of the current or last state pruning
+func (p *Admin) StatePruningStatus(_ *http.Request, _ *struct{}, reply *StatePruningStatusReply) error {
+	status, err := p.vm.blockChain.StatePruningStatus()
+	if err != nil {
+		return err
+	}
+	reply.OnlinePruningStatus = status
+	return nil
+}
@@ -0,0 +1,221 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const addressIndexKeyLen = common.AddressLength + wrappers.LongLen + common.HashLength
+
+var (
+	atomicAddressTxDBPrefix = []byte("atomicAddressTxDB")
+	// [addressIndexProgressKey] holds the last txID indexed while the
+	// address index is initialized, [addressIndexedKey] marks its completion
+	addressIndexProgressKey = []byte("atomicAddressIndexProgress")
+	addressIndexedKey       = []byte("atomicAddressIndexed")
+)
+
+// atomicTxAddresses returns the addresses touched by [tx]: the EVM addresses
+// of the EVMInputs and EVMOutputs, the owners of the exported UTXOs and the
+// owners of the imported UTXOs. The imported UTXOs may already be removed from
+// shared memory, so their owners are recovered from the signatures of [tx]. The
+// owners signing for a multisig alias are indexed in place of the alias.
+func atomicTxAddresses(tx *Tx, secpFactory *secp256k1.Factory) (set.Set[ids.ShortID], error) {
+	addrs := set.Set[ids.ShortID]{}
+	switch utx := tx.UnsignedAtomicTx.(type) {
+	case *UnsignedImportTx:
+		for _, out := range utx.Outs {
+			addrs.Add(ids.ShortID(out.Address))
+		}
+		for _, cred := range tx.Creds {
+			cred, ok := cred.(*secp256k1fx.Credential)
+			if !ok {
+				continue
+			}
+			for _, sig := range cred.Sigs {
+				pubKey, err := secpFactory.RecoverPublicKey(utx.Bytes(), sig[:])
+				if err != nil {
+					return nil, err
+				}
+				addrs.Add(pubKey.Address())
+			}
+		}
+	case *UnsignedExportTx:
+		exportTxAddresses(utx, addrs)
+	case *UnsignedCollectRewardsTx:
+		exportTxAddresses(&utx.UnsignedExportTx, addrs)
+	}
+	return addrs, nil
+}
+
+func exportTxAddresses(utx *UnsignedExportTx, addrs set.Set[ids.ShortID]) {
+	for _, in := range utx.Ins {
+		addrs.Add(ids.ShortID(in.Address))
+	}
+	for _, out := range utx.ExportedOutputs {
+		addressable, ok := out.Out.(avax.Addressable)
+		if !ok {
+			continue
+		}
+		for _, addr := range addressable.Addresses() {
+			if shortID, err := ids.ToShortID(addr); err == nil {
+				addrs.Add(shortID)
+			}
+		}
+	}
+}
+
+// addressIndexKey returns the key [addr]+[height]+[txID] of the address index
+func addressIndexKey(addr ids.ShortID, heightBytes []byte, txID ids.ID) []byte {
+	key := make([]byte, 0, addressIndexKeyLen)
+	key = append(key, addr[:]...)
+	key = append(key, heightBytes...)
+	return append(key, txID[:]...)
+}
+
+// indexTxByAddresses adds [tx] accepted at [heightBytes] to the
+// [acceptedAtomicTxByAddressDB] for each address it touches
+func (a *atomicTxRepository) indexTxByAddresses(heightBytes []byte, tx *Tx) error {
+	addrs, err := atomicTxAddresses(tx, &a.secpFactory)
+	if err != nil {
+		return fmt.Errorf("failed to recover the addresses of tx %s: %w", tx.ID(), err)
+	}
+	txID := tx.ID()
+	for addr := range addrs {
+		if err := a.acceptedAtomicTxByAddressDB.Put(addressIndexKey(addr, heightBytes, txID), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// initializeAddressIndex indexes the atomic txs which were accepted before the
+// address index existed. Like initializeHeightIndex, it iterates the txID index
+// so that every tx is indexed at its canonical height, and it commits its
+// progress periodically to resume from it after a restart.
+func (a *atomicTxRepository) initializeAddressIndex() error {
+	done, err := a.atomicRepoMetadataDB.Has(addressIndexedKey)
+	if err != nil || done {
+		return err
+	}
+
+	var lastTxID ids.ID
+	switch lastTxIDBytes, err := a.atomicRepoMetadataDB.Get(addressIndexProgressKey); {
+	case err == nil:
+		if lastTxID, err = ids.ToID(lastTxIDBytes); err != nil {
+			return err
+		}
+		log.Info("Initializing atomic tx address index from txID", "lastTxID", lastTxID)
+	case err == database.ErrNotFound:
+		log.Info("Initializing atomic tx address index from scratch")
+	default:
+		return err
+	}
+
+	startTime := time.Now()
+	lastLogTime := startTime
+	iter := a.acceptedAtomicTxDB.NewIteratorWithStart(lastTxID[:])
+	defer iter.Release()
+
+	indexedTxs := 0
+	pendingBytesApproximation := 0
+	for iter.Next() {
+		// iter.Value() consists of [height packed as uint64] + [tx serialized as packed []byte]
+		iterValue := iter.Value()
+		if len(iterValue) < wrappers.LongLen+wrappers.IntLen {
+			return fmt.Errorf("atomic tx DB iterator value had invalid length (%d) < (%d)", len(iterValue), wrappers.LongLen+wrappers.IntLen)
+		}
+		txBytes := iterValue[wrappers.LongLen+wrappers.IntLen:]
+		tx, err := ExtractAtomicTx(txBytes, a.codec)
+		if err != nil {
+			return err
+		}
+		if err := a.indexTxByAddresses(iterValue[:wrappers.LongLen], tx); err != nil {
+			return err
+		}
+		lastTxID = tx.ID()
+		pendingBytesApproximation += len(txBytes)
+
+		if pendingBytesApproximation > repoCommitSizeCap {
+			if err := a.atomicRepoMetadataDB.Put(addressIndexProgressKey, lastTxID[:]); err != nil {
+				return err
+			}
+			if err := a.db.Commit(); err != nil {
+				return err
+			}
+			log.Info("Committing work initializing the atomic tx address index", "lastTxID", lastTxID, "pendingBytesApprox", pendingBytesApproximation)
+			pendingBytesApproximation = 0
+		}
+		indexedTxs++
+		if time.Since(lastLogTime) > 15*time.Second {
+			lastLogTime = time.Now()
+			log.Info("Atomic tx address index initialization", "indexedTxs", indexedTxs)
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("atomic tx DB iterator errored while initializing address index: %w", err)
+	}
+	if err := a.atomicRepoMetadataDB.Delete(addressIndexProgressKey); err != nil {
+		return err
+	}
+	if err := a.atomicRepoMetadataDB.Put(addressIndexedKey, nil); err != nil {
+		return err
+	}
+
+	log.Info("Completed atomic tx address index initialization", "indexedTxs", indexedTxs, "duration", time.Since(startTime))
+	return a.db.Commit()
+}
+
+// GetByAddress returns up to [limit] accepted atomic txs touching [addr] with
+// the heights they were accepted at, ordered by height and txID. Only txs
+// after [startHeight]+[startTxID] are returned, so the last tx returned can
+// be used to fetch the next page.
+func (a *atomicTxRepository) GetByAddress(addr ids.ShortID, startHeight uint64, startTxID ids.ID, limit int) ([]*Tx, []uint64, error) {
+	startHeightBytes := make([]byte, wrappers.LongLen)
+	binary.BigEndian.PutUint64(startHeightBytes, startHeight)
+	startKey := addressIndexKey(addr, startHeightBytes, startTxID)
+
+	iter := a.acceptedAtomicTxByAddressDB.NewIteratorWithStartAndPrefix(startKey, addr[:])
+	defer iter.Release()
+
+	var (
+		txs     []*Tx
+		heights []uint64
+	)
+	for len(txs) < limit && iter.Next() {
+		key := iter.Key()
+		if len(key) != addressIndexKeyLen {
+			return nil, nil, fmt.Errorf("atomic tx address DB key has invalid length %d", len(key))
+		}
+		if bytes.Equal(key, startKey) {
+			continue
+		}
+		txID, err := ids.ToID(key[common.AddressLength+wrappers.LongLen:])
+		if err != nil {
+			return nil, nil, err
+		}
+		tx, _, err := a.GetByTxID(txID)
+		if err != nil {
+			return nil, nil, err
+		}
+		txs = append(txs, tx)
+		heights = append(heights, binary.BigEndian.Uint64(key[common.AddressLength:]))
+	}
+	return txs, heights, iter.Error()
+}
@@ -0,0 +1,170 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAddressImportTx(t *testing.T, to common.Address, amount uint64) *Tx {
+	tx := &Tx{UnsignedAtomicTx: &UnsignedImportTx{
+		Outs: []EVMOutput{{Address: to, Amount: amount}},
+	}}
+	require.NoError(t, tx.Sign(Codec, nil))
+	return tx
+}
+
+func newTestAddressExportTx(t *testing.T, from common.Address, to ids.ShortID, amount uint64) *Tx {
+	tx := &Tx{UnsignedAtomicTx: &UnsignedExportTx{
+		Ins: []EVMInput{{Address: from, Amount: amount}},
+		ExportedOutputs: []*avax.TransferableOutput{{
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amount,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{to},
+				},
+			},
+		}},
+	}}
+	require.NoError(t, tx.Sign(Codec, nil))
+	return tx
+}
+
+func TestAtomicTxAddressIndex(t *testing.T) {
+	require := require.New(t)
+
+	db := versiondb.New(memdb.New())
+	repo, err := NewAtomicTxRepository(db, Codec, 0, nil, nil, nil)
+	require.NoError(err)
+
+	evmAddr := common.Address{1}
+	otherEVMAddr := common.Address{2}
+	owner := ids.ShortID{3}
+
+	importTx := newTestAddressImportTx(t, evmAddr, 1_000)
+	exportTx := newTestAddressExportTx(t, evmAddr, owner, 500)
+	otherTx := newTestAddressImportTx(t, otherEVMAddr, 1_000)
+	require.NoError(repo.Write(10, []*Tx{importTx, otherTx}))
+	require.NoError(repo.Write(20, []*Tx{exportTx}))
+
+	txs, heights, err := repo.GetByAddress(ids.ShortID(evmAddr), 0, ids.Empty, 10)
+	require.NoError(err)
+	require.Equal([]uint64{10, 20}, heights)
+	require.Equal(importTx.ID(), txs[0].ID())
+	require.Equal(exportTx.ID(), txs[1].ID())
+
+	txs, heights, err = repo.GetByAddress(owner, 0, ids.Empty, 10)
+	require.NoError(err)
+	require.Equal([]uint64{20}, heights)
+	require.Equal(exportTx.ID(), txs[0].ID())
+
+	txs, _, err = repo.GetByAddress(ids.ShortID{4}, 0, ids.Empty, 10)
+	require.NoError(err)
+	require.Empty(txs)
+
+	// Pages continue after the last tx returned
+	txs, heights, err = repo.GetByAddress(ids.ShortID(evmAddr), 0, ids.Empty, 1)
+	require.NoError(err)
+	require.Len(txs, 1)
+	txs, heights, err = repo.GetByAddress(ids.ShortID(evmAddr), heights[0], txs[0].ID(), 1)
+	require.NoError(err)
+	require.Equal([]uint64{20}, heights)
+	require.Equal(exportTx.ID(), txs[0].ID())
+	txs, _, err = repo.GetByAddress(ids.ShortID(evmAddr), heights[0], txs[0].ID(), 1)
+	require.NoError(err)
+	require.Empty(txs)
+}
+
+func TestAtomicTxAddressIndexInitialization(t *testing.T) {
+	require := require.New(t)
+
+	db := versiondb.New(memdb.New())
+	repo, err := NewAtomicTxRepository(db, Codec, 0, nil, nil, nil)
+	require.NoError(err)
+
+	// Simulate txs accepted before the address index existed
+	evmAddr := common.Address{1}
+	txs := make([]*Tx, 5)
+	for i := range txs {
+		txs[i] = newTestAddressImportTx(t, evmAddr, uint64(i+1))
+		require.NoError(repo.Write(uint64(i+1), []*Tx{txs[i]}))
+	}
+	iter := repo.acceptedAtomicTxByAddressDB.NewIterator()
+	for iter.Next() {
+		require.NoError(repo.acceptedAtomicTxByAddressDB.Delete(iter.Key()))
+	}
+	iter.Release()
+	require.NoError(repo.atomicRepoMetadataDB.Delete(addressIndexedKey))
+	// and an initialization interrupted after the tx with the lowest ID
+	lowestTxID := txs[0].ID()
+	for _, tx := range txs[1:] {
+		if txID := tx.ID(); txID.Hex() < lowestTxID.Hex() {
+			lowestTxID = txID
+		}
+	}
+	require.NoError(repo.atomicRepoMetadataDB.Put(addressIndexProgressKey, lowestTxID[:]))
+	require.NoError(db.Commit())
+
+	repo, err = NewAtomicTxRepository(db, Codec, 5, nil, nil, nil)
+	require.NoError(err)
+	indexedTxs, heights, err := repo.GetByAddress(ids.ShortID(evmAddr), 0, ids.Empty, 10)
+	require.NoError(err)
+	require.Equal([]uint64{1, 2, 3, 4, 5}, heights)
+	for i, tx := range indexedTxs {
+		require.Equal(txs[i].ID(), tx.ID())
+	}
+	has, err := repo.atomicRepoMetadataDB.Has(addressIndexProgressKey)
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestAtomicTxAddressIndexImportOwners(t *testing.T) {
+	require := require.New(t)
+
+	db := versiondb.New(memdb.New())
+	repo, err := NewAtomicTxRepository(db, Codec, 0, nil, nil, nil)
+	require.NoError(err)
+
+	key := testKeys[0]
+	evmAddr := common.Address{1}
+	importTx := &Tx{UnsignedAtomicTx: &UnsignedImportTx{
+		ImportedInputs: []*avax.TransferableInput{{
+			In: &secp256k1fx.TransferInput{
+				Amt:   1_000,
+				Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+			},
+		}},
+		Outs: []EVMOutput{{Address: evmAddr, Amount: 1_000}},
+	}}
+	require.NoError(importTx.Sign(Codec, [][]*secp256k1.PrivateKey{{key}}))
+	require.NoError(repo.Write(10, []*Tx{importTx}))
+
+	// The owner of the consumed UTXO is recovered from the credentials
+	txs, heights, err := repo.GetByAddress(key.PublicKey().Address(), 0, ids.Empty, 10)
+	require.NoError(err)
+	require.Equal([]uint64{10}, heights)
+	require.Equal(importTx.ID(), txs[0].ID())
+
+	// and so is the owner of the txs re-indexed on initialization
+	require.NoError(repo.acceptedAtomicTxByAddressDB.Delete(addressIndexKey(key.PublicKey().Address(), []byte{0, 0, 0, 0, 0, 0, 0, 10}, importTx.ID())))
+	require.NoError(repo.atomicRepoMetadataDB.Delete(addressIndexedKey))
+	require.NoError(db.Commit())
+
+	repo, err = NewAtomicTxRepository(db, Codec, 10, nil, nil, nil)
+	require.NoError(err)
+	txs, _, err = repo.GetByAddress(key.PublicKey().Address(), 0, ids.Empty, 10)
+	require.NoError(err)
+	require.Len(txs, 1)
+	require.Equal(importTx.ID(), txs[0].ID())
+}
@@ -0,0 +1,193 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// AtomicTxInput describes an input of an unsigned atomic tx, which must be
+// signed by each of its signers in order
+type AtomicTxInput struct {
+	// UTXO spent by an import input
+	UTXOID string `json:"utxoID,omitempty"`
+	// Owners of the UTXO spent by an import input
+	Owners *ExportOwners `json:"owners,omitempty"`
+	// Addresses signing the input, bech32 addresses for import inputs and hex
+	// addresses for export inputs
+	Signers []string `json:"signers"`
+}
+
+// spendAtomicUTXO returns the input spending [out] at [now] with the first
+// threshold owners of [out] in [addrs], which sign the input.
+// It matches the owners as [secp256k1fx.Keychain] does, without requiring the
+// keys of the owners.
+func spendAtomicUTXO(addrs set.Set[ids.ShortID], out verify.Verifiable, now uint64) (*secp256k1fx.TransferInput, []ids.ShortID, bool) {
+	transferOut, ok := out.(*secp256k1fx.TransferOutput)
+	if !ok || now < transferOut.Locktime {
+		return nil, nil, false
+	}
+	sigIndices := make([]uint32, 0, transferOut.Threshold)
+	signers := make([]ids.ShortID, 0, transferOut.Threshold)
+	for i := uint32(0); i < uint32(len(transferOut.Addrs)) && uint32(len(signers)) < transferOut.Threshold; i++ {
+		if addr := transferOut.Addrs[i]; addrs.Contains(addr) {
+			sigIndices = append(sigIndices, i)
+			signers = append(signers, addr)
+		}
+	}
+	if uint32(len(signers)) != transferOut.Threshold {
+		return nil, nil, false
+	}
+	return &secp256k1fx.TransferInput{
+		Amt: transferOut.Amt,
+		Input: secp256k1fx.Input{
+			SigIndices: sigIndices,
+		},
+	}, signers, true
+}
+
+// innerSortTransferableInputsWithAddrs implements sort.Interface for
+// TransferableInputs and the addresses signing them
+type innerSortTransferableInputsWithAddrs struct {
+	ins     []*avax.TransferableInput
+	signers [][]ids.ShortID
+}
+
+func (ins *innerSortTransferableInputsWithAddrs) Less(i, j int) bool {
+	iID, iIndex := ins.ins[i].InputSource()
+	jID, jIndex := ins.ins[j].InputSource()
+
+	switch bytes.Compare(iID[:], jID[:]) {
+	case -1:
+		return true
+	case 0:
+		return iIndex < jIndex
+	default:
+		return false
+	}
+}
+
+func (ins *innerSortTransferableInputsWithAddrs) Len() int { return len(ins.ins) }
+
+func (ins *innerSortTransferableInputsWithAddrs) Swap(i, j int) {
+	ins.ins[j], ins.ins[i] = ins.ins[i], ins.ins[j]
+	ins.signers[j], ins.signers[i] = ins.signers[i], ins.signers[j]
+}
+
+// sortTransferableInputsWithAddrs sorts the inputs and the addresses signing
+// them based on the input's utxo ID
+func sortTransferableInputsWithAddrs(ins []*avax.TransferableInput, signers [][]ids.ShortID) {
+	sort.Sort(&innerSortTransferableInputsWithAddrs{ins: ins, signers: signers})
+}
+
+// newUnsignedTx returns [utx] as a tx without credentials
+func (vm *VM) newUnsignedTx(utx UnsignedAtomicTx) (*Tx, error) {
+	tx := &Tx{UnsignedAtomicTx: utx}
+	if err := tx.Sign(vm.codec, nil); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// buildImportTx returns an unsigned ImportTx importing the UTXOs of
+// [chainID] which can be spent by [addrs] to [to], along with the inputs to
+// sign
+func (vm *VM) buildImportTx(
+	chainID ids.ID, // chain to import from
+	to common.Address, // Address of recipient
+	baseFee *big.Int, // fee to use post-AP3
+	addrs set.Set[ids.ShortID], // Addresses owning the imported UTXOs
+) (*Tx, []AtomicTxInput, error) {
+	atomicUTXOs, _, _, err := vm.GetAtomicUTXOs(chainID, addrs, ids.ShortEmpty, ids.Empty, -1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("problem retrieving atomic UTXOs: %w", err)
+	}
+	utx, signers, err := vm.newUnsignedImportTxWithUTXOs(chainID, to, baseFee, addrs, atomicUTXOs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := utx.Verify(vm.ctx, vm.currentRules()); err != nil {
+		return nil, nil, err
+	}
+
+	utxos := make(map[ids.ID]*avax.UTXO, len(atomicUTXOs))
+	for _, utxo := range atomicUTXOs {
+		utxos[utxo.InputID()] = utxo
+	}
+	inputs := make([]AtomicTxInput, len(utx.ImportedInputs))
+	for i, in := range utx.ImportedInputs {
+		// Only TransferOutputs are spent by [newUnsignedImportTxWithUTXOs]
+		out := utxos[in.InputID()].Out.(*secp256k1fx.TransferOutput)
+		owners := &ExportOwners{
+			Locktime:  json.Uint64(out.Locktime),
+			Threshold: json.Uint32(out.Threshold),
+			Addresses: make([]string, len(out.Addrs)),
+		}
+		for j, addr := range out.Addrs {
+			if owners.Addresses[j], err = vm.FormatLocalAddress(addr); err != nil {
+				return nil, nil, err
+			}
+		}
+		inputs[i] = AtomicTxInput{
+			UTXOID:  in.UTXOID.String(),
+			Owners:  owners,
+			Signers: make([]string, len(signers[i])),
+		}
+		for j, addr := range signers[i] {
+			if inputs[i].Signers[j], err = vm.FormatLocalAddress(addr); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	tx, err := vm.newUnsignedTx(utx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, inputs, nil
+}
+
+// buildExportTx returns an unsigned ExportTx exporting [amounts] from [addrs]
+// to [owners], along with the inputs to sign. See newMultiAssetExportTx.
+func (vm *VM) buildExportTx(
+	amounts map[ids.ID]uint64, // Amounts of tokens to export by AssetID
+	chainID ids.ID, // Chain to send the UTXOs to
+	owners secp256k1fx.OutputOwners, // Owners of the exported outputs
+	baseFee *big.Int, // fee to use post-AP3
+	addrs []common.Address, // Pay the fee and provide the tokens
+	selection CoinSelection, // Strategy to select the addresses whose funds are spent
+) (*Tx, []AtomicTxInput, error) {
+	utx, err := vm.newUnsignedMultiAssetExportTx(amounts, chainID, owners, baseFee, addrs, selection)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := utx.Verify(vm.ctx, vm.currentRules()); err != nil {
+		return nil, nil, err
+	}
+
+	inputs := make([]AtomicTxInput, len(utx.Ins))
+	for i, in := range utx.Ins {
+		inputs[i] = AtomicTxInput{
+			Signers: []string{in.Address.Hex()},
+		}
+	}
+
+	tx, err := vm.newUnsignedTx(utx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, inputs, nil
+}
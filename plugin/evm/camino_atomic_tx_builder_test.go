@@ -0,0 +1,137 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// issueBuiltAtomicTx signs the tx of [reply] with [signer], issues it with
+// IssueTx and accepts the block containing it
+func issueBuiltAtomicTx(t *testing.T, issuer chan common.Message, vm *VM, reply *BuildAtomicTxReply, signer AtomicTxSigner) *Tx {
+	require := require.New(t)
+	service := &AvaxAPI{vm}
+
+	txBytes, err := formatting.Decode(reply.Encoding, reply.Tx)
+	require.NoError(err)
+	tx := &Tx{}
+	_, err = Codec.Unmarshal(txBytes, tx)
+	require.NoError(err)
+	require.Empty(tx.Creds)
+	require.NoError(SignAtomicTx(tx, reply.Inputs, signer))
+
+	txStr, err := formatting.Encode(formatting.Hex, tx.SignedBytes())
+	require.NoError(err)
+	txIDReply := &api.JSONTxID{}
+	require.NoError(service.IssueTx(nil, &api.FormattedTx{Tx: txStr, Encoding: formatting.Hex}, txIDReply))
+	require.Equal(tx.ID(), txIDReply.TxID)
+
+	<-issuer
+	blk, err := vm.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(vm.SetPreference(context.Background(), blk.ID()))
+	require.NoError(blk.Accept(context.Background()))
+
+	_, status, _, err := vm.getAtomicTx(tx.ID())
+	require.NoError(err)
+	require.Equal(Accepted, status)
+	return tx
+}
+
+func TestBuildAtomicTxs(t *testing.T) {
+	require := require.New(t)
+
+	importAmount := uint64(50_000_000)
+	issuer, vm, _, _, _ := GenesisVMWithUTXOs(t, true, genesisJSONApricotPhase3, "", "", map[ids.ShortID]uint64{
+		testShortIDAddrs[0]: importAmount,
+	})
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	service := &AvaxAPI{vm}
+	signer := NewLocalAtomicTxSigner(testKeys[0])
+
+	ownerAddr, err := vm.FormatLocalAddress(testShortIDAddrs[0])
+	require.NoError(err)
+	otherAddr, err := vm.FormatLocalAddress(testShortIDAddrs[1])
+	require.NoError(err)
+
+	// Nothing to import for addresses without UTXOs
+	reply := &BuildAtomicTxReply{}
+	err = service.BuildImport(nil, &BuildImportArgs{
+		SourceChain: "X",
+		To:          testEthAddrs[0].Hex(),
+		Addresses:   []string{otherAddr},
+	}, reply)
+	require.ErrorIs(err, errInsufficientFundsForFee)
+
+	require.NoError(service.BuildImport(nil, &BuildImportArgs{
+		SourceChain: "X",
+		To:          testEthAddrs[0].Hex(),
+		Addresses:   []string{ownerAddr, otherAddr},
+	}, reply))
+	require.Len(reply.Inputs, 1)
+	require.NotEmpty(reply.Inputs[0].UTXOID)
+	require.Equal(&ExportOwners{
+		Threshold: 1,
+		Addresses: []string{ownerAddr},
+	}, reply.Inputs[0].Owners)
+	require.Equal([]string{ownerAddr}, reply.Inputs[0].Signers)
+
+	// Signing requires the keys of the signers
+	tx := &Tx{}
+	txBytes, err := formatting.Decode(reply.Encoding, reply.Tx)
+	require.NoError(err)
+	_, err = Codec.Unmarshal(txBytes, tx)
+	require.NoError(err)
+	err = SignAtomicTx(tx, reply.Inputs, NewLocalAtomicTxSigner(testKeys[1]))
+	require.ErrorIs(err, errUnknownAtomicTxSigner)
+	err = SignAtomicTx(tx, nil, signer)
+	require.ErrorIs(err, errAtomicTxInputsMismatch)
+
+	importTx := issueBuiltAtomicTx(t, issuer, vm, reply, signer)
+	require.IsType(&UnsignedImportTx{}, importTx.UnsignedAtomicTx)
+
+	// Export the imported funds to [otherAddr] with a keyless request
+	exportAmount := importAmount / 2
+	xChainAddr, err := vm.FormatAddress(vm.ctx.XChainID, testShortIDAddrs[1])
+	require.NoError(err)
+	require.NoError(service.BuildExport(nil, &BuildExportArgs{
+		Amount:  json.Uint64(exportAmount),
+		AssetID: vm.ctx.AVAXAssetID.String(),
+		To:      xChainAddr,
+		From:    []string{testEthAddrs[0].Hex()},
+	}, reply))
+	require.Equal([]AtomicTxInput{{
+		Signers: []string{testEthAddrs[0].Hex()},
+	}}, reply.Inputs)
+
+	exportTx := issueBuiltAtomicTx(t, issuer, vm, reply, signer)
+	utx, ok := exportTx.UnsignedAtomicTx.(*UnsignedExportTx)
+	require.True(ok)
+	require.Len(utx.ExportedOutputs, 1)
+	require.Equal(secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{testShortIDAddrs[1]},
+	}, utx.ExportedOutputs[0].Out.(*secp256k1fx.TransferOutput).OutputOwners)
+	require.Equal(exportAmount, utx.ExportedOutputs[0].Out.Amount())
+
+	// The addresses paying the export are required
+	err = service.BuildExport(nil, &BuildExportArgs{
+		Amount: json.Uint64(exportAmount),
+		To:     xChainAddr,
+	}, reply)
+	require.ErrorIs(err, errNoAddresses)
+}
@@ -0,0 +1,235 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// errNoActiveAtomicTxJournal is returned if a tx is attempted to be inserted
+// into the journal, but no such file is currently open.
+var errNoActiveAtomicTxJournal = errors.New("no active atomic tx journal")
+
+// devNull is a WriteCloser that just discards anything written into it, so
+// that the txs added while loading the journal are not journaled twice.
+type devNull struct{}
+
+func (*devNull) Write(p []byte) (n int, err error) { return len(p), nil }
+func (*devNull) Close() error                      { return nil }
+
+// atomicTxJournal is a rotating log of the locally issued atomic txs, so that
+// the ones not accepted yet survive node restarts. Like the tx journal of the
+// eth tx pool, it stores the RLP encoded signed bytes of the txs.
+type atomicTxJournal struct {
+	lock sync.Mutex
+
+	path   string         // Filesystem path to store the txs at
+	writer io.WriteCloser // Output stream to write new txs into
+	codec  codec.Manager
+
+	// locals are the txs written into the journal since it was rotated
+	locals map[ids.ID]*Tx
+}
+
+// newAtomicTxJournal creates a new atomic tx journal at [path]
+func newAtomicTxJournal(path string, codec codec.Manager) *atomicTxJournal {
+	return &atomicTxJournal{
+		path:   path,
+		codec:  codec,
+		locals: make(map[ids.ID]*Tx),
+	}
+}
+
+// load parses the journal from disk and passes its txs to [add], which is
+// expected to insert the txs added successfully into the journal again.
+func (journal *atomicTxJournal) load(add func(*Tx) error) error {
+	input, err := os.Open(journal.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		// Skip the parsing if the journal file doesn't exist at all
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	// Temporarily discard any journal additions (don't double add on load)
+	journal.setWriter(new(devNull))
+	defer journal.setWriter(nil)
+
+	stream := rlp.NewStream(input, 0)
+	total, dropped := 0, 0
+	var failure error
+	for {
+		txBytes, err := stream.Bytes()
+		if err != nil {
+			if err != io.EOF {
+				failure = err
+			}
+			break
+		}
+		total++
+
+		tx, err := ExtractAtomicTx(txBytes, journal.codec)
+		if err == nil {
+			err = add(tx)
+		}
+		if err != nil {
+			log.Debug("Failed to add journaled atomic tx", "err", err)
+			dropped++
+		}
+	}
+	log.Info("Loaded local atomic tx journal", "transactions", total, "dropped", dropped)
+
+	return failure
+}
+
+func (journal *atomicTxJournal) setWriter(writer io.WriteCloser) {
+	journal.lock.Lock()
+	defer journal.lock.Unlock()
+
+	journal.writer = writer
+}
+
+// insert adds [tx] to the journal on disk
+func (journal *atomicTxJournal) insert(tx *Tx) error {
+	journal.lock.Lock()
+	defer journal.lock.Unlock()
+
+	// [tx] is kept on the next rotation even if it cannot be written now
+	journal.locals[tx.ID()] = tx
+	if journal.writer == nil {
+		return errNoActiveAtomicTxJournal
+	}
+	return rlp.Encode(journal.writer, tx.SignedBytes())
+}
+
+// rotate regenerates the journal with the txs for which [keep] returns true,
+// that is the txs which are still in the mempool
+func (journal *atomicTxJournal) rotate(keep func(ids.ID) bool) error {
+	journal.lock.Lock()
+	defer journal.lock.Unlock()
+
+	// Close the current journal (if any is open)
+	if journal.writer != nil {
+		if err := journal.writer.Close(); err != nil {
+			return err
+		}
+		journal.writer = nil
+	}
+	// Generate a new journal with the local txs still in the mempool
+	replacement, err := os.OpenFile(journal.path+".new", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	for txID, tx := range journal.locals {
+		if !keep(txID) {
+			delete(journal.locals, txID)
+			continue
+		}
+		if err := rlp.Encode(replacement, tx.SignedBytes()); err != nil {
+			replacement.Close()
+			return err
+		}
+	}
+	replacement.Close()
+
+	// Replace the live journal with the newly generated one
+	if err := os.Rename(journal.path+".new", journal.path); err != nil {
+		return err
+	}
+	sink, err := os.OpenFile(journal.path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	journal.writer = sink
+	log.Info("Regenerated local atomic tx journal", "transactions", len(journal.locals))
+
+	return nil
+}
+
+// close flushes the journal contents to disk and closes the file
+func (journal *atomicTxJournal) close() error {
+	journal.lock.Lock()
+	defer journal.lock.Unlock()
+
+	var err error
+	if journal.writer != nil {
+		err = journal.writer.Close()
+		journal.writer = nil
+	}
+	return err
+}
+
+// atomicTxJournalPath returns the configured journal path, relative paths are
+// resolved in the chain data directory if there is one
+func (vm *VM) atomicTxJournalPath() string {
+	path := vm.config.AtomicTxJournal
+	if path == "" || filepath.IsAbs(path) || vm.ctx.ChainDataDir == "" {
+		return path
+	}
+	return filepath.Join(vm.ctx.ChainDataDir, path)
+}
+
+// startAtomicTxJournal re-issues the journaled atomic txs, which are verified
+// at the preferred block, added to the mempool and gossiped again. Afterwards
+// the journal is regenerated periodically until the VM shuts down.
+func (vm *VM) startAtomicTxJournal() error {
+	if vm.atomicTxJournal == nil {
+		return nil
+	}
+	if err := vm.atomicTxJournal.load(func(tx *Tx) error {
+		return vm.issueTx(tx, true /*=local*/)
+	}); err != nil {
+		log.Warn("Failed to load atomic tx journal", "err", err)
+	}
+	if err := vm.atomicTxJournal.rotate(vm.mempool.has); err != nil {
+		return fmt.Errorf("failed to rotate atomic tx journal: %w", err)
+	}
+
+	vm.shutdownWg.Add(1)
+	go func() {
+		defer vm.shutdownWg.Done()
+
+		ticker := time.NewTicker(vm.config.AtomicTxRejournal.Duration)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := vm.atomicTxJournal.rotate(vm.mempool.has); err != nil {
+					log.Warn("Failed to rotate atomic tx journal", "err", err)
+				}
+			case <-vm.shutdownChan:
+				if err := vm.atomicTxJournal.close(); err != nil {
+					log.Warn("Failed to close atomic tx journal", "err", err)
+				}
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// journalAtomicTx adds the locally issued [tx] to the journal
+func (vm *VM) journalAtomicTx(tx *Tx) {
+	if vm.atomicTxJournal == nil {
+		return
+	}
+	if err := vm.atomicTxJournal.insert(tx); err != nil {
+		log.Warn("Failed to journal local atomic tx", "txID", tx.ID(), "err", err)
+	}
+}
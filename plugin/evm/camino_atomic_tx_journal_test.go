@@ -0,0 +1,93 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtomicTxJournal(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "atomic_transactions.rlp")
+	journal := newAtomicTxJournal(path, Codec)
+
+	// Loading a missing journal is a noop
+	require.NoError(journal.load(func(*Tx) error { return nil }))
+	require.NoError(journal.rotate(func(ids.ID) bool { return true }))
+
+	tx1 := newTestAddressImportTx(t, common.Address{1}, 1)
+	tx2 := newTestAddressImportTx(t, common.Address{1}, 2)
+	tx3 := newTestAddressImportTx(t, common.Address{1}, 3)
+	require.NoError(journal.insert(tx1))
+	require.NoError(journal.insert(tx2))
+	require.NoError(journal.insert(tx3))
+	require.NoError(journal.close())
+
+	// Txs failing to be added are dropped on the next rotation
+	journal = newAtomicTxJournal(path, Codec)
+	loaded := []ids.ID{}
+	require.NoError(journal.load(func(tx *Tx) error {
+		loaded = append(loaded, tx.ID())
+		if tx.ID() == tx2.ID() {
+			return errConflictingAtomicTx
+		}
+		return journal.insert(tx)
+	}))
+	require.Equal([]ids.ID{tx1.ID(), tx2.ID(), tx3.ID()}, loaded)
+	require.ErrorIs(journal.insert(tx2), errNoActiveAtomicTxJournal)
+	delete(journal.locals, tx2.ID())
+
+	// Txs not in the mempool anymore are dropped as well
+	require.NoError(journal.rotate(func(txID ids.ID) bool { return txID != tx3.ID() }))
+	require.NoError(journal.close())
+
+	loaded = loaded[:0]
+	require.NoError(newAtomicTxJournal(path, Codec).load(func(tx *Tx) error {
+		loaded = append(loaded, tx.ID())
+		return nil
+	}))
+	require.Equal([]ids.ID{tx1.ID()}, loaded)
+}
+
+func TestAtomicTxJournalReissue(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "atomic_transactions.rlp")
+	configJSON := fmt.Sprintf(`{"atomic-tx-journal": %q}`, path)
+	_, vm, _, sharedMemory, _ := GenesisVM(t, false, genesisJSONApricotPhase3, configJSON, "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	// Journal a valid import and a tx which cannot be verified
+	tx := createImportTxOptions(t, vm, sharedMemory)[0]
+	invalidTx := newTestAddressImportTx(t, common.Address{1}, 1)
+	journal := newAtomicTxJournal(path, Codec)
+	require.NoError(journal.rotate(func(ids.ID) bool { return true }))
+	require.NoError(journal.insert(tx))
+	require.NoError(journal.insert(invalidTx))
+	require.NoError(journal.close())
+
+	require.NoError(vm.SetState(context.Background(), snow.Bootstrapping))
+	require.NoError(vm.SetState(context.Background(), snow.NormalOp))
+
+	require.True(vm.mempool.has(tx.ID()))
+	require.False(vm.mempool.has(invalidTx.ID()))
+	require.Contains(vm.atomicTxJournal.locals, tx.ID())
+	require.NotContains(vm.atomicTxJournal.locals, invalidTx.ID())
+
+	// Local txs issued afterwards are journaled
+	tx2 := createImportTxOptions(t, vm, sharedMemory)[0]
+	require.NoError(vm.issueTx(tx2, true /*=local*/))
+	require.Contains(vm.atomicTxJournal.locals, tx2.ID())
+}
@@ -0,0 +1,196 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/coreth/accounts"
+	"github.com/ava-labs/coreth/accounts/external"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// MimetypeAtomicTx is the mimetype of the unsigned atomic tx bytes sent to
+// external signers. The signer is expected to sign sha256(data) and to
+// return the signature in the [R || S || V] format. Note that clef rejects it
+// as it only signs its own mimetypes.
+const MimetypeAtomicTx = "application/x-avalanche-atomic-tx"
+
+var (
+	errUnknownAtomicTxSigner  = errors.New("unknown atomic tx signer")
+	errAtomicTxInputsMismatch = errors.New("number of inputs to sign does not match the tx")
+	errInvalidSignature       = errors.New("signature does not match the signer")
+
+	_ AtomicTxSigner = (*localAtomicTxSigner)(nil)
+	_ AtomicTxSigner = (*externalAtomicTxSigner)(nil)
+)
+
+// AtomicTxSigner signs the inputs of atomic txs built with avax.buildImport
+// and avax.buildExport
+type AtomicTxSigner interface {
+	// SignAtomicTx returns the signature of [unsignedBytes] by [signer], which
+	// is either a bech32 or a hex address
+	SignAtomicTx(signer string, unsignedBytes []byte) ([secp256k1.SignatureLen]byte, error)
+}
+
+// SignAtomicTx signs [inputs] of [tx], in order, with [signer] and attaches
+// the credentials to [tx]
+func SignAtomicTx(tx *Tx, inputs []AtomicTxInput, signer AtomicTxSigner) error {
+	unsignedBytes, err := Codec.Marshal(codecVersion, &tx.UnsignedAtomicTx)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal UnsignedAtomicTx: %w", err)
+	}
+
+	var numInputs int
+	switch utx := tx.UnsignedAtomicTx.(type) {
+	case *UnsignedImportTx:
+		numInputs = len(utx.ImportedInputs)
+	case *UnsignedExportTx:
+		numInputs = len(utx.Ins)
+	}
+	if numInputs != len(inputs) {
+		return fmt.Errorf("%w: %d != %d", errAtomicTxInputsMismatch, len(inputs), numInputs)
+	}
+
+	creds := make([]verify.Verifiable, len(inputs))
+	for i, in := range inputs {
+		cred := &secp256k1fx.Credential{
+			Sigs: make([][secp256k1.SignatureLen]byte, len(in.Signers)),
+		}
+		for j, addr := range in.Signers {
+			if cred.Sigs[j], err = signer.SignAtomicTx(addr, unsignedBytes); err != nil {
+				return fmt.Errorf("problem signing input %d with %s: %w", i, addr, err)
+			}
+		}
+		creds[i] = cred
+	}
+	tx.Creds = creds
+
+	signedBytes, err := Codec.Marshal(codecVersion, tx)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal Tx: %w", err)
+	}
+	tx.Initialize(unsignedBytes, signedBytes)
+	return nil
+}
+
+// parseAtomicTxSigner returns the address of [signer], which is either an EVM
+// address or a bech32 address
+func parseAtomicTxSigner(signer string) (common.Address, ids.ShortID, bool, error) {
+	if common.IsHexAddress(signer) {
+		return common.HexToAddress(signer), ids.ShortID{}, true, nil
+	}
+	addr, err := address.ParseToID(signer)
+	if err != nil {
+		return common.Address{}, ids.ShortID{}, false, fmt.Errorf("couldn't parse signer %q: %w", signer, err)
+	}
+	return common.Address{}, addr, false, nil
+}
+
+type localAtomicTxSigner struct {
+	kc      *secp256k1fx.Keychain
+	ethKeys map[common.Address]*secp256k1.PrivateKey
+}
+
+// NewLocalAtomicTxSigner returns an AtomicTxSigner signing with [keys]
+func NewLocalAtomicTxSigner(keys ...*secp256k1.PrivateKey) AtomicTxSigner {
+	signer := &localAtomicTxSigner{
+		kc:      secp256k1fx.NewKeychain(keys...),
+		ethKeys: make(map[common.Address]*secp256k1.PrivateKey, len(keys)),
+	}
+	for _, key := range keys {
+		signer.ethKeys[GetEthAddress(key)] = key
+	}
+	return signer
+}
+
+func (s *localAtomicTxSigner) SignAtomicTx(signer string, unsignedBytes []byte) ([secp256k1.SignatureLen]byte, error) {
+	var sig [secp256k1.SignatureLen]byte
+	ethAddr, addr, isEthAddr, err := parseAtomicTxSigner(signer)
+	if err != nil {
+		return sig, err
+	}
+
+	var key *secp256k1.PrivateKey
+	if isEthAddr {
+		key = s.ethKeys[ethAddr]
+	} else if kcSigner, ok := s.kc.Get(addr); ok {
+		key = kcSigner.(*secp256k1.PrivateKey)
+	}
+	if key == nil {
+		return sig, fmt.Errorf("%w: %s", errUnknownAtomicTxSigner, signer)
+	}
+
+	sigBytes, err := key.SignHash(hashing.ComputeHash256(unsignedBytes))
+	if err != nil {
+		return sig, err
+	}
+	copy(sig[:], sigBytes)
+	return sig, nil
+}
+
+type externalAtomicTxSigner struct {
+	signer   *external.ExternalSigner
+	accounts map[ids.ShortID]common.Address
+}
+
+// NewExternalAtomicTxSigner returns an AtomicTxSigner signing with the
+// external [signer] through its account_signData API, which must support
+// [MimetypeAtomicTx].
+// Bech32 signers are signed with the EVM account of the same key in
+// [accounts].
+func NewExternalAtomicTxSigner(signer *external.ExternalSigner, accounts map[ids.ShortID]common.Address) AtomicTxSigner {
+	return &externalAtomicTxSigner{
+		signer:   signer,
+		accounts: accounts,
+	}
+}
+
+func (s *externalAtomicTxSigner) SignAtomicTx(signer string, unsignedBytes []byte) ([secp256k1.SignatureLen]byte, error) {
+	var sig [secp256k1.SignatureLen]byte
+	ethAddr, addr, isEthAddr, err := parseAtomicTxSigner(signer)
+	if err != nil {
+		return sig, err
+	}
+	if !isEthAddr {
+		account, ok := s.accounts[addr]
+		if !ok {
+			return sig, fmt.Errorf("%w: %s", errUnknownAtomicTxSigner, signer)
+		}
+		ethAddr = account
+	}
+
+	sigBytes, err := s.signer.SignData(accounts.Account{Address: ethAddr}, MimetypeAtomicTx, unsignedBytes)
+	if err != nil {
+		return sig, err
+	}
+	if len(sigBytes) != secp256k1.SignatureLen {
+		return sig, fmt.Errorf("%w: unexpected length %d", errInvalidSignature, len(sigBytes))
+	}
+	if v := sigBytes[secp256k1.SignatureLen-1]; v == 27 || v == 28 {
+		// Transform V from Ethereum-legacy to 0/1
+		sigBytes[secp256k1.SignatureLen-1] -= 27
+	}
+
+	// Make sure that the external signer signed with the expected key, as an
+	// invalid signature would only be detected once the tx is issued
+	factory := secp256k1.Factory{}
+	pubKey, err := factory.RecoverHashPublicKey(hashing.ComputeHash256(unsignedBytes), sigBytes)
+	if err != nil {
+		return sig, fmt.Errorf("%w: %s", errInvalidSignature, err)
+	}
+	if isEthAddr && PublicKeyToEthAddress(pubKey) != ethAddr || !isEthAddr && pubKey.Address() != addr {
+		return sig, fmt.Errorf("%w: %s", errInvalidSignature, signer)
+	}
+	copy(sig[:], sigBytes)
+	return sig, nil
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/coreth/accounts/external"
+	"github.com/ava-labs/coreth/rpc"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// testExternalSigner is an external signer signing atomic txs with its keys.
+// It returns the signatures in the Ethereum-legacy format.
+type testExternalSigner struct {
+	keys map[common.Address]*secp256k1.PrivateKey
+}
+
+func (*testExternalSigner) Version() string {
+	return "6.0.0"
+}
+
+func (c *testExternalSigner) SignData(mimeType string, addr common.MixedcaseAddress, data hexutil.Bytes) (hexutil.Bytes, error) {
+	if mimeType != MimetypeAtomicTx {
+		return nil, errUnknownAtomicTxSigner
+	}
+	key, ok := c.keys[addr.Address()]
+	if !ok {
+		return nil, errUnknownAtomicTxSigner
+	}
+	sig, err := key.SignHash(hashing.ComputeHash256(data))
+	if err != nil {
+		return nil, err
+	}
+	sig[secp256k1.SignatureLen-1] += 27
+	return sig, nil
+}
+
+func TestExternalAtomicTxSigner(t *testing.T) {
+	require := require.New(t)
+
+	server := rpc.NewServer(0)
+	defer server.Stop()
+	require.NoError(server.RegisterName("account", &testExternalSigner{
+		keys: map[common.Address]*secp256k1.PrivateKey{
+			testEthAddrs[0]: testKeys[0],
+			// The key of [testEthAddrs[2]] differs from the account
+			testEthAddrs[2]: testKeys[1],
+		},
+	}))
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	externalSigner, err := external.NewExternalSigner(httpServer.URL)
+	require.NoError(err)
+	signer := NewExternalAtomicTxSigner(externalSigner, map[ids.ShortID]common.Address{
+		testShortIDAddrs[0]: testEthAddrs[0],
+	})
+
+	unsignedBytes := []byte("unsigned atomic tx")
+	hash := hashing.ComputeHash256(unsignedBytes)
+	expectedSig, err := testKeys[0].SignHash(hash)
+	require.NoError(err)
+
+	bech32Addr, err := address.Format("X", "local", testShortIDAddrs[0].Bytes())
+	require.NoError(err)
+	unknownAddr, err := address.Format("X", "local", testShortIDAddrs[1].Bytes())
+	require.NoError(err)
+
+	tests := map[string]struct {
+		signer  string
+		wantErr error
+	}{
+		"hex address": {
+			signer: testEthAddrs[0].Hex(),
+		},
+		"bech32 address": {
+			signer: bech32Addr,
+		},
+		"unknown bech32 address": {
+			signer:  unknownAddr,
+			wantErr: errUnknownAtomicTxSigner,
+		},
+		"wrong key": {
+			signer:  testEthAddrs[2].Hex(),
+			wantErr: errInvalidSignature,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sig, err := signer.SignAtomicTx(tt.signer, unsignedBytes)
+			require.ErrorIs(err, tt.wantErr)
+			if tt.wantErr == nil {
+				require.Equal(expectedSig, sig[:])
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+)
+
+// CoinSelection is the strategy to select the keys whose funds are spent
+type CoinSelection string
+
+const (
+	// CoinSelectionKeyOrder spends the funds in the order of the keys
+	CoinSelectionKeyOrder CoinSelection = ""
+	// CoinSelectionLargestFirst spends the largest balances first, which
+	// minimizes the number of inputs and so the fee
+	CoinSelectionLargestFirst CoinSelection = "largestFirst"
+	// CoinSelectionSmallestFirst spends the smallest balances first, which
+	// consolidates the funds
+	CoinSelectionSmallestFirst CoinSelection = "smallestFirst"
+)
+
+var errUnknownCoinSelection = errors.New("unknown coin selection")
+
+// Verify returns an error if [selection] is not a known strategy
+func (selection CoinSelection) Verify() error {
+	switch selection {
+	case CoinSelectionKeyOrder, CoinSelectionLargestFirst, CoinSelectionSmallestFirst:
+		return nil
+	default:
+		return fmt.Errorf("%w %q", errUnknownCoinSelection, selection)
+	}
+}
+
+// spendableBalance returns the balance of [assetID] owned by [addr], which
+// can be exported
+func (vm *VM) spendableBalance(state *state.StateDB, addr common.Address, assetID ids.ID) uint64 {
+	if assetID == vm.ctx.AVAXAssetID {
+		// If the asset is AVAX, we divide by the x2cRate to convert back to the correct
+		// denomination of AVAX that can be exported.
+		return new(big.Int).Div(state.GetBalance(addr), x2cRate).Uint64()
+	}
+	return state.GetBalanceMultiCoin(addr, common.Hash(assetID)).Uint64()
+}
+
+// selectAddresses returns [addrs] in the order their balances of [assetID] are
+// spent with [selection]
+func (vm *VM) selectAddresses(
+	state *state.StateDB,
+	addrs []common.Address,
+	assetID ids.ID,
+	selection CoinSelection,
+) ([]common.Address, error) {
+	if err := selection.Verify(); err != nil {
+		return nil, err
+	}
+	if selection == CoinSelectionKeyOrder {
+		return addrs, nil
+	}
+
+	balances := make(map[common.Address]uint64, len(addrs))
+	for _, addr := range addrs {
+		balances[addr] = vm.spendableBalance(state, addr, assetID)
+	}
+	selected := make([]common.Address, len(addrs))
+	copy(selected, addrs)
+	// Addresses with equal balances keep their order
+	sort.SliceStable(selected, func(i, j int) bool {
+		if selection == CoinSelectionLargestFirst {
+			return balances[selected[i]] > balances[selected[j]]
+		}
+		return balances[selected[i]] < balances[selected[j]]
+	})
+	return selected, nil
+}
+
+// ethAddresses returns the EVM addresses of [keys]
+func ethAddresses(keys []*secp256k1.PrivateKey) []common.Address {
+	addrs := make([]common.Address, len(keys))
+	for i, key := range keys {
+		addrs[i] = GetEthAddress(key)
+	}
+	return addrs
+}
+
+// evmInputSigners returns the key of [keys] signing each of [inputs]
+func evmInputSigners(inputs []EVMInput, keys []*secp256k1.PrivateKey) [][]*secp256k1.PrivateKey {
+	keysByAddr := make(map[common.Address]*secp256k1.PrivateKey, len(keys))
+	for _, key := range keys {
+		keysByAddr[GetEthAddress(key)] = key
+	}
+	signers := make([][]*secp256k1.PrivateKey, len(inputs))
+	for i, in := range inputs {
+		signers[i] = []*secp256k1.PrivateKey{keysByAddr[in.Address]}
+	}
+	return signers
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestSelectAddresses(t *testing.T) {
+	require := require.New(t)
+
+	_, vm, _, _, _ := GenesisVM(t, true, genesisJSONApricotPhase3, "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	state, err := vm.blockChain.State()
+	require.NoError(err)
+	assetID := ids.ID{1}
+	addrs := []common.Address{testEthAddrs[0], testEthAddrs[1], testEthAddrs[2]}
+	for i, balance := range []int64{20, 30, 10} {
+		addr := addrs[i]
+		state.AddBalance(addr, new(big.Int).Mul(big.NewInt(balance), x2cRate))
+		state.AddBalanceMultiCoin(addr, common.Hash(assetID), big.NewInt(40-balance))
+	}
+
+	tests := map[string]struct {
+		assetID   ids.ID
+		selection CoinSelection
+		want      []common.Address
+		wantErr   error
+	}{
+		"key order": {
+			assetID:   vm.ctx.AVAXAssetID,
+			selection: CoinSelectionKeyOrder,
+			want:      addrs,
+		},
+		"largest first": {
+			assetID:   vm.ctx.AVAXAssetID,
+			selection: CoinSelectionLargestFirst,
+			want:      []common.Address{addrs[1], addrs[0], addrs[2]},
+		},
+		"smallest first": {
+			assetID:   vm.ctx.AVAXAssetID,
+			selection: CoinSelectionSmallestFirst,
+			want:      []common.Address{addrs[2], addrs[0], addrs[1]},
+		},
+		"largest first multicoin": {
+			assetID:   assetID,
+			selection: CoinSelectionLargestFirst,
+			want:      []common.Address{addrs[2], addrs[0], addrs[1]},
+		},
+		"unknown": {
+			assetID:   vm.ctx.AVAXAssetID,
+			selection: "random",
+			wantErr:   errUnknownCoinSelection,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			selected, err := vm.selectAddresses(state, addrs, tt.assetID, tt.selection)
+			require.ErrorIs(err, tt.wantErr)
+			require.Equal(tt.want, selected)
+		})
+	}
+}
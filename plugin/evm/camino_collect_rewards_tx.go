@@ -14,33 +14,17 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
-	"github.com/ava-labs/avalanchego/vms/components/message"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
-	gconstants "github.com/ava-labs/coreth/constants"
 	"github.com/ava-labs/coreth/core/state"
 	"github.com/ava-labs/coreth/params"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
-const (
-	FeeRewardAddressStr = "0x010000000000000000000000000000000000000c"
-)
-
 var (
 	_ UnsignedAtomicTx       = &UnsignedCollectRewardsTx{}
 	_ secp256k1fx.UnsignedTx = &UnsignedCollectRewardsTx{}
 
-	FeeRewardAddress      = common.HexToAddress(FeeRewardAddressStr)
-	FeeRewardAddressID, _ = ids.ToShortID(FeeRewardAddress.Bytes())
-
-	BalanceSlot   = common.Hash{0x01}
-	TimestampSlot = common.Hash{0x02}
-
-	ExportRewardRate        = new(big.Int).SetUint64(300_000)
-	IncentivePoolRewardRate = new(big.Int).SetUint64(300_000)
-	RateDenominator         = new(big.Int).SetUint64(1_000_000)
-
 	errWrongInputCount                = errors.New("wrong input count")
 	errWrongExportCount               = errors.New("wrong ExportedOuts count")
 	errExportLimit                    = errors.New("export limit not yet reached")
@@ -51,6 +35,7 @@ var (
 	errInvalidBlockTime               = errors.New("invalid block time")
 	errInvalidNextEarliestCollectTime = errors.New("invalid next earliest collect time")
 	errRewardAmountMismatch           = errors.New("calculated reward amount mismatch")
+	errRewardRateMismatch             = errors.New("export / incentive rate mismatch")
 )
 
 type UnsignedCollectRewardsTx struct {
@@ -96,13 +81,16 @@ func (ucx *UnsignedCollectRewardsTx) SemanticVerify(
 		return errAssetIDMismatch
 	}
 
-	// Verify sender of the rewards
-	if ucx.Ins[0].Address != gconstants.BlackholeAddr {
+	contracts := rules.SystemContracts
+
+	// Verify sender of the rewards. The blackhole is spent without credentials,
+	// so unlike export txs no multisig aliases are resolved here.
+	if ucx.Ins[0].Address != contracts.BlackholeAddress {
 		return errInvalidInputAddress
 	}
 
 	// Verify receiver of the outputs
-	if len(output.OutputOwners.Addrs) != 1 || output.OutputOwners.Addrs[0] != FeeRewardAddressID {
+	if len(output.OutputOwners.Addrs) != 1 || output.OutputOwners.Addrs[0] != ids.ShortID(contracts.FeeRewardAddress) {
 		return errInvalidOutputOwner
 	}
 
@@ -110,18 +98,19 @@ func (ucx *UnsignedCollectRewardsTx) SemanticVerify(
 		return errInOutAmountMismatch
 	}
 
-	// Verify Rates
-	if ucx.ExportRate != ExportRewardRate.Uint64() ||
-		ucx.IncentiveRate != IncentivePoolRewardRate.Uint64() {
-		return fmt.Errorf("export / incentive rate mismatch")
-	}
-
 	// Get block header
 	head := vm.blockChain.GetHeaderByHash(ucx.BlockHash)
 	if head == nil {
 		return fmt.Errorf("cannot get header of tx BlockHash %s", ucx.BlockHash.Hex())
 	}
 
+	// Verify Rates against the split active at the block the rewards are collected from
+	rates := vm.chainConfig.FeeRewardRates(new(big.Int).SetUint64(head.Time))
+	if ucx.ExportRate != rates.ExportRate ||
+		ucx.IncentiveRate != rates.IncentivePoolRate {
+		return errRewardRateMismatch
+	}
+
 	headTime := modTime(vm, head.Time)
 	if headTime != modTime(vm, ucx.BlockTime) {
 		return errInvalidBlockTime
@@ -138,12 +127,12 @@ func (ucx *UnsignedCollectRewardsTx) SemanticVerify(
 		return fmt.Errorf("cannot get state at head root: %s", head.Root.Hex())
 	}
 
-	triggerTime := state.GetState(gconstants.BlackholeAddr, TimestampSlot).Big().Uint64()
+	triggerTime := state.GetState(contracts.BlackholeAddress, contracts.FeeRewardTimestampSlot).Big().Uint64()
 	if headTime < triggerTime {
 		return errTimeNotPassed
 	}
 
-	balanceAvax, err := getReward(vm, state)
+	balanceAvax, err := getReward(vm, contracts, rates, state)
 	if err != nil {
 		return err
 	}
@@ -163,7 +152,7 @@ func (ucx *UnsignedCollectRewardsTx) SemanticVerify(
 	}
 
 	// If parent trigger time != current trigger time, it was executed before
-	if triggerTime != state.GetState(gconstants.BlackholeAddr, TimestampSlot).Big().Uint64() {
+	if triggerTime != state.GetState(contracts.BlackholeAddress, contracts.FeeRewardTimestampSlot).Big().Uint64() {
 		return nil
 	}
 
@@ -173,7 +162,7 @@ func (ucx *UnsignedCollectRewardsTx) SemanticVerify(
 	}
 
 	// Check if the reward export limit is not reached
-	_, err = getReward(vm, state)
+	_, err = getReward(vm, contracts, vm.chainConfig.FeeRewardRates(new(big.Int).SetUint64(head.Time)), state)
 	if err == nil {
 		// should never happen. We expect the CollectRewardsTx is auto-issued locally at the earliest block where conditions are met
 		return fmt.Errorf("past block would execute")
@@ -198,7 +187,7 @@ func (ucx *UnsignedCollectRewardsTx) AtomicOps() (ids.ID, *atomic.Requests, erro
 
 	// Only export a part of new amount burned
 	newOut := &secp256k1fx.TransferOutput{
-		Amt:          calculateRate(exportOut.Amt, ExportRewardRate),
+		Amt:          calculateRate(exportOut.Amt, ucx.ExportRate),
 		OutputOwners: exportOut.OutputOwners,
 	}
 
@@ -235,7 +224,9 @@ func (vm *VM) NewCollectRewardsTx(
 	amount uint64,
 	blockTime uint64,
 ) (*Tx, error) {
-	nonce, err := vm.GetCurrentNonce(gconstants.BlackholeAddr)
+	contracts := vm.chainConfig.CaminoSystemContracts(new(big.Int).SetUint64(blockTime))
+	rates := vm.chainConfig.FeeRewardRates(new(big.Int).SetUint64(blockTime))
+	nonce, err := vm.GetCurrentNonce(contracts.BlackholeAddress)
 	if err != nil {
 		return nil, err
 	}
@@ -252,7 +243,7 @@ func (vm *VM) NewCollectRewardsTx(
 			BlockchainID:     vm.ctx.ChainID,
 			DestinationChain: constants.PlatformChainID,
 			Ins: []EVMInput{{
-				Address: gconstants.BlackholeAddr,
+				Address: contracts.BlackholeAddress,
 				Amount:  amount,
 				AssetID: vm.ctx.AVAXAssetID,
 				Nonce:   nonce,
@@ -264,7 +255,7 @@ func (vm *VM) NewCollectRewardsTx(
 					OutputOwners: secp256k1fx.OutputOwners{
 						Locktime:  0,
 						Threshold: 1,
-						Addrs:     []ids.ShortID{FeeRewardAddressID},
+						Addrs:     []ids.ShortID{ids.ShortID(contracts.FeeRewardAddress)},
 					},
 				},
 			}},
@@ -272,8 +263,8 @@ func (vm *VM) NewCollectRewardsTx(
 		BlockHash:               hash,
 		BlockTime:               blockTime,
 		NextEarliestCollectTime: nextEarliestCollectTime,
-		ExportRate:              ExportRewardRate.Uint64(),
-		IncentiveRate:           IncentivePoolRewardRate.Uint64(),
+		ExportRate:              rates.ExportRate,
+		IncentiveRate:           rates.IncentivePoolRate,
 	}
 
 	tx := &Tx{UnsignedAtomicTx: utx}
@@ -296,17 +287,8 @@ func (vm *VM) TriggerRewardsTx(block *Block) {
 		return
 	}
 
-	// check if we need to notify p-chain for new aaceppted TX
-	for _, tx := range block.atomicTxs {
-		if _, ok := tx.UnsignedAtomicTx.(*UnsignedCollectRewardsTx); ok {
-			request, err := message.Codec.Marshal(message.CodecVersion, &message.CaminoRewardMessage{})
-			if err != nil {
-				log.Warn("cannot marshall reward message", "error", err)
-			}
-			vm.RequestCrossChain(ids.ID{}, request, nil)
-			break
-		}
-	}
+	// notify p-chain about the rewards collected by the accepted block
+	vm.rewardNotifier.send()
 
 	blockTime := blockTimeBN.Uint64()
 	state, err := vm.blockChain.StateAt(block.ethBlock.Root())
@@ -314,12 +296,13 @@ func (vm *VM) TriggerRewardsTx(block *Block) {
 		return
 	}
 
-	triggerTime := state.GetState(gconstants.BlackholeAddr, TimestampSlot).Big().Uint64()
+	contracts := vm.chainConfig.CaminoSystemContracts(blockTimeBN)
+	triggerTime := state.GetState(contracts.BlackholeAddress, contracts.FeeRewardTimestampSlot).Big().Uint64()
 	if modTime(vm, blockTime) < triggerTime {
 		return
 	}
 
-	amount, err := getReward(vm, state)
+	amount, err := getReward(vm, contracts, vm.chainConfig.FeeRewardRates(blockTimeBN), state)
 	if err != nil {
 		return
 	}
@@ -333,8 +316,37 @@ func (vm *VM) TriggerRewardsTx(block *Block) {
 	vm.issueTx(tx, true /*=local*/)
 }
 
+// startRewardNotifier sends the reward notifications until the VM shuts down
+func (vm *VM) startRewardNotifier() {
+	vm.shutdownWg.Add(1)
+	go func() {
+		defer vm.shutdownWg.Done()
+		vm.rewardNotifier.run(vm.shutdownChan)
+	}()
+}
+
+// trackRewardNotifications persists the notifications of the P-Chain about
+// the rewards collected by [block] and removes the dropped ones. They are sent
+// by TriggerRewardsTx after the block was committed.
+func (vm *VM) trackRewardNotifications(block *Block) error {
+	if err := vm.rewardNotifier.deleteDropped(); err != nil {
+		return fmt.Errorf("failed to delete dropped reward notifications: %w", err)
+	}
+	if !vm.bootstrapped || !vm.chainConfig.IsSunrisePhase0(block.ethBlock.Timestamp()) {
+		return nil
+	}
+	for _, tx := range block.atomicTxs {
+		if _, ok := tx.UnsignedAtomicTx.(*UnsignedCollectRewardsTx); ok {
+			if err := vm.rewardNotifier.add(tx.ID()); err != nil {
+				return fmt.Errorf("failed to persist reward notification of %s: %w", tx.ID(), err)
+			}
+		}
+	}
+	return nil
+}
+
 // EVMStateTransfer executes the state update from the atomic export transaction
-func (ucx *UnsignedCollectRewardsTx) EVMStateTransfer(ctx *snow.Context, state *state.StateDB) error {
+func (ucx *UnsignedCollectRewardsTx) EVMStateTransfer(ctx *snow.Context, state *state.StateDB, rules params.Rules) error {
 	// Check again
 	if len(ucx.Ins) != 1 {
 		return errWrongInputCount
@@ -344,11 +356,17 @@ func (ucx *UnsignedCollectRewardsTx) EVMStateTransfer(ctx *snow.Context, state *
 		return errWrongExportCount
 	}
 
+	rates := params.FeeRewardRates{ExportRate: ucx.ExportRate, IncentivePoolRate: ucx.IncentiveRate}
+	if err := rates.Verify(); err != nil {
+		return err
+	}
+
 	from := ucx.Ins[0]
 
 	// Calculate partitial amounts
-	amountExport := calculateRate(from.Amount, ExportRewardRate)
-	amountIncentive := calculateRate(from.Amount, IncentivePoolRewardRate)
+	// The rates were verified against the chain config in SemanticVerify
+	amountExport := calculateRate(from.Amount, ucx.ExportRate)
+	amountIncentive := calculateRate(from.Amount, ucx.IncentiveRate)
 
 	log.Debug("reward", "amount", from.Amount, "export", amountExport, "incentive", amountIncentive, "assetID", "CAM")
 	// We multiply the input amount by x2cRate to convert AVAX back to the appropriate
@@ -377,31 +395,31 @@ func (ucx *UnsignedCollectRewardsTx) EVMStateTransfer(ctx *snow.Context, state *
 	amountToBurn := new(big.Int).Div(
 		new(big.Int).Mul(
 			new(big.Int).SetUint64(amountExport+amountIncentive),
-			RateDenominator,
+			new(big.Int).SetUint64(params.FeeRewardRateDenominator),
 		),
-		new(big.Int).Add(ExportRewardRate, IncentivePoolRewardRate),
+		new(big.Int).SetUint64(ucx.ExportRate+ucx.IncentiveRate),
 	).Uint64() - (amountExport + amountIncentive)
 	amountToBurnEvm := new(big.Int).Mul(
 		new(big.Int).SetUint64(amountToBurn), x2cRate,
 	)
 
 	// balance - lastPayoutBalance is the amount we can max distribute
-	lastPayoutBalance := state.GetState(ucx.Ins[0].Address, BalanceSlot).Big()
+	lastPayoutBalance := state.GetState(ucx.Ins[0].Address, rules.SystemContracts.FeeRewardBalanceSlot).Big()
 	// This can happen if there was a payout before this TX executes
 	if lastPayoutBalance.Add(lastPayoutBalance, amountToBurnEvm).Cmp(balance) > 0 {
 		return fmt.Errorf("paid out fees exceed balance")
 	}
-	state.SetState(ucx.Ins[0].Address, BalanceSlot, common.BigToHash(lastPayoutBalance))
+	state.SetState(ucx.Ins[0].Address, rules.SystemContracts.FeeRewardBalanceSlot, common.BigToHash(lastPayoutBalance))
 
 	// Add balances to incentive pool smart contract
 	amountIncentiveEVM := new(big.Int).Mul(
 		new(big.Int).SetUint64(amountIncentive), x2cRate,
 	)
-	state.AddBalance(common.Address(FeeRewardAddressID), amountIncentiveEVM)
+	state.AddBalance(rules.SystemContracts.FeeRewardAddress, amountIncentiveEVM)
 
 	// Step up timestamp for the next iteration
 	nextBig := new(big.Int).SetUint64(ucx.NextEarliestCollectTime)
-	state.SetState(from.Address, TimestampSlot, common.BigToHash(nextBig))
+	state.SetState(from.Address, rules.SystemContracts.FeeRewardTimestampSlot, common.BigToHash(nextBig))
 
 	if state.GetNonce(from.Address) != from.Nonce {
 		return errInvalidNonce
@@ -411,10 +429,10 @@ func (ucx *UnsignedCollectRewardsTx) EVMStateTransfer(ctx *snow.Context, state *
 	return nil
 }
 
-func calculateRate(amt uint64, rate *big.Int) uint64 {
+func calculateRate(amt uint64, rate uint64) uint64 {
 	bn := new(big.Int).SetUint64(amt)
-	bn.Mul(bn, rate)
-	bn.Div(bn, RateDenominator)
+	bn.Mul(bn, new(big.Int).SetUint64(rate))
+	bn.Div(bn, new(big.Int).SetUint64(params.FeeRewardRateDenominator))
 	return bn.Uint64()
 }
 
@@ -422,19 +440,24 @@ func modTime(vm *VM, timestamp uint64) uint64 {
 	return timestamp - (timestamp % feeRewardExportMinTimeInterval(vm))
 }
 
-func getReward(vm *VM, state *state.StateDB) (uint64, error) {
-	// balance - lastPayoutBalance is the amount we can max distribute
-	balance := state.GetBalance(gconstants.BlackholeAddr)
-	balance.Sub(balance, state.GetState(gconstants.BlackholeAddr, BalanceSlot).Big())
-	balanceAvax := balance.Div(balance, x2cRate).Uint64()
-
-	if calculateRate(balanceAvax, ExportRewardRate) < feeRewardExportMinAmount(vm) {
+func getReward(vm *VM, contracts *params.SystemContracts, rates params.FeeRewardRates, state *state.StateDB) (uint64, error) {
+	balanceAvax := pendingReward(contracts, state)
+	if calculateRate(balanceAvax, rates.ExportRate) < feeRewardExportMinAmount(vm) {
 		return 0, errExportLimit
 	}
 
 	return balanceAvax, nil
 }
 
+// pendingReward returns the collected fees not paid out yet, in nAVAX
+func pendingReward(contracts *params.SystemContracts, state *state.StateDB) uint64 {
+	// balance - lastPayoutBalance is the amount we can max distribute
+	// GetBalance returns the balance of the state object, don't modify it
+	balance := new(big.Int).Set(state.GetBalance(contracts.BlackholeAddress))
+	balance.Sub(balance, state.GetState(contracts.BlackholeAddress, contracts.FeeRewardBalanceSlot).Big())
+	return balance.Div(balance, x2cRate).Uint64()
+}
+
 func feeRewardExportMinAmount(vm *VM) uint64 {
 	if vm.ethConfig.Genesis.FeeRewardExportMinAmount == 0 {
 		return 10_000_000_000
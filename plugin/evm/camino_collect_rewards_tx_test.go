@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	gconstants "github.com/ava-labs/coreth/constants"
+	"github.com/ava-labs/coreth/params"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
@@ -133,17 +134,17 @@ func TestCollectRewardsEVMStateTransfer(t *testing.T) {
 			state.AddBalance(gconstants.BlackholeAddr, big.NewInt(tt.balance))
 
 			// Add slot balance to coinbase address
-			state.SetState(gconstants.BlackholeAddr, BalanceSlot, common.BigToHash(big.NewInt(tt.slotBalance)))
+			state.SetState(gconstants.BlackholeAddr, params.DefaultSystemContracts.FeeRewardBalanceSlot, common.BigToHash(big.NewInt(tt.slotBalance)))
 
 			// Cal the rewards tx
 			tx, err := vm.NewCollectRewardsTx(common.Hash{}, tt.amountToDistribute, 1)
 			require.NoError(t, err)
 
-			err = tx.EVMStateTransfer(vm.ctx, state)
+			err = tx.EVMStateTransfer(vm.ctx, state, vm.currentRules())
 			require.NoError(t, err)
 
 			// assert incentive balance
-			incentiveBalance := state.GetBalance(common.Address(FeeRewardAddressID)).Uint64()
+			incentiveBalance := state.GetBalance(params.DefaultSystemContracts.FeeRewardAddress).Uint64()
 			require.Equal(t, tt.expectedIPReward, incentiveBalance, fmt.Sprintf("expected %d, got (actual) %d", tt.expectedIPReward, incentiveBalance))
 
 			// assert coinbase balance
@@ -151,7 +152,7 @@ func TestCollectRewardsEVMStateTransfer(t *testing.T) {
 			require.Equal(t, tt.expectedNewBalance, newCoinbaseBalance, fmt.Sprintf("expected %d, got (actual) %d", tt.expectedNewBalance, newCoinbaseBalance))
 
 			// assert slot balance
-			newSlotBalance := state.GetState(gconstants.BlackholeAddr, BalanceSlot).Big().Uint64()
+			newSlotBalance := state.GetState(gconstants.BlackholeAddr, params.DefaultSystemContracts.FeeRewardBalanceSlot).Big().Uint64()
 			require.Equal(t, tt.expectedNewSlotBalance, newSlotBalance, fmt.Sprintf("expected %d, got (actual) %d", tt.expectedNewSlotBalance, newSlotBalance))
 		})
 	}
@@ -254,7 +255,7 @@ func TestCollectRewardsSemanticVerify(t *testing.T) {
 
 			require.NoError(t, err)
 
-			err = tx.SemanticVerify(vm, nil, parent, sunriseBaseFee, apricotRulesPhase5)
+			err = tx.SemanticVerify(vm, nil, parent, sunriseBaseFee, vm.currentRules())
 
 			if tt.expectedInvalidError != nil {
 				require.Equal(t, tt.expectedInvalidError, err)
@@ -305,9 +306,9 @@ func TestIssueCollectRewardsTxAndBuildBlock(t *testing.T) {
 	expectedBalance := amount - uint64(2*0.3*float64(amount))
 	require.Equal(t, expectedBalance, balanceAvax, "expected %d but got %d", amount, balanceAvax)
 
-	TimestampSlot := state.GetState(gconstants.BlackholeAddr, TimestampSlot).Big().Uint64()
+	timestampSlot := state.GetState(gconstants.BlackholeAddr, params.DefaultSystemContracts.FeeRewardTimestampSlot).Big().Uint64()
 	expectedNextTimestampSlot := feeRewardExportMinTimeInterval(vm)
-	require.Equal(t, expectedNextTimestampSlot, TimestampSlot, "expected %d but got %d", expectedNextTimestampSlot, TimestampSlot)
+	require.Equal(t, expectedNextTimestampSlot, timestampSlot, "expected %d but got %d", expectedNextTimestampSlot, timestampSlot)
 }
 
 func TestCollectRewardsAtomicOps(t *testing.T) {
@@ -369,6 +370,49 @@ func TestCollectRewardsAtomicOps(t *testing.T) {
 	}
 }
 
+func TestCollectRewardsScheduledRates(t *testing.T) {
+	amount := uint64(100_000_000_000)
+	genesisJSON := prepareGenesis(amount, 0, 1675080000, 1675081531, 0, 10_000_000_000, 3600)
+	_, vm, _, _, _ := GenesisVM(t, true, genesisJSON, "", "")
+	vm.chainConfig.FeeRewardUpgrades = []params.FeeRewardUpgrade{{
+		BlockTimestamp: big.NewInt(1675081531),
+		FeeRewardRates: params.FeeRewardRates{ExportRate: 500_000, IncentivePoolRate: 200_000},
+	}}
+	parent := vm.LastAcceptedBlockInternal().(*Block)
+
+	// Before the upgrade the default rates apply
+	tx, err := vm.NewCollectRewardsTx(parent.ethBlock.Hash(), 10, 1)
+	require.NoError(t, err)
+	utx := tx.UnsignedAtomicTx.(*UnsignedCollectRewardsTx)
+	require.Equal(t, params.DefaultFeeRewardRates.ExportRate, utx.ExportRate)
+	require.Equal(t, params.DefaultFeeRewardRates.IncentivePoolRate, utx.IncentiveRate)
+
+	// The rates active at the block the rewards are collected from are used
+	tx, err = vm.NewCollectRewardsTx(parent.ethBlock.Hash(), amount, parent.ethBlock.Time())
+	require.NoError(t, err)
+	utx = tx.UnsignedAtomicTx.(*UnsignedCollectRewardsTx)
+	require.Equal(t, uint64(500_000), utx.ExportRate)
+	require.Equal(t, uint64(200_000), utx.IncentiveRate)
+	require.NoError(t, tx.SemanticVerify(vm, nil, parent, sunriseBaseFee, vm.currentRules()))
+
+	_, reqs, err := tx.AtomicOps()
+	require.NoError(t, err)
+	utxo := &avax.TimedUTXO{}
+	_, err = vm.codec.Unmarshal(reqs.PutRequests[0].Value, utxo)
+	require.NoError(t, err)
+	require.Equal(t, amount/2, utxo.Out.(*secp256k1fx.TransferOutput).Amt)
+
+	state, err := vm.blockChain.State()
+	require.NoError(t, err)
+	require.NoError(t, tx.EVMStateTransfer(vm.ctx, state, vm.currentRules()))
+	incentiveBalance := new(big.Int).Div(state.GetBalance(params.DefaultSystemContracts.FeeRewardAddress), x2cRate)
+	require.Equal(t, amount/5, incentiveBalance.Uint64())
+
+	// Rates which differ from the scheduled ones are rejected
+	utx.ExportRate = params.DefaultFeeRewardRates.ExportRate
+	require.ErrorIs(t, tx.SemanticVerify(vm, nil, parent, sunriseBaseFee, vm.currentRules()), errRewardRateMismatch)
+}
+
 func TestVMStateCanBeDefinedInGenesis(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -440,9 +484,9 @@ func TestVMStateCanBeDefinedInGenesis(t *testing.T) {
 			nonce := state.GetNonce(gconstants.BlackholeAddr)
 			balance := state.GetBalance(gconstants.BlackholeAddr)
 			balanceAvax := new(big.Int).Div(balance, x2cRate).Uint64()
-			balanceSlot := state.GetState(gconstants.BlackholeAddr, BalanceSlot).Big()
+			balanceSlot := state.GetState(gconstants.BlackholeAddr, params.DefaultSystemContracts.FeeRewardBalanceSlot).Big()
 			bSlotAvax := new(big.Int).Div(balanceSlot, x2cRate).Uint64()
-			tsSlot := state.GetState(gconstants.BlackholeAddr, TimestampSlot).Big().Uint64()
+			tsSlot := state.GetState(gconstants.BlackholeAddr, params.DefaultSystemContracts.FeeRewardTimestampSlot).Big().Uint64()
 
 			require.Equal(t, tt.nonce, nonce)
 			require.Equal(t, tt.balance, balanceAvax)
@@ -0,0 +1,147 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/coreth/params"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var errNoExportAmounts = errors.New("no amounts to export")
+
+// newMultiAssetExportTx returns a new ExportTx with one output owned by
+// [owners] for each asset in [amounts]. The fee is paid in AVAX, the keys
+// whose funds are spent are selected with [selection].
+// Non-AVAX assets are rejected as of Banff.
+func (vm *VM) newMultiAssetExportTx(
+	amounts map[ids.ID]uint64, // Amounts of tokens to export by AssetID
+	chainID ids.ID, // Chain to send the UTXOs to
+	owners secp256k1fx.OutputOwners, // Owners of the exported outputs
+	baseFee *big.Int, // fee to use post-AP3
+	keys []*secp256k1.PrivateKey, // Pay the fee and provide the tokens
+	selection CoinSelection, // Strategy to select the keys whose funds are spent
+) (*Tx, error) {
+	utx, err := vm.newUnsignedMultiAssetExportTx(amounts, chainID, owners, baseFee, ethAddresses(keys), selection)
+	if err != nil {
+		return nil, err
+	}
+	tx := &Tx{UnsignedAtomicTx: utx}
+	if err := tx.Sign(vm.codec, evmInputSigners(utx.Ins, keys)); err != nil {
+		return nil, err
+	}
+	return tx, utx.Verify(vm.ctx, vm.currentRules())
+}
+
+// newUnsignedMultiAssetExportTx returns a new unsigned ExportTx with one
+// output owned by [owners] for each asset in [amounts], spending the funds of
+// [addrs]. See newMultiAssetExportTx.
+func (vm *VM) newUnsignedMultiAssetExportTx(
+	amounts map[ids.ID]uint64, // Amounts of tokens to export by AssetID
+	chainID ids.ID, // Chain to send the UTXOs to
+	owners secp256k1fx.OutputOwners, // Owners of the exported outputs
+	baseFee *big.Int, // fee to use post-AP3
+	addrs []common.Address, // Pay the fee and provide the tokens
+	selection CoinSelection, // Strategy to select the addresses whose funds are spent
+) (*UnsignedExportTx, error) {
+	if len(amounts) == 0 {
+		return nil, errNoExportAmounts
+	}
+
+	rules := vm.currentRules()
+	assetIDs := make([]ids.ID, 0, len(amounts))
+	for assetID := range amounts {
+		if rules.IsBanff && assetID != vm.ctx.AVAXAssetID {
+			return nil, errExportNonAVAXOutputBanff
+		}
+		assetIDs = append(assetIDs, assetID)
+	}
+	// Spend the non-AVAX assets in a deterministic order
+	utils.Sort(assetIDs)
+
+	var (
+		avaxNeeded   uint64 = 0
+		outs                = make([]*avax.TransferableOutput, 0, len(amounts))
+		ins, avaxIns []EVMInput
+		err          error
+	)
+	for _, assetID := range assetIDs {
+		amount := amounts[assetID]
+		outs = append(outs, &avax.TransferableOutput{
+			Asset: avax.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          amount,
+				OutputOwners: owners,
+			},
+		})
+
+		if assetID == vm.ctx.AVAXAssetID {
+			avaxNeeded = amount
+			continue
+		}
+		// consume non-AVAX
+		assetIns, err := vm.getSpendableFunds(addrs, assetID, amount, selection)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't generate tx inputs: %w", err)
+		}
+		ins = append(ins, assetIns...)
+	}
+
+	switch {
+	case rules.IsApricotPhase3:
+		utx := &UnsignedExportTx{
+			NetworkID:        vm.ctx.NetworkID,
+			BlockchainID:     vm.ctx.ChainID,
+			DestinationChain: chainID,
+			Ins:              ins,
+			ExportedOutputs:  outs,
+		}
+		tx := &Tx{UnsignedAtomicTx: utx}
+		if err := tx.Sign(vm.codec, nil); err != nil {
+			return nil, err
+		}
+
+		var cost uint64
+		cost, err = tx.GasUsed(rules.IsApricotPhase5)
+		if err != nil {
+			return nil, err
+		}
+
+		avaxIns, err = vm.getSpendableAVAXWithFee(addrs, avaxNeeded, cost, baseFee, selection)
+	default:
+		var newAvaxNeeded uint64
+		newAvaxNeeded, err = math.Add64(avaxNeeded, params.AvalancheAtomicTxFee)
+		if err != nil {
+			return nil, errOverflowExport
+		}
+		avaxIns, err = vm.getSpendableFunds(addrs, vm.ctx.AVAXAssetID, newAvaxNeeded, selection)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs: %w", err)
+	}
+	ins = append(ins, avaxIns...)
+
+	avax.SortTransferableOutputs(outs, vm.codec)
+	// The signers are derived from the sorted inputs
+	SortEVMInputsAndSigners(ins, make([][]*secp256k1.PrivateKey, len(ins)))
+
+	// Create the transaction
+	return &UnsignedExportTx{
+		NetworkID:        vm.ctx.NetworkID,
+		BlockchainID:     vm.ctx.ChainID,
+		DestinationChain: chainID,
+		Ins:              ins,
+		ExportedOutputs:  outs,
+	}, nil
+}
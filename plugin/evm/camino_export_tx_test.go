@@ -0,0 +1,143 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestMultiAssetExportTx(t *testing.T) {
+	require := require.New(t)
+
+	// Non-AVAX assets cannot be imported or exported as of Banff
+	issuer, vm, _, sharedMemory, _ := GenesisVM(t, true, genesisJSONApricotPhase3, "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	service := &AvaxAPI{vm}
+	keys := []*secp256k1.PrivateKey{testKeys[0]}
+	addr := GetEthAddress(testKeys[0])
+	assetID := ids.GenerateTestID()
+
+	// Import AVAX and [assetID] to [addr]
+	_, err := addUTXO(sharedMemory, vm.ctx, ids.GenerateTestID(), 0, vm.ctx.AVAXAssetID, 50_000_000, testShortIDAddrs[0])
+	require.NoError(err)
+	_, err = addUTXO(sharedMemory, vm.ctx, ids.GenerateTestID(), 0, assetID, 30_000_000, testShortIDAddrs[0])
+	require.NoError(err)
+	importTx, err := vm.newImportTx(vm.ctx.XChainID, addr, initialBaseFee, keys)
+	require.NoError(err)
+	require.NoError(vm.issueTx(importTx, true /*=local*/))
+	<-issuer
+	blk, err := vm.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(vm.SetPreference(context.Background(), blk.ID()))
+	require.NoError(blk.Accept(context.Background()))
+
+	balancesReply := &GetMultiCoinBalancesReply{}
+	balancesArgs := &GetMultiCoinBalancesArgs{
+		Address:  addr.Hex(),
+		AssetIDs: []string{assetID.String()},
+	}
+	require.NoError(service.GetMultiCoinBalances(nil, balancesArgs, balancesReply))
+	require.Equal([]MultiCoinBalance{{
+		AssetID: assetID,
+		Balance: (*hexutil.Big)(big.NewInt(30_000_000)),
+	}}, balancesReply.Balances)
+	// Without assetIDs, the balances of the imported assets are returned
+	importedBalancesArgs := &GetMultiCoinBalancesArgs{Address: addr.Hex()}
+	require.NoError(service.GetMultiCoinBalances(nil, importedBalancesArgs, balancesReply))
+	require.Equal([]MultiCoinBalance{{
+		AssetID: assetID,
+		Balance: (*hexutil.Big)(big.NewInt(30_000_000)),
+	}}, balancesReply.Balances)
+
+	_, err = vm.newMultiAssetExportTx(nil, vm.ctx.XChainID, secp256k1fx.OutputOwners{}, initialBaseFee, keys, CoinSelectionKeyOrder)
+	require.ErrorIs(err, errNoExportAmounts)
+
+	owners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{testShortIDAddrs[1]},
+	}
+	exportTx, err := vm.newMultiAssetExportTx(
+		map[ids.ID]uint64{vm.ctx.AVAXAssetID: 5_000_000, assetID: 10_000_000},
+		vm.ctx.XChainID,
+		owners,
+		initialBaseFee,
+		keys,
+		CoinSelectionLargestFirst,
+	)
+	require.NoError(err)
+
+	utx := exportTx.UnsignedAtomicTx.(*UnsignedExportTx)
+	require.Len(utx.ExportedOutputs, 2)
+	exported := make(map[ids.ID]uint64)
+	for _, out := range utx.ExportedOutputs {
+		exported[out.AssetID()] = out.Output().Amount()
+		require.Equal(owners, out.Out.(*secp256k1fx.TransferOutput).OutputOwners)
+	}
+	require.Equal(map[ids.ID]uint64{vm.ctx.AVAXAssetID: 5_000_000, assetID: 10_000_000}, exported)
+	// Only AVAX is burned to pay the fee
+	burned, err := utx.Burned(assetID)
+	require.NoError(err)
+	require.Zero(burned)
+
+	require.NoError(vm.issueTx(exportTx, true /*=local*/))
+	<-issuer
+	blk, err = vm.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(vm.SetPreference(context.Background(), blk.ID()))
+	require.NoError(blk.Accept(context.Background()))
+
+	require.NoError(service.GetMultiCoinBalances(nil, balancesArgs, balancesReply))
+	require.Equal([]MultiCoinBalance{{
+		AssetID: assetID,
+		Balance: (*hexutil.Big)(big.NewInt(20_000_000)),
+	}}, balancesReply.Balances)
+	require.NoError(service.GetMultiCoinBalances(nil, importedBalancesArgs, balancesReply))
+	require.Equal([]MultiCoinBalance{{
+		AssetID: assetID,
+		Balance: (*hexutil.Big)(big.NewInt(20_000_000)),
+	}}, balancesReply.Balances)
+
+	// Zero balances are omitted
+	require.NoError(service.GetMultiCoinBalances(nil, &GetMultiCoinBalancesArgs{
+		Address:  testEthAddrs[1].Hex(),
+		AssetIDs: []string{assetID.String()},
+	}, balancesReply))
+	require.Empty(balancesReply.Balances)
+	require.NoError(service.GetMultiCoinBalances(nil, &GetMultiCoinBalancesArgs{
+		Address: testEthAddrs[1].Hex(),
+	}, balancesReply))
+	require.Empty(balancesReply.Balances)
+}
+
+func TestMultiAssetExportTxBanff(t *testing.T) {
+	require := require.New(t)
+
+	_, vm, _, _, _ := GenesisVM(t, true, genesisJSONBanff, "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	_, err := vm.newMultiAssetExportTx(
+		map[ids.ID]uint64{vm.ctx.AVAXAssetID: 5_000_000, ids.GenerateTestID(): 10_000_000},
+		vm.ctx.XChainID,
+		secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{testShortIDAddrs[1]}},
+		initialBaseFee,
+		[]*secp256k1.PrivateKey{testKeys[0]},
+		CoinSelectionKeyOrder,
+	)
+	require.ErrorIs(err, errExportNonAVAXOutputBanff)
+}
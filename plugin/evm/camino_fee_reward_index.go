@@ -0,0 +1,141 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+var (
+	feeRewardDBPrefix    = []byte("feeRewardDB")
+	feeRewardsIndexedKey = []byte("feeRewardsIndexed")
+)
+
+// FeeReward describes the fees collected and distributed by an accepted
+// UnsignedCollectRewardsTx. Amounts are denominated in nAVAX.
+type FeeReward struct {
+	// TxID of the UnsignedCollectRewardsTx
+	TxID ids.ID `serialize:"true" json:"txID"`
+	// Height of the block which accepted the tx
+	Height json.Uint64 `serialize:"true" json:"height"`
+	// BlockHash of the block the fees were collected from
+	BlockHash common.Hash `serialize:"true" json:"blockHash"`
+	// BlockTime of the block the fees were collected from
+	BlockTime json.Uint64 `serialize:"true" json:"blockTime"`
+	// Amount collected from the blackhole
+	Amount json.Uint64 `serialize:"true" json:"amount"`
+	// ExportAmount exported to the P-Chain
+	ExportAmount json.Uint64 `serialize:"true" json:"exportAmount"`
+	// IncentiveAmount paid to the fee reward contract
+	IncentiveAmount json.Uint64 `serialize:"true" json:"incentiveAmount"`
+	// NextEarliestCollectTime set by the tx
+	NextEarliestCollectTime json.Uint64 `serialize:"true" json:"nextEarliestCollectTime"`
+}
+
+// feeReward returns the FeeReward of [ucx] accepted at [height]
+func (ucx *UnsignedCollectRewardsTx) feeReward(txID ids.ID, height uint64) *FeeReward {
+	amount := ucx.Ins[0].Amount
+	return &FeeReward{
+		TxID:                    txID,
+		Height:                  json.Uint64(height),
+		BlockHash:               ucx.BlockHash,
+		BlockTime:               json.Uint64(ucx.BlockTime),
+		Amount:                  json.Uint64(amount),
+		ExportAmount:            json.Uint64(calculateRate(amount, ucx.ExportRate)),
+		IncentiveAmount:         json.Uint64(calculateRate(amount, ucx.IncentiveRate)),
+		NextEarliestCollectTime: json.Uint64(ucx.NextEarliestCollectTime),
+	}
+}
+
+// indexFeeRewards adds the UnsignedCollectRewardsTxs in [txs] to the
+// [feeRewardDB] stored with the key [height]+[txID]
+func (a *atomicTxRepository) indexFeeRewards(heightBytes []byte, txs []*Tx) error {
+	for _, tx := range txs {
+		ucx, ok := tx.UnsignedAtomicTx.(*UnsignedCollectRewardsTx)
+		if !ok || len(ucx.Ins) != 1 {
+			continue
+		}
+		txID := tx.ID()
+		rewardBytes, err := a.codec.Marshal(codecVersion, ucx.feeReward(txID, binary.BigEndian.Uint64(heightBytes)))
+		if err != nil {
+			return err
+		}
+		key := make([]byte, 0, wrappers.LongLen+len(txID))
+		key = append(key, heightBytes...)
+		key = append(key, txID[:]...)
+		if err := a.feeRewardDB.Put(key, rewardBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// initializeFeeRewardIndex indexes the UnsignedCollectRewardsTxs which were
+// accepted before the fee reward index existed
+func (a *atomicTxRepository) initializeFeeRewardIndex() error {
+	done, err := a.atomicRepoMetadataDB.Has(feeRewardsIndexedKey)
+	if err != nil || done {
+		return err
+	}
+
+	startTime := time.Now()
+	iter := a.acceptedAtomicTxByHeightDB.NewIterator()
+	defer iter.Release()
+
+	indexedHeights := 0
+	for iter.Next() {
+		txs, err := ExtractAtomicTxsBatch(iter.Value(), a.codec)
+		if err != nil {
+			return err
+		}
+		if err := a.indexFeeRewards(iter.Key(), txs); err != nil {
+			return err
+		}
+		indexedHeights++
+	}
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("atomic tx DB iterator errored while initializing fee reward index: %w", err)
+	}
+	if err := a.atomicRepoMetadataDB.Put(feeRewardsIndexedKey, nil); err != nil {
+		return err
+	}
+
+	log.Info("Completed fee reward index initialization", "indexedHeights", indexedHeights, "duration", time.Since(startTime))
+	return a.db.Commit()
+}
+
+// GetFeeRewards returns up to [limit] fee rewards accepted at heights
+// in [startHeight, endHeight], ordered by height
+func (a *atomicTxRepository) GetFeeRewards(startHeight, endHeight uint64, limit int) ([]*FeeReward, error) {
+	startBytes := make([]byte, wrappers.LongLen)
+	binary.BigEndian.PutUint64(startBytes, startHeight)
+	iter := a.feeRewardDB.NewIteratorWithStart(startBytes)
+	defer iter.Release()
+
+	rewards := []*FeeReward{}
+	for len(rewards) < limit && iter.Next() {
+		key := iter.Key()
+		if len(key) < wrappers.LongLen {
+			return nil, fmt.Errorf("fee reward DB key too short: %d", len(key))
+		}
+		if binary.BigEndian.Uint64(key) > endHeight {
+			break
+		}
+		reward := &FeeReward{}
+		if _, err := a.codec.Unmarshal(iter.Value(), reward); err != nil {
+			return nil, err
+		}
+		rewards = append(rewards, reward)
+	}
+	return rewards, iter.Error()
+}
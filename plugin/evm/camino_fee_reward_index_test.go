@@ -0,0 +1,113 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCollectRewardsTx(t *testing.T, amount uint64, blockTime uint64) *Tx {
+	tx := &Tx{UnsignedAtomicTx: &UnsignedCollectRewardsTx{
+		UnsignedExportTx: UnsignedExportTx{
+			Ins: []EVMInput{{Amount: amount}},
+		},
+		BlockHash:               common.Hash{1},
+		BlockTime:               blockTime,
+		NextEarliestCollectTime: blockTime + 3600,
+		ExportRate:              300_000,
+		IncentiveRate:           200_000,
+	}}
+	require.NoError(t, tx.Sign(Codec, nil))
+	return tx
+}
+
+func TestFeeRewardIndex(t *testing.T) {
+	require := require.New(t)
+
+	db := versiondb.New(memdb.New())
+	repo, err := NewAtomicTxRepository(db, Codec, 0, nil, nil, nil)
+	require.NoError(err)
+
+	tx1 := newTestCollectRewardsTx(t, 1_000, 3600)
+	tx2 := newTestCollectRewardsTx(t, 2_000, 7200)
+	require.NoError(repo.Write(10, []*Tx{tx1}))
+	require.NoError(repo.Write(11, nil))
+	require.NoError(repo.Write(20, []*Tx{tx2}))
+
+	rewards, err := repo.GetFeeRewards(0, 100, 10)
+	require.NoError(err)
+	require.Equal([]*FeeReward{
+		{
+			TxID:                    tx1.ID(),
+			Height:                  10,
+			BlockHash:               common.Hash{1},
+			BlockTime:               3600,
+			Amount:                  1_000,
+			ExportAmount:            300,
+			IncentiveAmount:         200,
+			NextEarliestCollectTime: 7200,
+		},
+		{
+			TxID:                    tx2.ID(),
+			Height:                  20,
+			BlockHash:               common.Hash{1},
+			BlockTime:               7200,
+			Amount:                  2_000,
+			ExportAmount:            600,
+			IncentiveAmount:         400,
+			NextEarliestCollectTime: 10800,
+		},
+	}, rewards)
+
+	// Ranges and limits are applied
+	rewards, err = repo.GetFeeRewards(11, 100, 10)
+	require.NoError(err)
+	require.Len(rewards, 1)
+	require.Equal(json.Uint64(20), rewards[0].Height)
+
+	rewards, err = repo.GetFeeRewards(0, 19, 10)
+	require.NoError(err)
+	require.Len(rewards, 1)
+	require.Equal(tx1.ID(), rewards[0].TxID)
+
+	rewards, err = repo.GetFeeRewards(0, 100, 1)
+	require.NoError(err)
+	require.Len(rewards, 1)
+}
+
+func TestFeeRewardIndexInitialization(t *testing.T) {
+	require := require.New(t)
+
+	db := versiondb.New(memdb.New())
+	repo, err := NewAtomicTxRepository(db, Codec, 0, nil, nil, nil)
+	require.NoError(err)
+
+	// Simulate txs accepted before the fee reward index existed
+	tx := newTestCollectRewardsTx(t, 1_000, 3600)
+	require.NoError(repo.Write(10, []*Tx{tx}))
+	iter := repo.feeRewardDB.NewIterator()
+	for iter.Next() {
+		require.NoError(repo.feeRewardDB.Delete(iter.Key()))
+	}
+	iter.Release()
+	require.NoError(repo.atomicRepoMetadataDB.Delete(feeRewardsIndexedKey))
+	require.NoError(db.Commit())
+
+	rewards, err := repo.GetFeeRewards(0, 100, 10)
+	require.NoError(err)
+	require.Empty(rewards)
+
+	repo, err = NewAtomicTxRepository(db, Codec, 10, nil, nil, nil)
+	require.NoError(err)
+	rewards, err = repo.GetFeeRewards(0, 100, 10)
+	require.NoError(err)
+	require.Len(rewards, 1)
+	require.Equal(tx.ID(), rewards[0].TxID)
+}
@@ -0,0 +1,207 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+// Status of a tx in the atomic mempool
+const (
+	MempoolTxPending   = "pending"   // waiting in the tx heap to be issued into a block
+	MempoolTxCurrent   = "current"   // about to be added to a block
+	MempoolTxIssued    = "issued"    // issued into a block which is processing
+	MempoolTxDiscarded = "discarded" // recently discarded
+)
+
+var errTxNotInMempool = errors.New("tx not in mempool")
+
+// discardedTx is a tx recently discarded from the mempool and the reason
+type discardedTx struct {
+	tx     *Tx
+	reason string
+}
+
+// MempoolTx describes a tx in the atomic mempool
+type MempoolTx struct {
+	TxID   ids.ID `json:"txID"`
+	Status string `json:"status"`
+	// GasPrice is the price paid per unit of gas, by which pending txs are
+	// ordered and replaced
+	GasPrice   json.Uint64 `json:"gasPrice"`
+	InputUTXOs []ids.ID    `json:"inputUTXOs"`
+	// ConflictingUTXOs are the input UTXOs spent by another tx in the mempool
+	ConflictingUTXOs []ids.ID `json:"conflictingUTXOs,omitempty"`
+	// DiscardReason is set for discarded txs
+	DiscardReason string `json:"discardReason,omitempty"`
+}
+
+// MempoolStatus summarizes the atomic mempool
+type MempoolStatus struct {
+	Pending   json.Uint32 `json:"pending"`
+	Current   json.Uint32 `json:"current"`
+	Issued    json.Uint32 `json:"issued"`
+	Discarded json.Uint32 `json:"discarded"`
+	// Size counts the pending and the issued txs, which are limited by MaxSize
+	Size    json.Uint32 `json:"size"`
+	MaxSize json.Uint32 `json:"maxSize"`
+	// SpentUTXOs is the number of UTXOs spent by txs in the mempool
+	SpentUTXOs json.Uint32 `json:"spentUTXOs"`
+}
+
+// discardTx records [tx] as discarded for [reason]. The discarded txs are
+// kept in [discardedTxs] to not request them again and in [discardReasons] to
+// report them, both bounded to [discardedTxsCacheSize].
+// Assumes the lock is held.
+func (m *Mempool) discardTx(tx *Tx, reason string) {
+	txID := tx.ID()
+	m.discardedTxs.Put(txID, tx)
+	m.discardReasons.Delete(txID)
+	m.discardReasons.Put(txID, &discardedTx{tx: tx, reason: reason})
+	if m.discardReasons.Len() > discardedTxsCacheSize {
+		if oldestID, _, ok := m.discardReasons.Oldest(); ok {
+			m.discardReasons.Delete(oldestID)
+		}
+	}
+}
+
+// evictDiscardedTx removes [txID] from the discarded txs.
+// Assumes the lock is held.
+func (m *Mempool) evictDiscardedTx(txID ids.ID) {
+	m.discardedTxs.Evict(txID)
+	m.discardReasons.Delete(txID)
+}
+
+// DiscardTx records [tx], which was not added to the mempool because of
+// [reason], as discarded so it won't be requested again
+func (m *Mempool) DiscardTx(tx *Tx, reason error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.discardTx(tx, reason.Error())
+}
+
+// Content returns the txs in the mempool, ordered by status, and the
+// recently discarded txs, ordered by the time they were discarded
+func (m *Mempool) Content() []*MempoolTx {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	content := make([]*MempoolTx, 0, m.txHeap.Len()+len(m.currentTxs)+len(m.issuedTxs)+m.discardReasons.Len())
+	for _, entry := range m.txHeap.maxHeap.items {
+		content = append(content, m.describeTx(entry.tx, MempoolTxPending))
+	}
+	// Pending txs are issued by descending gas price
+	sort.SliceStable(content, func(i, j int) bool { return content[i].GasPrice > content[j].GasPrice })
+	for _, tx := range m.currentTxs {
+		content = append(content, m.describeTx(tx, MempoolTxCurrent))
+	}
+	for _, tx := range m.issuedTxs {
+		content = append(content, m.describeTx(tx, MempoolTxIssued))
+	}
+	iter := m.discardReasons.NewIterator()
+	for iter.Next() {
+		discarded := iter.Value()
+		mempoolTx := m.describeTx(discarded.tx, MempoolTxDiscarded)
+		mempoolTx.DiscardReason = discarded.reason
+		content = append(content, mempoolTx)
+	}
+	return content
+}
+
+// describeTx returns the MempoolTx of [tx] with [status].
+// Assumes the lock is held.
+func (m *Mempool) describeTx(tx *Tx, status string) *MempoolTx {
+	txID := tx.ID()
+	// The gas price of discarded txs might not be computable
+	gasPrice, _ := m.atomicTxGasPrice(tx)
+	mempoolTx := &MempoolTx{
+		TxID:       txID,
+		Status:     status,
+		GasPrice:   json.Uint64(gasPrice),
+		InputUTXOs: tx.InputUTXOs().List(),
+	}
+	utils.Sort(mempoolTx.InputUTXOs)
+	for _, utxoID := range mempoolTx.InputUTXOs {
+		if spender, ok := m.utxoSpenders[utxoID]; ok && spender.ID() != txID {
+			mempoolTx.ConflictingUTXOs = append(mempoolTx.ConflictingUTXOs, utxoID)
+		}
+	}
+	return mempoolTx
+}
+
+// Status returns the number of txs by status and the capacity of the mempool
+func (m *Mempool) Status() MempoolStatus {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return MempoolStatus{
+		Pending:    json.Uint32(m.txHeap.Len()),
+		Current:    json.Uint32(len(m.currentTxs)),
+		Issued:     json.Uint32(len(m.issuedTxs)),
+		Discarded:  json.Uint32(m.discardReasons.Len()),
+		Size:       json.Uint32(m.length()),
+		MaxSize:    json.Uint32(m.maxSize),
+		SpentUTXOs: json.Uint32(len(m.utxoSpenders)),
+	}
+}
+
+// DropTx discards the pending, current or issued tx [txID] and releases the
+// UTXOs it spends, so that a conflicting tx can be added
+func (m *Mempool) DropTx(txID ids.ID) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	tx, ok := m.txHeap.Get(txID)
+	if !ok {
+		tx, ok = m.currentTxs[txID]
+	}
+	if !ok {
+		tx, ok = m.issuedTxs[txID]
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s", errTxNotInMempool, txID)
+	}
+	m.removeTx(tx, "dropped by admin")
+	return nil
+}
+
+// minReplacementGasPrice returns the minimum gas price of a tx replacing a
+// conflicting tx with [gasPrice], that is [gasPrice] bumped by [priceBump]
+// percent and at least one more than [gasPrice]
+func (m *Mempool) minReplacementGasPrice(gasPrice uint64) uint64 {
+	bumped := new(big.Int).SetUint64(gasPrice)
+	bumped.Mul(bumped, new(big.Int).SetUint64(100+m.priceBump))
+	bumped.Div(bumped, big.NewInt(100))
+	switch {
+	case !bumped.IsUint64():
+		return math.MaxUint64
+	case bumped.Uint64() <= gasPrice:
+		if gasPrice == math.MaxUint64 {
+			return math.MaxUint64
+		}
+		return gasPrice + 1
+	default:
+		return bumped.Uint64()
+	}
+}
+
+// removeNewTx removes [txID] from the txs ready to be gossiped.
+// Assumes the lock is held.
+func (m *Mempool) removeNewTx(txID ids.ID) {
+	for i, tx := range m.newTxs {
+		if tx.ID() == txID {
+			m.newTxs = append(m.newTxs[:i], m.newTxs[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/coreth/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMempoolContent(t *testing.T) {
+	require := require.New(t)
+
+	// we use AP3 genesis here to not trip any block fees
+	_, vm, _, _, _ := GenesisVM(t, true, genesisJSONApricotPhase3, "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	mempool := vm.mempool
+
+	tx1 := createImportTx(t, vm, ids.ID{1}, params.AvalancheAtomicTxFee)
+	conflictingTx1 := createImportTx(t, vm, ids.ID{1}, 2*params.AvalancheAtomicTxFee)
+	tx2 := createImportTx(t, vm, ids.ID{2}, 3*params.AvalancheAtomicTxFee)
+	tx3 := createImportTx(t, vm, ids.ID{3}, params.AvalancheAtomicTxFee)
+	require.NoError(mempool.AddTx(tx1))
+	require.NoError(mempool.AddTx(conflictingTx1))
+	require.NoError(mempool.AddTx(tx2))
+	require.NoError(mempool.AddTx(tx3))
+
+	// [tx2] pays the highest gas price and becomes current
+	next, ok := mempool.NextTx()
+	require.True(ok)
+	require.Equal(tx2.ID(), next.ID())
+
+	content := mempool.Content()
+	require.Len(content, 4)
+	statuses := make(map[ids.ID]*MempoolTx)
+	for _, mempoolTx := range content {
+		statuses[mempoolTx.TxID] = mempoolTx
+	}
+	require.Equal(MempoolTxPending, content[0].Status)
+	require.Equal(conflictingTx1.ID(), content[0].TxID)
+	require.Equal(MempoolTxPending, content[1].Status)
+	require.Equal(tx3.ID(), content[1].TxID)
+	require.Equal(MempoolTxCurrent, statuses[tx2.ID()].Status)
+
+	discarded := statuses[tx1.ID()]
+	require.Equal(MempoolTxDiscarded, discarded.Status)
+	require.Contains(discarded.DiscardReason, "replaced by conflicting tx "+conflictingTx1.ID().String())
+	require.Len(discarded.InputUTXOs, 2)
+	require.Equal(discarded.InputUTXOs, discarded.ConflictingUTXOs)
+	require.Empty(statuses[conflictingTx1.ID()].ConflictingUTXOs)
+
+	gasPrice, err := mempool.atomicTxGasPrice(tx2)
+	require.NoError(err)
+	require.Equal(json.Uint64(gasPrice), statuses[tx2.ID()].GasPrice)
+
+	require.Equal(MempoolStatus{
+		Pending:    2,
+		Current:    1,
+		Issued:     0,
+		Discarded:  1,
+		Size:       2,
+		MaxSize:    json.Uint32(defaultMempoolSize),
+		SpentUTXOs: 6,
+	}, mempool.Status())
+
+	// Dropped txs release their UTXOs
+	require.NoError(mempool.DropTx(conflictingTx1.ID()))
+	require.ErrorIs(mempool.DropTx(conflictingTx1.ID()), errTxNotInMempool)
+	require.False(mempool.has(conflictingTx1.ID()))
+	for _, mempoolTx := range mempool.Content() {
+		if mempoolTx.TxID == conflictingTx1.ID() {
+			require.Equal(MempoolTxDiscarded, mempoolTx.Status)
+			require.Equal("dropped by admin", mempoolTx.DiscardReason)
+		}
+	}
+	require.NoError(mempool.AddTx(tx1))
+	require.True(mempool.has(tx1.ID()))
+
+	// Discarded txs added again are not reported as discarded anymore
+	status := mempool.Status()
+	require.Equal(json.Uint32(1), status.Discarded)
+}
+
+func TestMempoolAPI(t *testing.T) {
+	require := require.New(t)
+
+	_, vm, _, _, _ := GenesisVM(t, true, genesisJSONApricotPhase3, "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	service := &AvaxAPI{vm}
+	adminService := &AvaxAdminAPI{vm}
+
+	tx := createImportTx(t, vm, ids.ID{1}, params.AvalancheAtomicTxFee)
+	require.NoError(vm.mempool.AddTx(tx))
+
+	contentReply := &GetMempoolContentReply{}
+	require.NoError(service.GetMempoolContent(nil, nil, contentReply))
+	require.Len(contentReply.Txs, 1)
+	require.Equal(tx.ID(), contentReply.Txs[0].TxID)
+
+	statusReply := &MempoolStatus{}
+	require.NoError(service.GetMempoolStatus(nil, nil, statusReply))
+	require.Equal(json.Uint32(1), statusReply.Pending)
+
+	require.ErrorIs(adminService.DropAtomicTx(nil, &api.JSONTxID{}, &api.EmptyReply{}), errNilTxID)
+	require.NoError(adminService.DropAtomicTx(nil, &api.JSONTxID{TxID: tx.ID()}, &api.EmptyReply{}))
+	require.NoError(service.GetMempoolStatus(nil, nil, statusReply))
+	require.Equal(json.Uint32(0), statusReply.Pending)
+	require.Equal(json.Uint32(1), statusReply.Discarded)
+}
+
+func TestMempoolPriceBump(t *testing.T) {
+	require := require.New(t)
+
+	_, vm, _, _, _ := GenesisVM(t, true, genesisJSONApricotPhase3, "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	mempool := vm.mempool
+	require.Equal(uint64(10), mempool.priceBump)
+
+	require.Equal(uint64(110), mempool.minReplacementGasPrice(100))
+	require.Equal(uint64(6), mempool.minReplacementGasPrice(5))
+	require.Equal(uint64(1), mempool.minReplacementGasPrice(0))
+	require.Equal(uint64(math.MaxUint64), mempool.minReplacementGasPrice(math.MaxUint64))
+
+	tx := createImportTx(t, vm, ids.ID{1}, params.AvalancheAtomicTxFee)
+	underpricedTx := createImportTx(t, vm, ids.ID{1}, params.AvalancheAtomicTxFee*105/100)
+	replacementTx := createImportTx(t, vm, ids.ID{1}, params.AvalancheAtomicTxFee*110/100)
+	require.NoError(mempool.AddTx(tx))
+	require.ErrorIs(mempool.AddTx(underpricedTx), errConflictingAtomicTx)
+	require.NoError(mempool.AddTx(replacementTx))
+	require.False(mempool.has(tx.ID()))
+	require.True(mempool.has(replacementTx.ID()))
+
+	// The replaced tx is not gossiped
+	newTxs := mempool.GetNewTxs()
+	require.Len(newTxs, 1)
+	require.Equal(replacementTx.ID(), newTxs[0].ID())
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/coreth/core"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// maxAliasDepth bounds the nesting of multisig aliases owning other aliases
+const maxAliasDepth = 3
+
+var (
+	errDuplicateSigner   = errors.New("duplicate signer")
+	errUnusedSignature   = errors.New("signature of non owner")
+	errAliasNotSatisfied = errors.New("multisig alias threshold not reached")
+)
+
+// stateAliasGetter returns the multisig aliases defined in the EVM state of [parent]
+func (vm *VM) stateAliasGetter(parent *Block) (secp256k1fx.AliasGetter, error) {
+	state, err := vm.blockChain.StateAt(parent.ethBlock.Root())
+	if err != nil {
+		return nil, fmt.Errorf("cannot get state of block %s: %w", parent.ID(), err)
+	}
+	return core.NewStateAliasGetter(vm.chainConfig.CaminoSystemContracts(parent.ethBlock.Timestamp()), state), nil
+}
+
+// verifyAliasCredential verifies that the signatures in [cred] over
+// [unsignedBytes] satisfy the multisig alias at [address]. Every signature
+// must belong to a distinct signer counted towards a single owner.
+func (vm *VM) verifyAliasCredential(unsignedBytes []byte, address common.Address, cred *secp256k1fx.Credential, aliases secp256k1fx.AliasGetter) error {
+	alias, err := aliases.GetMultisigAlias(ids.ShortID(address))
+	if err != nil {
+		return err
+	}
+	owners, ok := alias.Owners.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return fmt.Errorf("multisig alias %s has unexpected owners %T", alias.ID, alias.Owners)
+	}
+
+	signers := set.NewSet[ids.ShortID](len(cred.Sigs))
+	for _, sig := range cred.Sigs {
+		pubKey, err := vm.secpFactory.RecoverPublicKey(unsignedBytes, sig[:])
+		if err != nil {
+			return err
+		}
+		signer := pubKey.Address()
+		if signers.Contains(signer) {
+			return errDuplicateSigner
+		}
+		signers.Add(signer)
+	}
+
+	if !aliasSatisfied(owners, signers, aliases, maxAliasDepth) {
+		return errAliasNotSatisfied
+	}
+	if signers.Len() != 0 {
+		return errUnusedSignature
+	}
+	return nil
+}
+
+// aliasSatisfied returns true if [signers] reach the threshold of [owners].
+// Owners which are aliases themselves count if their owners reach their
+// threshold, up to [depth] levels. Every signer counts towards a single owner
+// only: the signers counted are removed from [signers] if the threshold is
+// reached, like in VerifyMultisigOwner.
+func aliasSatisfied(owners *secp256k1fx.OutputOwners, signers set.Set[ids.ShortID], aliases secp256k1fx.AliasGetter, depth int) bool {
+	if depth == 0 {
+		return false
+	}
+	remaining := set.NewSet[ids.ShortID](signers.Len())
+	remaining.Union(signers)
+	reached := uint32(0)
+	for _, addr := range owners.Addrs {
+		if reached == owners.Threshold {
+			break
+		}
+		if remaining.Contains(addr) {
+			remaining.Remove(addr)
+			reached++
+			continue
+		}
+		alias, err := aliases.GetMultisigAlias(addr)
+		if err != nil {
+			continue
+		}
+		aliasOwners, ok := alias.Owners.(*secp256k1fx.OutputOwners)
+		if !ok {
+			continue
+		}
+		// The signers are only consumed if the nested alias is satisfied
+		if aliasSatisfied(aliasOwners, remaining, aliases, depth-1) {
+			reached++
+		}
+	}
+	if reached != owners.Threshold {
+		return false
+	}
+	signers.Clear()
+	signers.Union(remaining)
+	return true
+}
+
+// isAliasNotFound returns true if [err] reports a missing multisig alias
+func isAliasNotFound(err error) bool {
+	return errors.Is(err, database.ErrNotFound)
+}
@@ -0,0 +1,154 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportTxMultisigAlias(t *testing.T) {
+	require := require.New(t)
+
+	aliasID := ids.ShortID{0xaa, 0xbb}
+	nestedAliasID := ids.ShortID{0xcc, 0xdd}
+	siblingAliasID := ids.ShortID{0xee, 0xff}
+	sharedAliasID := ids.ShortID{0x11, 0x22}
+	contracts := params.DefaultSystemContracts
+
+	// The alias is owned by testKeys[0] and a nested alias owned by testKeys[1].
+	// The shared alias is owned by the nested alias and a sibling alias owned
+	// by testKeys[1] or testKeys[2].
+	genesis := &core.Genesis{}
+	require.NoError(json.Unmarshal([]byte(genesisJSONApricotPhase3), genesis))
+	storage := core.MultisigAliasStorage(&contracts, aliasID, &secp256k1fx.OutputOwners{
+		Threshold: 2,
+		Addrs:     []ids.ShortID{testKeys[0].PublicKey().Address(), nestedAliasID},
+	}, 0)
+	nestedStorage := core.MultisigAliasStorage(&contracts, nestedAliasID, &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{testKeys[1].PublicKey().Address()},
+	}, 0)
+	siblingStorage := core.MultisigAliasStorage(&contracts, siblingAliasID, &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{testKeys[1].PublicKey().Address(), testKeys[2].PublicKey().Address()},
+	}, 0)
+	sharedStorage := core.MultisigAliasStorage(&contracts, sharedAliasID, &secp256k1fx.OutputOwners{
+		Threshold: 2,
+		Addrs:     []ids.ShortID{nestedAliasID, siblingAliasID},
+	}, 0)
+	for _, aliasStorage := range []map[common.Hash]common.Hash{nestedStorage, siblingStorage, sharedStorage} {
+		for key, value := range aliasStorage {
+			storage[key] = value
+		}
+	}
+	genesis.Alloc[contracts.MultisigAddress] = core.GenesisAccount{Balance: common.Big0, Storage: storage}
+	genesis.KeepProxyStorage = true
+	for _, id := range []ids.ShortID{aliasID, sharedAliasID} {
+		genesis.Alloc[common.Address(id)] = core.GenesisAccount{
+			Balance: new(big.Int).Mul(new(big.Int).SetUint64(units.Avax), x2cRate),
+		}
+	}
+	genesisJSON, err := json.Marshal(genesis)
+	require.NoError(err)
+
+	_, vm, _, _, _ := GenesisVM(t, true, string(genesisJSON), "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	parent := vm.LastAcceptedBlockInternal().(*Block)
+	rules := apricotRulesPhase3
+	rules.IsSunrisePhase2 = true
+
+	exportTx := &UnsignedExportTx{
+		NetworkID:        vm.ctx.NetworkID,
+		BlockchainID:     vm.ctx.ChainID,
+		DestinationChain: vm.ctx.XChainID,
+		Ins: []EVMInput{{
+			Address: common.Address(aliasID),
+			Amount:  units.Avax,
+			AssetID: vm.ctx.AVAXAssetID,
+		}},
+		ExportedOutputs: []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: vm.ctx.AVAXAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: units.Avax / 2,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{testShortIDAddrs[0]},
+				},
+			},
+		}},
+	}
+
+	tests := map[string]struct {
+		alias   ids.ShortID
+		signers []*secp256k1.PrivateKey
+		err     error
+	}{
+		"threshold reached": {
+			signers: []*secp256k1.PrivateKey{testKeys[0], testKeys[1]},
+		},
+		"threshold not reached": {
+			signers: []*secp256k1.PrivateKey{testKeys[0]},
+			err:     errAliasNotSatisfied,
+		},
+		"signature of non owner": {
+			signers: []*secp256k1.PrivateKey{testKeys[0], testKeys[1], testKeys[2]},
+			err:     errUnusedSignature,
+		},
+		"duplicate signer": {
+			signers: []*secp256k1.PrivateKey{testKeys[0], testKeys[0]},
+			err:     errDuplicateSigner,
+		},
+		"sibling aliases with distinct signers": {
+			alias:   sharedAliasID,
+			signers: []*secp256k1.PrivateKey{testKeys[1], testKeys[2]},
+		},
+		"sibling aliases sharing a signer": {
+			alias:   sharedAliasID,
+			signers: []*secp256k1.PrivateKey{testKeys[1]},
+			err:     errAliasNotSatisfied,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			utx := *exportTx
+			if test.alias != ids.ShortEmpty {
+				utx.Ins = []EVMInput{{Address: common.Address(test.alias), Amount: units.Avax, AssetID: vm.ctx.AVAXAssetID}}
+			}
+			tx := &Tx{UnsignedAtomicTx: &utx}
+			require.NoError(tx.Sign(vm.codec, [][]*secp256k1.PrivateKey{test.signers}))
+
+			err := utx.SemanticVerify(vm, tx, parent, initialBaseFee, rules)
+			require.ErrorIs(err, test.err)
+		})
+	}
+
+	// An input which is no alias still requires a single matching signature
+	utx := *exportTx
+	utx.Ins = []EVMInput{{Address: testEthAddrs[1], Amount: units.Avax, AssetID: vm.ctx.AVAXAssetID}}
+	tx := &Tx{UnsignedAtomicTx: &utx}
+	require.NoError(tx.Sign(vm.codec, [][]*secp256k1.PrivateKey{{testKeys[0]}}))
+	require.ErrorIs(utx.SemanticVerify(vm, tx, parent, initialBaseFee, rules), errPublicKeySignatureMismatch)
+
+	// Before Sunrise Phase 2 the aliases defined in the EVM state are ignored
+	utx = *exportTx
+	tx = &Tx{UnsignedAtomicTx: &utx}
+	require.NoError(tx.Sign(vm.codec, [][]*secp256k1.PrivateKey{{testKeys[0], testKeys[1]}}))
+	require.NoError(utx.SemanticVerify(vm, tx, parent, initialBaseFee, rules))
+	require.ErrorContains(utx.SemanticVerify(vm, tx, parent, initialBaseFee, apricotRulesPhase3), "expected one signature")
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	errReplacedTxNotPending      = errors.New("tx to replace is not pending in the mempool")
+	errReplacementNotSupported   = errors.New("tx cannot be replaced")
+	errReplacementUnderpriced    = errors.New("replacement tx underpriced")
+	errReplacementNotConflicting = errors.New("replacement tx does not spend the same UTXOs")
+	errReplacementMissingKeys    = errors.New("keys cannot spend all UTXOs of the tx to replace")
+)
+
+// replaceAtomicTx replaces the pending import or export tx [txID] by a tx
+// with the same effect which spends the same UTXOs with a higher fee. The
+// gas price of the replacement is bumped by at least the configured price
+// bump, [baseFee] is raised accordingly. If [baseFee] is nil, the estimated
+// base fee is used.
+// The replacement is issued locally, which discards [txID] from the mempool so
+// that it isn't gossiped anymore.
+func (vm *VM) replaceAtomicTx(txID ids.ID, baseFee *big.Int, keys []*secp256k1.PrivateKey) (*Tx, error) {
+	tx, ok := vm.mempool.GetPendingTx(txID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errReplacedTxNotPending, txID)
+	}
+	gasPrice, err := vm.mempool.atomicTxGasPrice(tx)
+	if err != nil {
+		return nil, err
+	}
+	minGasPrice := vm.mempool.minReplacementGasPrice(gasPrice)
+
+	if baseFee == nil {
+		if baseFee, err = vm.estimateBaseFee(context.Background()); err != nil {
+			return nil, err
+		}
+	}
+	// The gas price is denominated in nAVAX, so [minGasPrice] is converted
+	// to the base fee paying it
+	minBaseFee := new(big.Int).Mul(new(big.Int).SetUint64(minGasPrice), x2cRate)
+	if baseFee.Cmp(minBaseFee) < 0 {
+		baseFee = minBaseFee
+	}
+
+	replacement, err := vm.newReplacementAtomicTx(tx, baseFee, keys)
+	if err != nil {
+		return nil, err
+	}
+	if inputUTXOs := replacement.InputUTXOs(); !inputUTXOs.Overlaps(tx.InputUTXOs()) {
+		return nil, errReplacementNotConflicting
+	}
+	// The fee might not depend on the base fee before Apricot Phase 3
+	replacementGasPrice, err := vm.mempool.atomicTxGasPrice(replacement)
+	if err != nil {
+		return nil, err
+	}
+	if replacementGasPrice < minGasPrice {
+		return nil, fmt.Errorf("%w: gas price %d < %d", errReplacementUnderpriced, replacementGasPrice, minGasPrice)
+	}
+
+	if err := vm.issueTx(replacement, true /*=local*/); err != nil {
+		return nil, err
+	}
+	return replacement, nil
+}
+
+// newReplacementAtomicTx returns a tx with the effect of [tx], spending the
+// same UTXOs, built with [baseFee] and signed with [keys]
+func (vm *VM) newReplacementAtomicTx(tx *Tx, baseFee *big.Int, keys []*secp256k1.PrivateKey) (*Tx, error) {
+	switch utx := tx.UnsignedAtomicTx.(type) {
+	case *UnsignedImportTx:
+		return vm.newReplacementImportTx(utx, baseFee, keys)
+	case *UnsignedExportTx:
+		return vm.newReplacementExportTx(utx, baseFee, keys)
+	default:
+		return nil, fmt.Errorf("%w: unexpected tx type %T", errReplacementNotSupported, utx)
+	}
+}
+
+// newReplacementImportTx imports the UTXOs imported by [utx] to the same
+// recipient
+func (vm *VM) newReplacementImportTx(utx *UnsignedImportTx, baseFee *big.Int, keys []*secp256k1.PrivateKey) (*Tx, error) {
+	if len(utx.Outs) == 0 {
+		return nil, fmt.Errorf("%w: import tx without outputs", errReplacementNotSupported)
+	}
+
+	utxoIDs := make([][]byte, len(utx.ImportedInputs))
+	for i, in := range utx.ImportedInputs {
+		inputID := in.InputID()
+		utxoIDs[i] = inputID[:]
+	}
+	allUTXOBytes, err := vm.ctx.SharedMemory.Get(utx.SourceChain, utxoIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch imported UTXOs: %w", err)
+	}
+	utxos := make([]*avax.UTXO, len(allUTXOBytes))
+	for i, utxoBytes := range allUTXOBytes {
+		utxo := &avax.UTXO{}
+		if _, err := vm.codec.Unmarshal(utxoBytes, utxo); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal imported UTXO: %w", err)
+		}
+		utxos[i] = utxo
+	}
+
+	kc := secp256k1fx.NewKeychain()
+	for _, key := range keys {
+		kc.Add(key)
+	}
+	// UTXOs which cannot be spent by [keys] would be skipped
+	now := vm.clock.Unix()
+	for _, utxo := range utxos {
+		if _, _, err := kc.Spend(utxo.Out, now); err != nil {
+			return nil, fmt.Errorf("%w: UTXO %s", errReplacementMissingKeys, utxo.InputID())
+		}
+	}
+	return vm.newImportTxWithUTXOs(utx.SourceChain, utx.Outs[0].Address, baseFee, kc, utxos)
+}
+
+// newReplacementExportTx exports the output exported by [utx] to the same
+// owners. Only the keys of the addresses spent by [utx] are used, so that the
+// replacement spends the same nonces.
+func (vm *VM) newReplacementExportTx(utx *UnsignedExportTx, baseFee *big.Int, keys []*secp256k1.PrivateKey) (*Tx, error) {
+	if len(utx.ExportedOutputs) != 1 {
+		return nil, fmt.Errorf("%w: export tx with %d outputs", errReplacementNotSupported, len(utx.ExportedOutputs))
+	}
+	exported := utx.ExportedOutputs[0]
+	out, ok := exported.Out.(*secp256k1fx.TransferOutput)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected output type %T", errReplacementNotSupported, exported.Out)
+	}
+
+	spenders := set.NewSet[common.Address](len(utx.Ins))
+	for _, in := range utx.Ins {
+		spenders.Add(in.Address)
+	}
+	spenderKeys := make([]*secp256k1.PrivateKey, 0, spenders.Len())
+	for _, key := range keys {
+		if addr := GetEthAddress(key); spenders.Contains(addr) {
+			spenderKeys = append(spenderKeys, key)
+			spenders.Remove(addr)
+		}
+	}
+	if spenders.Len() != 0 {
+		return nil, errReplacementMissingKeys
+	}
+	return vm.newExportTxToOwners(exported.AssetID(), out.Amt, utx.DestinationChain, out.OutputOwners, baseFee, spenderKeys)
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+)
+
+// requireReplaced checks that [replacement] replaced [tx] in the mempool with
+// a gas price bumped by the configured price bump
+func requireReplaced(t *testing.T, vm *VM, tx, replacement *Tx) {
+	require := require.New(t)
+
+	gasPrice, err := vm.mempool.atomicTxGasPrice(tx)
+	require.NoError(err)
+	replacementGasPrice, err := vm.mempool.atomicTxGasPrice(replacement)
+	require.NoError(err)
+	require.GreaterOrEqual(replacementGasPrice, vm.mempool.minReplacementGasPrice(gasPrice))
+
+	_, pending := vm.mempool.GetPendingTx(replacement.ID())
+	require.True(pending)
+	require.False(vm.mempool.has(tx.ID()))
+	_, dropped, _ := vm.mempool.GetTx(tx.ID())
+	require.True(dropped)
+
+	// Only the replacement is gossiped
+	for _, newTx := range vm.mempool.GetNewTxs() {
+		require.NotEqual(tx.ID(), newTx.ID())
+	}
+}
+
+func TestReplaceAtomicTx(t *testing.T) {
+	require := require.New(t)
+
+	importAmount := uint64(50_000_000)
+	issuer, vm, _, _, _ := GenesisVMWithUTXOs(t, true, genesisJSONApricotPhase3, "", "", map[ids.ShortID]uint64{
+		testShortIDAddrs[0]: importAmount,
+	})
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	keys := []*secp256k1.PrivateKey{testKeys[0]}
+
+	// Replace an import tx
+	importTx, err := vm.newImportTx(vm.ctx.XChainID, testEthAddrs[0], initialBaseFee, keys)
+	require.NoError(err)
+	require.NoError(vm.issueTx(importTx, true /*=local*/))
+
+	_, err = vm.replaceAtomicTx(importTx.ID(), nil, []*secp256k1.PrivateKey{testKeys[1]})
+	require.ErrorIs(err, errReplacementMissingKeys)
+
+	importReplacement, err := vm.replaceAtomicTx(importTx.ID(), nil, keys)
+	require.NoError(err)
+	require.Equal(importTx.InputUTXOs(), importReplacement.InputUTXOs())
+	require.Equal(
+		importTx.UnsignedAtomicTx.(*UnsignedImportTx).Outs[0].Address,
+		importReplacement.UnsignedAtomicTx.(*UnsignedImportTx).Outs[0].Address,
+	)
+	requireReplaced(t, vm, importTx, importReplacement)
+
+	_, err = vm.replaceAtomicTx(importTx.ID(), nil, keys)
+	require.ErrorIs(err, errReplacedTxNotPending)
+
+	<-issuer
+	blk, err := vm.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(vm.SetPreference(context.Background(), blk.ID()))
+	require.NoError(blk.Accept(context.Background()))
+
+	// Replace an export tx, the replacement spends the same nonce
+	exportTx, err := vm.newExportTx(vm.ctx.AVAXAssetID, importAmount/2, vm.ctx.XChainID, testShortIDAddrs[1], initialBaseFee, keys)
+	require.NoError(err)
+	require.NoError(vm.issueTx(exportTx, true /*=local*/))
+
+	exportReplacement, err := vm.replaceAtomicTx(exportTx.ID(), nil, keys)
+	require.NoError(err)
+	require.Equal(exportTx.InputUTXOs(), exportReplacement.InputUTXOs())
+	require.Equal(
+		exportTx.UnsignedAtomicTx.(*UnsignedExportTx).ExportedOutputs,
+		exportReplacement.UnsignedAtomicTx.(*UnsignedExportTx).ExportedOutputs,
+	)
+	requireReplaced(t, vm, exportTx, exportReplacement)
+
+	<-issuer
+	blk, err = vm.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(vm.SetPreference(context.Background(), blk.ID()))
+	require.NoError(blk.Accept(context.Background()))
+
+	_, status, _, err := vm.getAtomicTx(exportReplacement.ID())
+	require.NoError(err)
+	require.Equal(Accepted, status)
+}
+
+func TestReplaceAtomicTxAPI(t *testing.T) {
+	require := require.New(t)
+
+	_, vm, _, _, _ := GenesisVM(t, true, genesisJSONApricotPhase3, "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	service := &AvaxAPI{vm}
+
+	reply := &api.JSONTxID{}
+	require.ErrorIs(service.ReplaceAtomicTx(nil, &ReplaceAtomicTxArgs{}, reply), errNilTxID)
+}
@@ -0,0 +1,296 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/message"
+
+	"github.com/ava-labs/coreth/metrics"
+	evmmessage "github.com/ava-labs/coreth/plugin/evm/message"
+)
+
+const (
+	// rewardNotificationCheckInterval is the interval to check for
+	// notifications due to be (re)sent
+	rewardNotificationCheckInterval = time.Second
+	// Bounds of the exponential backoff between attempts of a notification
+	minRewardNotificationBackoff = 5 * time.Second
+	maxRewardNotificationBackoff = 10 * time.Minute
+	// Notifications are dropped after [maxRewardNotificationAttempts] failed
+	// attempts or once they are older than [rewardNotificationExpiry]. The
+	// message carries no data, so the next notification covers the rewards
+	// of a dropped one.
+	maxRewardNotificationAttempts = 30
+	rewardNotificationExpiry      = 24 * time.Hour
+)
+
+var rewardNotificationDBPrefix = []byte("rewardNotificationDB")
+
+// crossChainRequester sends requests to other chains of this node
+type crossChainRequester interface {
+	RequestCrossChain(chainID ids.ID, msg []byte, handler evmmessage.ResponseHandler) error
+}
+
+// rewardNotifierMetrics defines the metrics for the reward notifications
+type rewardNotifierMetrics struct {
+	pending      metrics.Gauge   // Gauge of notifications not acknowledged yet
+	sent         metrics.Counter // Count of all attempts to send a notification
+	failed       metrics.Counter // Count of all failed attempts
+	acknowledged metrics.Counter // Count of all acknowledged notifications
+	dropped      metrics.Counter // Count of all notifications given up
+}
+
+func newRewardNotifierMetrics() *rewardNotifierMetrics {
+	return &rewardNotifierMetrics{
+		pending:      metrics.GetOrRegisterGauge("reward_notifications_pending", nil),
+		sent:         metrics.GetOrRegisterCounter("reward_notifications_sent", nil),
+		failed:       metrics.GetOrRegisterCounter("reward_notifications_failed", nil),
+		acknowledged: metrics.GetOrRegisterCounter("reward_notifications_acknowledged", nil),
+		dropped:      metrics.GetOrRegisterCounter("reward_notifications_dropped", nil),
+	}
+}
+
+// rewardNotification tracks the delivery of the notification about the
+// rewards collected by an UnsignedCollectRewardsTx
+type rewardNotification struct {
+	added       time.Time
+	attempts    int
+	nextAttempt time.Time
+	inFlight    bool
+}
+
+// rewardNotifier notifies the P-Chain about collected fee rewards with a
+// CaminoRewardMessage. Notifications are persisted when the block collecting
+// the rewards is accepted and resent with exponential backoff until the
+// P-Chain responds, also across restarts, or until they are dropped.
+//
+// A notification is only acknowledged by a CrossChainAppResponse, so the
+// P-Chain may receive it several times, e.g. if the response is lost or
+// arrives after the request timed out. The P-Chain must handle repeated
+// CaminoRewardMessages idempotently.
+type rewardNotifier struct {
+	lock sync.Mutex
+
+	// [db] maintains the txIDs of the unacknowledged notifications along
+	// with the time they were added. It is written while accepting blocks
+	// and while handling cross chain responses, both with the snow context
+	// lock held.
+	db     database.Database
+	commit func() error
+
+	requester crossChainRequester
+	request   []byte
+	pending   map[ids.ID]*rewardNotification
+	// [dropped] are removed from [db] with the next accepted block
+	dropped []ids.ID
+	wake    chan struct{}
+	now     func() time.Time
+	metrics *rewardNotifierMetrics
+}
+
+// newRewardNotifier returns a rewardNotifier which loads the unacknowledged
+// notifications from [db]. [commit] makes changes to [db] persistent.
+func newRewardNotifier(db database.Database, commit func() error, requester crossChainRequester) (*rewardNotifier, error) {
+	request, err := message.Codec.Marshal(message.CodecVersion, &message.CaminoRewardMessage{})
+	if err != nil {
+		return nil, err
+	}
+	n := &rewardNotifier{
+		db:        db,
+		commit:    commit,
+		requester: requester,
+		request:   request,
+		pending:   make(map[ids.ID]*rewardNotification),
+		wake:      make(chan struct{}, 1),
+		now:       time.Now,
+		metrics:   newRewardNotifierMetrics(),
+	}
+
+	iter := db.NewIterator()
+	defer iter.Release()
+	for iter.Next() {
+		txID, err := ids.ToID(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		// Notifications persisted without the time they were added expire
+		// relative to the restart
+		added := n.now()
+		if value := iter.Value(); len(value) == wrappers.LongLen {
+			added = time.Unix(int64(binary.BigEndian.Uint64(value)), 0)
+		}
+		n.pending[txID] = &rewardNotification{added: added}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	if len(n.pending) > 0 {
+		log.Info("Loaded unacknowledged reward notifications", "count", len(n.pending))
+	}
+	n.metrics.pending.Update(int64(len(n.pending)))
+	return n, nil
+}
+
+// add persists the notification about the rewards collected by [txID]. It is
+// sent with the next call of send. It is called while accepting a block.
+func (n *rewardNotifier) add(txID ids.ID) error {
+	added := n.now()
+	value := make([]byte, wrappers.LongLen)
+	binary.BigEndian.PutUint64(value, uint64(added.Unix()))
+	if err := n.db.Put(txID[:], value); err != nil {
+		return err
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if _, ok := n.pending[txID]; !ok {
+		n.pending[txID] = &rewardNotification{added: added}
+		n.metrics.pending.Update(int64(len(n.pending)))
+	}
+	return nil
+}
+
+// send wakes up the notifier to send the notifications which are due
+func (n *rewardNotifier) send() {
+	select {
+	case n.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run sends due notifications until [shutdownChan] is closed
+func (n *rewardNotifier) run(shutdownChan <-chan struct{}) {
+	ticker := time.NewTicker(rewardNotificationCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		n.sendDue()
+		select {
+		case <-ticker.C:
+		case <-n.wake:
+		case <-shutdownChan:
+			return
+		}
+	}
+}
+
+// sendDue drops the notifications which ran out of attempts or expired and
+// sends the notifications whose next attempt is due
+func (n *rewardNotifier) sendDue() {
+	n.lock.Lock()
+	now := n.now()
+	due := make([]ids.ID, 0, len(n.pending))
+	for txID, notification := range n.pending {
+		switch {
+		case notification.inFlight:
+		case notification.attempts >= maxRewardNotificationAttempts ||
+			now.Sub(notification.added) >= rewardNotificationExpiry:
+			log.Warn("dropping unacknowledged reward notification", "txID", txID, "attempts", notification.attempts)
+			delete(n.pending, txID)
+			n.dropped = append(n.dropped, txID)
+			n.metrics.dropped.Inc(1)
+		case !now.Before(notification.nextAttempt):
+			notification.inFlight = true
+			due = append(due, txID)
+		}
+	}
+	n.metrics.pending.Update(int64(len(n.pending)))
+	n.lock.Unlock()
+
+	for _, txID := range due {
+		n.metrics.sent.Inc(1)
+		handler := &rewardNotificationHandler{notifier: n, txID: txID}
+		// The P-Chain has the empty chain ID
+		if err := n.requester.RequestCrossChain(constants.PlatformChainID, n.request, handler); err != nil {
+			log.Debug("failed to send reward notification", "txID", txID, "err", err)
+			n.failed(txID)
+		}
+	}
+}
+
+// acknowledged removes the notification about [txID]
+func (n *rewardNotifier) acknowledged(txID ids.ID) error {
+	n.lock.Lock()
+	if _, ok := n.pending[txID]; !ok {
+		n.lock.Unlock()
+		return nil
+	}
+	delete(n.pending, txID)
+	n.metrics.pending.Update(int64(len(n.pending)))
+	n.lock.Unlock()
+
+	n.metrics.acknowledged.Inc(1)
+	log.Debug("reward notification acknowledged", "txID", txID)
+	if err := n.db.Delete(txID[:]); err != nil {
+		return err
+	}
+	return n.commit()
+}
+
+// deleteDropped removes the dropped notifications from the database. It is
+// called while accepting a block, which commits the changes. Notifications
+// dropped before a restart are loaded and dropped again.
+func (n *rewardNotifier) deleteDropped() error {
+	n.lock.Lock()
+	dropped := n.dropped
+	n.dropped = nil
+	n.lock.Unlock()
+
+	for _, txID := range dropped {
+		if err := n.db.Delete(txID[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// failed schedules the next attempt to send the notification about [txID]
+func (n *rewardNotifier) failed(txID ids.ID) {
+	n.metrics.failed.Inc(1)
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	notification, ok := n.pending[txID]
+	if !ok {
+		return
+	}
+	notification.inFlight = false
+	notification.attempts++
+	backoff := maxRewardNotificationBackoff
+	if notification.attempts < 16 {
+		backoff = minRewardNotificationBackoff << (notification.attempts - 1)
+	}
+	if backoff > maxRewardNotificationBackoff {
+		backoff = maxRewardNotificationBackoff
+	}
+	notification.nextAttempt = n.now().Add(backoff)
+	log.Debug("reward notification failed", "txID", txID, "attempts", notification.attempts, "backoff", backoff)
+}
+
+// rewardNotificationHandler handles the P-Chain response to a notification
+type rewardNotificationHandler struct {
+	notifier *rewardNotifier
+	txID     ids.ID
+}
+
+func (h *rewardNotificationHandler) OnResponse([]byte) error {
+	return h.notifier.acknowledged(h.txID)
+}
+
+func (h *rewardNotificationHandler) OnFailure() error {
+	h.notifier.failed(h.txID)
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/coreth/plugin/evm/message"
+)
+
+type testCrossChainRequester struct {
+	err      error
+	chainIDs []ids.ID
+	handlers []message.ResponseHandler
+}
+
+func (r *testCrossChainRequester) RequestCrossChain(chainID ids.ID, _ []byte, handler message.ResponseHandler) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.chainIDs = append(r.chainIDs, chainID)
+	r.handlers = append(r.handlers, handler)
+	return nil
+}
+
+func TestRewardNotifier(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := versiondb.New(baseDB)
+	requester := &testCrossChainRequester{}
+	notifier, err := newRewardNotifier(prefixdb.New(rewardNotificationDBPrefix, db), db.Commit, requester)
+	require.NoError(err)
+	now := time.Unix(1000, 0)
+	notifier.now = func() time.Time { return now }
+
+	txID := ids.GenerateTestID()
+	require.NoError(notifier.add(txID))
+	require.NoError(db.Commit())
+
+	notifier.sendDue()
+	require.Equal([]ids.ID{constants.PlatformChainID}, requester.chainIDs)
+
+	// Notifications in flight are not sent again
+	notifier.sendDue()
+	require.Len(requester.handlers, 1)
+
+	// Failed notifications are retried after the backoff
+	require.NoError(requester.handlers[0].OnFailure())
+	notifier.sendDue()
+	require.Len(requester.handlers, 1)
+	now = now.Add(minRewardNotificationBackoff)
+	notifier.sendDue()
+	require.Len(requester.handlers, 2)
+
+	// The backoff doubles with every attempt
+	require.NoError(requester.handlers[1].OnFailure())
+	now = now.Add(minRewardNotificationBackoff)
+	notifier.sendDue()
+	require.Len(requester.handlers, 2)
+	now = now.Add(minRewardNotificationBackoff)
+	notifier.sendDue()
+	require.Len(requester.handlers, 3)
+
+	// Errors sending the request count as failure
+	requester.err = errors.New("send failed")
+	require.NoError(requester.handlers[2].OnFailure())
+	now = now.Add(maxRewardNotificationBackoff)
+	notifier.sendDue()
+	requester.err = nil
+	notifier.sendDue()
+	require.Len(requester.handlers, 3)
+	require.Equal(4, notifier.pending[txID].attempts)
+
+	// Unacknowledged notifications are loaded on restart
+	restarted, err := newRewardNotifier(prefixdb.New(rewardNotificationDBPrefix, versiondb.New(baseDB)), db.Commit, requester)
+	require.NoError(err)
+	require.Contains(restarted.pending, txID)
+
+	// Acknowledged notifications are removed
+	require.NoError(requester.handlers[2].OnResponse(nil))
+	require.Empty(notifier.pending)
+	restarted, err = newRewardNotifier(prefixdb.New(rewardNotificationDBPrefix, versiondb.New(baseDB)), db.Commit, requester)
+	require.NoError(err)
+	require.Empty(restarted.pending)
+}
+
+func TestRewardNotifierDrop(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := versiondb.New(baseDB)
+	requester := &testCrossChainRequester{}
+	newNotifier := func(now time.Time) *rewardNotifier {
+		notifier, err := newRewardNotifier(prefixdb.New(rewardNotificationDBPrefix, db), db.Commit, requester)
+		require.NoError(err)
+		notifier.now = func() time.Time { return now }
+		return notifier
+	}
+	now := time.Unix(1000, 0)
+	notifier := newNotifier(now)
+
+	failingTxID := ids.GenerateTestID()
+	expiringTxID := ids.GenerateTestID()
+	require.NoError(notifier.add(failingTxID))
+	require.NoError(notifier.add(expiringTxID))
+	require.NoError(db.Commit())
+
+	// The notifications are given up after the last failed attempt
+	notifier.pending[failingTxID].attempts = maxRewardNotificationAttempts - 1
+	notifier.sendDue()
+	require.Len(requester.handlers, 2)
+	for _, handler := range requester.handlers {
+		require.NoError(handler.OnFailure())
+	}
+	now = now.Add(maxRewardNotificationBackoff)
+	notifier.now = func() time.Time { return now }
+	notifier.sendDue()
+	require.NotContains(notifier.pending, failingTxID)
+	require.Contains(notifier.pending, expiringTxID)
+
+	// Dropped notifications are deleted with the next accepted block
+	require.Len(newNotifier(now).pending, 2)
+	require.NoError(notifier.deleteDropped())
+	require.NoError(db.Commit())
+	require.Len(newNotifier(now).pending, 1)
+
+	// The time a notification was added is kept across restarts
+	now = time.Unix(1000, 0).Add(rewardNotificationExpiry)
+	notifier = newNotifier(now)
+	require.Contains(notifier.pending, expiringTxID)
+	notifier.sendDue()
+	require.Empty(notifier.pending)
+	require.NoError(notifier.deleteDropped())
+	require.NoError(db.Commit())
+	require.Empty(newNotifier(now).pending)
+}
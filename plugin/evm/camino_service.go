@@ -0,0 +1,596 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// Max number of fee rewards returned by GetFeeRewards
+	maxGetFeeRewardsLimit = 1024
+	// Max number of txs returned by GetAtomicTxsByAddress
+	maxGetAtomicTxsByAddressLimit = 1024
+)
+
+var (
+	errInvalidHeightRange   = errors.New("end height is before start height")
+	errNoAddress            = errors.New("no address provided")
+	errZeroExportAmount     = errors.New("argument 'amount' must be > 0")
+	errDuplicateExportAsset = errors.New("asset exported more than once")
+)
+
+// GetFeeRewardsArgs are the arguments for GetFeeRewards
+type GetFeeRewardsArgs struct {
+	// StartHeight is the first block height to return fee rewards of
+	StartHeight json.Uint64 `json:"startHeight"`
+	// EndHeight is the last block height to return fee rewards of.
+	// Zero means the last accepted block.
+	EndHeight json.Uint64 `json:"endHeight"`
+	// Limit is the max number of fee rewards to return, at most and by
+	// default [maxGetFeeRewardsLimit]
+	Limit json.Uint32 `json:"limit"`
+}
+
+// GetFeeRewardsReply is the reply of GetFeeRewards
+type GetFeeRewardsReply struct {
+	FeeRewards []*FeeReward `json:"feeRewards"`
+}
+
+// GetFeeRewards returns the fee rewards collected by the blocks accepted in
+// the requested height range
+func (service *AvaxAPI) GetFeeRewards(r *http.Request, args *GetFeeRewardsArgs, reply *GetFeeRewardsReply) error {
+	log.Info("EVM: GetFeeRewards called", "startHeight", args.StartHeight, "endHeight", args.EndHeight)
+
+	endHeight := uint64(args.EndHeight)
+	if endHeight == 0 {
+		endHeight = service.vm.LastAcceptedBlockInternal().Height()
+	}
+	if endHeight < uint64(args.StartHeight) {
+		return errInvalidHeightRange
+	}
+	limit := int(args.Limit)
+	if limit <= 0 || limit > maxGetFeeRewardsLimit {
+		limit = maxGetFeeRewardsLimit
+	}
+
+	feeRewards, err := service.vm.atomicTxRepository.GetFeeRewards(uint64(args.StartHeight), endHeight, limit)
+	if err != nil {
+		return fmt.Errorf("couldn't get fee rewards: %w", err)
+	}
+	reply.FeeRewards = feeRewards
+	return nil
+}
+
+// GetPendingFeeRewardReply is the reply of GetPendingFeeReward. Amounts are
+// denominated in nAVAX.
+type GetPendingFeeRewardReply struct {
+	// Amount of collected fees not paid out yet
+	Amount json.Uint64 `json:"amount"`
+	// ExportAmount would be exported to the P-Chain at the current rates
+	ExportAmount json.Uint64 `json:"exportAmount"`
+	// IncentiveAmount would be paid to the fee reward contract at the
+	// current rates
+	IncentiveAmount json.Uint64 `json:"incentiveAmount"`
+	// NextCollectTime is the earliest block time of the next collection
+	NextCollectTime json.Uint64 `json:"nextCollectTime"`
+	// Collectable is true if the export min amount is reached
+	Collectable bool `json:"collectable"`
+}
+
+// GetPendingFeeReward returns the fees collected but not paid out yet at the
+// last accepted block and the earliest time they can be collected
+func (service *AvaxAPI) GetPendingFeeReward(r *http.Request, _ *struct{}, reply *GetPendingFeeRewardReply) error {
+	log.Info("EVM: GetPendingFeeReward called")
+
+	vm := service.vm
+	block := vm.LastAcceptedBlockInternal().(*Block)
+	state, err := vm.blockChain.StateAt(block.ethBlock.Root())
+	if err != nil {
+		return fmt.Errorf("couldn't get state of last accepted block: %w", err)
+	}
+
+	contracts := vm.chainConfig.CaminoSystemContracts(block.ethBlock.Timestamp())
+	rates := vm.chainConfig.FeeRewardRates(block.ethBlock.Timestamp())
+	amount := pendingReward(contracts, state)
+	_, err = getReward(vm, contracts, rates, state)
+
+	reply.Amount = json.Uint64(amount)
+	reply.ExportAmount = json.Uint64(calculateRate(amount, rates.ExportRate))
+	reply.IncentiveAmount = json.Uint64(calculateRate(amount, rates.IncentivePoolRate))
+	reply.NextCollectTime = json.Uint64(state.GetState(contracts.BlackholeAddress, contracts.FeeRewardTimestampSlot).Big().Uint64())
+	reply.Collectable = err == nil
+	return nil
+}
+
+// AtomicTxIndex identifies an atomic tx in the address index
+type AtomicTxIndex struct {
+	Height json.Uint64 `json:"height"`
+	TxID   ids.ID      `json:"txID"`
+}
+
+// GetAtomicTxsByAddressArgs are the arguments for GetAtomicTxsByAddress
+type GetAtomicTxsByAddressArgs struct {
+	// Address is either a hex encoded EVM address or a bech32 encoded address
+	// of any chain, e.g. the owner of exported UTXOs
+	Address string `json:"address"`
+	// StartIndex is the index of the last tx of the previous page, the txs
+	// following it are returned
+	StartIndex AtomicTxIndex `json:"startIndex"`
+	// Limit is the max number of txs to return, at most and by default
+	// [maxGetAtomicTxsByAddressLimit]
+	Limit    json.Uint32         `json:"limit"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetAtomicTxsByAddressReply is the reply of GetAtomicTxsByAddress
+type GetAtomicTxsByAddressReply struct {
+	Txs        []FormattedTx `json:"txs"`
+	NumFetched json.Uint64   `json:"numFetched"`
+	// EndIndex is the index of the last tx returned, to be passed as
+	// StartIndex to fetch the next page
+	EndIndex AtomicTxIndex `json:"endIndex"`
+}
+
+// GetAtomicTxsByAddress returns the accepted atomic txs touching the address,
+// ordered by the height they were accepted at
+func (service *AvaxAPI) GetAtomicTxsByAddress(r *http.Request, args *GetAtomicTxsByAddressArgs, reply *GetAtomicTxsByAddressReply) error {
+	log.Info("EVM: GetAtomicTxsByAddress called", "address", args.Address, "startHeight", args.StartIndex.Height)
+
+	addr, err := service.vm.parseIndexedAddress(args.Address)
+	if err != nil {
+		return err
+	}
+	limit := int(args.Limit)
+	if limit <= 0 || limit > maxGetAtomicTxsByAddressLimit {
+		limit = maxGetAtomicTxsByAddressLimit
+	}
+
+	txs, heights, err := service.vm.atomicTxRepository.GetByAddress(addr, uint64(args.StartIndex.Height), args.StartIndex.TxID, limit)
+	if err != nil {
+		return fmt.Errorf("couldn't get atomic txs of %s: %w", args.Address, err)
+	}
+
+	reply.Txs = make([]FormattedTx, len(txs))
+	for i, tx := range txs {
+		txStr, err := formatAtomicTx(tx, args.Encoding)
+		if err != nil {
+			return err
+		}
+		height := json.Uint64(heights[i])
+		reply.Txs[i].Tx = txStr
+		reply.Txs[i].Encoding = args.Encoding
+		reply.Txs[i].BlockHeight = &height
+	}
+	reply.NumFetched = json.Uint64(len(txs))
+	reply.EndIndex = args.StartIndex
+	if len(txs) > 0 {
+		reply.EndIndex = AtomicTxIndex{
+			Height: json.Uint64(heights[len(heights)-1]),
+			TxID:   txs[len(txs)-1].ID(),
+		}
+	}
+	return nil
+}
+
+// parseIndexedAddress parses [addrStr] as hex encoded EVM address or as
+// bech32 encoded address of any chain
+func (vm *VM) parseIndexedAddress(addrStr string) (ids.ShortID, error) {
+	if addrStr == "" {
+		return ids.ShortEmpty, errNoAddress
+	}
+	if common.IsHexAddress(addrStr) {
+		return ids.ShortID(common.HexToAddress(addrStr)), nil
+	}
+	_, addr, err := vm.ParseAddress(addrStr)
+	if err != nil {
+		return ids.ShortEmpty, fmt.Errorf("couldn't parse address %q: %w", addrStr, err)
+	}
+	return addr, nil
+}
+
+// ExportAsset is an amount of an asset to export
+type ExportAsset struct {
+	// AssetID of the tokens
+	AssetID string `json:"assetID"`
+	// Amount of the asset to send
+	Amount json.Uint64 `json:"amount"`
+}
+
+// parseExportAmounts returns the amounts to export by asset: [amount] of
+// [assetID] and [assets]
+func (service *AvaxAPI) parseExportAmounts(assetID string, amount json.Uint64, assets []ExportAsset) (map[ids.ID]uint64, error) {
+	if len(assets) == 0 || assetID != "" || amount != 0 {
+		assets = append([]ExportAsset{{AssetID: assetID, Amount: amount}}, assets...)
+	}
+
+	amounts := make(map[ids.ID]uint64, len(assets))
+	for _, asset := range assets {
+		assetID, err := service.parseAssetID(asset.AssetID)
+		if err != nil {
+			return nil, err
+		}
+		if asset.Amount == 0 {
+			return nil, fmt.Errorf("%w: asset %s", errZeroExportAmount, assetID)
+		}
+		if _, ok := amounts[assetID]; ok {
+			return nil, fmt.Errorf("%w: %s", errDuplicateExportAsset, assetID)
+		}
+		amounts[assetID] = uint64(asset.Amount)
+	}
+	return amounts, nil
+}
+
+// GetMultiCoinBalancesArgs are the arguments for GetMultiCoinBalances
+type GetMultiCoinBalancesArgs struct {
+	// Address is the hex encoded EVM address
+	Address string `json:"address"`
+	// AssetIDs are the non-AVAX assets to get the balances of. Empty means
+	// the assets imported to the address.
+	AssetIDs []string `json:"assetIDs"`
+}
+
+// MultiCoinBalance is the balance of a non-AVAX asset
+type MultiCoinBalance struct {
+	AssetID ids.ID       `json:"assetID"`
+	Balance *hexutil.Big `json:"balance"`
+}
+
+// GetMultiCoinBalancesReply is the reply of GetMultiCoinBalances
+type GetMultiCoinBalancesReply struct {
+	Balances []MultiCoinBalance `json:"balances"`
+}
+
+// GetMultiCoinBalances returns the non-zero balances of the address in the
+// non-AVAX assets [args.AssetIDs] at the last accepted block, ordered by
+// assetID. If no assetIDs are provided, the assets imported to the address are
+// looked up in the atomic tx address index. Assets only received through EVM
+// transfers, e.g. by native asset calls, are not indexed and have to be
+// requested explicitly.
+func (service *AvaxAPI) GetMultiCoinBalances(r *http.Request, args *GetMultiCoinBalancesArgs, reply *GetMultiCoinBalancesReply) error {
+	log.Info("EVM: GetMultiCoinBalances called", "address", args.Address)
+
+	if args.Address == "" {
+		return errNoAddress
+	}
+	addr, err := ParseEthAddress(args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse address %q: %w", args.Address, err)
+	}
+
+	var assetIDs set.Set[ids.ID]
+	if len(args.AssetIDs) == 0 {
+		assetIDs, err = service.importedAssetIDs(addr)
+		if err != nil {
+			return fmt.Errorf("couldn't get the assets imported to %s: %w", addr, err)
+		}
+	} else {
+		assetIDs = set.NewSet[ids.ID](len(args.AssetIDs))
+		for _, assetIDStr := range args.AssetIDs {
+			assetID, err := ids.FromString(assetIDStr)
+			if err != nil {
+				return fmt.Errorf("couldn't parse assetID %q: %w", assetIDStr, err)
+			}
+			assetIDs.Add(assetID)
+		}
+	}
+	assetIDs.Remove(service.vm.ctx.AVAXAssetID)
+
+	state, err := service.vm.blockChain.StateAt(service.vm.blockChain.LastConsensusAcceptedBlock().Root())
+	if err != nil {
+		return err
+	}
+	sortedAssetIDs := assetIDs.List()
+	utils.Sort(sortedAssetIDs)
+	reply.Balances = make([]MultiCoinBalance, 0, len(sortedAssetIDs))
+	for _, assetID := range sortedAssetIDs {
+		balance := state.GetBalanceMultiCoin(addr, common.Hash(assetID))
+		if balance.Sign() == 0 {
+			continue
+		}
+		reply.Balances = append(reply.Balances, MultiCoinBalance{
+			AssetID: assetID,
+			Balance: (*hexutil.Big)(balance),
+		})
+	}
+	return nil
+}
+
+// importedAssetIDs returns the assets of the outputs of the accepted import
+// txs crediting [addr]
+func (service *AvaxAPI) importedAssetIDs(addr common.Address) (set.Set[ids.ID], error) {
+	var (
+		assetIDs    = set.Set[ids.ID]{}
+		startHeight uint64
+		startTxID   ids.ID
+	)
+	for {
+		txs, heights, err := service.vm.atomicTxRepository.GetByAddress(ids.ShortID(addr), startHeight, startTxID, maxGetAtomicTxsByAddressLimit)
+		if err != nil {
+			return nil, err
+		}
+		for _, tx := range txs {
+			utx, ok := tx.UnsignedAtomicTx.(*UnsignedImportTx)
+			if !ok {
+				continue
+			}
+			for _, out := range utx.Outs {
+				if out.Address == addr {
+					assetIDs.Add(out.AssetID)
+				}
+			}
+		}
+		if len(txs) < maxGetAtomicTxsByAddressLimit {
+			return assetIDs, nil
+		}
+		startHeight, startTxID = heights[len(heights)-1], txs[len(txs)-1].ID()
+	}
+}
+
+// GetMempoolContentReply is the reply of GetMempoolContent
+type GetMempoolContentReply struct {
+	Txs []*MempoolTx `json:"txs"`
+}
+
+// GetMempoolContent returns the txs in the atomic mempool and the recently
+// discarded txs
+func (service *AvaxAPI) GetMempoolContent(r *http.Request, _ *struct{}, reply *GetMempoolContentReply) error {
+	log.Info("EVM: GetMempoolContent called")
+
+	reply.Txs = service.vm.mempool.Content()
+	return nil
+}
+
+// GetMempoolStatus returns the number of txs in the atomic mempool by status
+func (service *AvaxAPI) GetMempoolStatus(r *http.Request, _ *struct{}, reply *MempoolStatus) error {
+	log.Info("EVM: GetMempoolStatus called")
+
+	*reply = service.vm.mempool.Status()
+	return nil
+}
+
+// ReplaceAtomicTxArgs are the arguments to ReplaceAtomicTx
+type ReplaceAtomicTxArgs struct {
+	api.UserPass
+
+	// ID of the pending import or export tx to replace
+	TxID ids.ID `json:"txID"`
+
+	// Fee that should be used when creating the replacement, it is raised to
+	// bump the gas price of the replaced tx by the configured price bump
+	BaseFee *hexutil.Big `json:"baseFee"`
+}
+
+// ReplaceAtomicTx issues a tx spending the same UTXOs as the pending import or
+// export tx [args.TxID] with a higher fee, which replaces it in the mempool
+func (service *AvaxAPI) ReplaceAtomicTx(_ *http.Request, args *ReplaceAtomicTxArgs, response *api.JSONTxID) error {
+	log.Info("EVM: ReplaceAtomicTx called", "txID", args.TxID)
+
+	if args.TxID == ids.Empty {
+		return errNilTxID
+	}
+
+	// Get the user's info
+	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+	if err != nil {
+		return fmt.Errorf("couldn't get user '%s': %w", args.Username, err)
+	}
+	defer db.Close()
+
+	user := user{
+		secpFactory: &service.vm.secpFactory,
+		db:          db,
+	}
+	privKeys, err := user.getKeys()
+	if err != nil { // Get keys
+		return fmt.Errorf("couldn't get keys controlled by the user: %w", err)
+	}
+
+	var baseFee *big.Int
+	if args.BaseFee != nil {
+		baseFee = args.BaseFee.ToInt()
+	}
+
+	tx, err := service.vm.replaceAtomicTx(args.TxID, baseFee, privKeys)
+	if err != nil {
+		return err
+	}
+	response.TxID = tx.ID()
+	return nil
+}
+
+// BuildImportArgs are the arguments to BuildImport
+type BuildImportArgs struct {
+	// Fee that should be used when creating the tx
+	BaseFee *hexutil.Big `json:"baseFee"`
+
+	// Chain the funds are coming from
+	SourceChain string `json:"sourceChain"`
+
+	// The address that will receive the imported funds
+	To string `json:"to"`
+
+	// Addresses owning the UTXOs to import
+	Addresses []string `json:"addresses"`
+
+	// Encoding of the returned tx
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// BuildAtomicTxReply is the reply of BuildImport and BuildExport
+type BuildAtomicTxReply struct {
+	// The tx without credentials in [Encoding]
+	api.FormattedTx
+	// Inputs of the tx to sign, in order
+	Inputs []AtomicTxInput `json:"inputs"`
+}
+
+// BuildImport returns an unsigned tx importing the UTXOs owned by
+// [args.Addresses] from the X/P-Chain, along with the inputs to sign.
+// Once signed, the tx is issued with IssueTx.
+func (service *AvaxAPI) BuildImport(_ *http.Request, args *BuildImportArgs, reply *BuildAtomicTxReply) error {
+	log.Info("EVM: BuildImport called")
+
+	if len(args.Addresses) == 0 {
+		return errNoAddresses
+	}
+	if len(args.Addresses) > maxGetUTXOsAddrs {
+		return fmt.Errorf("number of addresses given, %d, exceeds maximum, %d", len(args.Addresses), maxGetUTXOsAddrs)
+	}
+
+	chainID, err := service.vm.ctx.BCLookup.Lookup(args.SourceChain)
+	if err != nil {
+		return fmt.Errorf("problem parsing chainID %q: %w", args.SourceChain, err)
+	}
+
+	to, err := ParseEthAddress(args.To)
+	if err != nil { // Parse address
+		return fmt.Errorf("couldn't parse argument 'to' to an address: %w", err)
+	}
+
+	addrSet := set.NewSet[ids.ShortID](len(args.Addresses))
+	for _, addrStr := range args.Addresses {
+		addr, err := service.vm.ParseLocalAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse address %q: %w", addrStr, err)
+		}
+		addrSet.Add(addr)
+	}
+
+	var baseFee *big.Int
+	if args.BaseFee == nil {
+		// Get the base fee to use
+		baseFee, err = service.vm.estimateBaseFee(context.Background())
+		if err != nil {
+			return err
+		}
+	} else {
+		baseFee = args.BaseFee.ToInt()
+	}
+
+	tx, inputs, err := service.vm.buildImportTx(chainID, to, baseFee, addrSet)
+	if err != nil {
+		return err
+	}
+	return reply.set(tx, inputs, args.Encoding)
+}
+
+// BuildExportArgs are the arguments to BuildExport. Unlike ExportArgs, they
+// do not hold any keystore credentials.
+type BuildExportArgs struct {
+	// Fee that should be used when creating the tx
+	BaseFee *hexutil.Big `json:"baseFee"`
+
+	// Amount of [AssetID] to send
+	Amount json.Uint64 `json:"amount"`
+	// AssetID of the tokens
+	AssetID string `json:"assetID"`
+	// Assets exported in the same tx in addition to [Amount] of [AssetID],
+	// which can be omitted if [Assets] are given
+	Assets []ExportAsset `json:"assets,omitempty"`
+
+	// Address receiving the exported funds, which includes the chainID of
+	// the destination chain
+	To string `json:"to"`
+	// Owners of the exported outputs, used instead of [To]
+	Owners *ExportOwners `json:"owners,omitempty"`
+
+	// Addresses paying the fee and providing the exported funds
+	From []string `json:"from"`
+	// Strategy to select the addresses whose funds are spent
+	CoinSelection CoinSelection `json:"coinSelection,omitempty"`
+
+	// Encoding of the returned tx
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// BuildExport returns an unsigned tx exporting assets from [args.From] to the
+// X/P-Chain, along with the inputs to sign.
+// Once signed, the tx is issued with IssueTx.
+func (service *AvaxAPI) BuildExport(_ *http.Request, args *BuildExportArgs, reply *BuildAtomicTxReply) error {
+	log.Info("EVM: BuildExport called")
+
+	if len(args.From) == 0 {
+		return errNoAddresses
+	}
+	from := make([]common.Address, len(args.From))
+	for i, addrStr := range args.From {
+		addr, err := ParseEthAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse address %q: %w", addrStr, err)
+		}
+		from[i] = addr
+	}
+
+	amounts, err := service.parseExportAmounts(args.AssetID, args.Amount, args.Assets)
+	if err != nil {
+		return err
+	}
+	if err := args.CoinSelection.Verify(); err != nil {
+		return err
+	}
+
+	chainID, owners, err := service.parseExportOwners(args.To, args.Owners)
+	if err != nil {
+		return err
+	}
+
+	var baseFee *big.Int
+	if args.BaseFee == nil {
+		// Get the base fee to use
+		baseFee, err = service.vm.estimateBaseFee(context.Background())
+		if err != nil {
+			return err
+		}
+	} else {
+		baseFee = args.BaseFee.ToInt()
+	}
+
+	tx, inputs, err := service.vm.buildExportTx(amounts, chainID, owners, baseFee, from, args.CoinSelection)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+	return reply.set(tx, inputs, args.Encoding)
+}
+
+// set sets [reply] to the unsigned [tx] in [encoding] and its [inputs]
+func (reply *BuildAtomicTxReply) set(tx *Tx, inputs []AtomicTxInput, encoding formatting.Encoding) error {
+	txStr, err := formatAtomicTx(tx, encoding)
+	if err != nil {
+		return err
+	}
+	reply.Tx = txStr
+	reply.Encoding = encoding
+	reply.Inputs = inputs
+	return nil
+}
+
+// AvaxAdminAPI offers the avax API calls which are served by the admin
+// endpoint only
+type AvaxAdminAPI struct{ vm *VM }
+
+// DropAtomicTx discards a tx from the atomic mempool, e.g. to unblock a
+// conflicting tx
+func (service *AvaxAdminAPI) DropAtomicTx(r *http.Request, args *api.JSONTxID, _ *api.EmptyReply) error {
+	log.Info("EVM: DropAtomicTx called", "txID", args.TxID)
+
+	if args.TxID == ids.Empty {
+		return errNilTxID
+	}
+	return service.vm.mempool.DropTx(args.TxID)
+}
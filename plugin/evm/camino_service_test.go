@@ -0,0 +1,268 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExportOwners(t *testing.T) {
+	require := require.New(t)
+
+	_, vm, _, _, _ := GenesisVM(t, true, genesisJSONApricotPhase0, "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	service := &AvaxAPI{vm}
+
+	format := func(chainID ids.ID, addr ids.ShortID) string {
+		addrStr, err := vm.FormatAddress(chainID, addr)
+		require.NoError(err)
+		return addrStr
+	}
+	addr0 := format(vm.ctx.XChainID, testShortIDAddrs[0])
+	addr1 := format(vm.ctx.XChainID, testShortIDAddrs[1])
+
+	sortedAddrs := []ids.ShortID{testShortIDAddrs[0], testShortIDAddrs[1]}
+	if testShortIDAddrs[1].Less(testShortIDAddrs[0]) {
+		sortedAddrs[0], sortedAddrs[1] = sortedAddrs[1], sortedAddrs[0]
+	}
+
+	tests := map[string]struct {
+		args    ExportAVAXArgs
+		owners  secp256k1fx.OutputOwners
+		wantErr bool
+	}{
+		"single address": {
+			args: ExportAVAXArgs{To: addr0},
+			owners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{testShortIDAddrs[0]},
+			},
+		},
+		"multisig owners": {
+			args: ExportAVAXArgs{Owners: &ExportOwners{
+				Locktime:  10,
+				Threshold: 2,
+				Addresses: []string{addr1, addr0},
+			}},
+			owners: secp256k1fx.OutputOwners{
+				Locktime:  10,
+				Threshold: 2,
+				Addrs:     sortedAddrs,
+			},
+		},
+		"to and owners": {
+			args: ExportAVAXArgs{To: addr0, Owners: &ExportOwners{
+				Threshold: 1,
+				Addresses: []string{addr1},
+			}},
+			wantErr: true,
+		},
+		"threshold too high": {
+			args: ExportAVAXArgs{Owners: &ExportOwners{
+				Threshold: 3,
+				Addresses: []string{addr0, addr1},
+			}},
+			wantErr: true,
+		},
+		"duplicate address": {
+			args: ExportAVAXArgs{Owners: &ExportOwners{
+				Threshold: 1,
+				Addresses: []string{addr0, addr0},
+			}},
+			wantErr: true,
+		},
+		"different chains": {
+			args: ExportAVAXArgs{Owners: &ExportOwners{
+				Threshold: json.Uint32(1),
+				Addresses: []string{addr0, format(vm.ctx.ChainID, testShortIDAddrs[1])},
+			}},
+			wantErr: true,
+		},
+		"no addresses": {
+			args:    ExportAVAXArgs{Owners: &ExportOwners{Threshold: 1}},
+			wantErr: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			chainID, owners, err := service.parseExportOwners(test.args.To, test.args.Owners)
+			if test.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(vm.ctx.XChainID, chainID)
+			require.Equal(test.owners, owners)
+		})
+	}
+}
+
+func TestGetFeeRewards(t *testing.T) {
+	require := require.New(t)
+
+	amount := uint64(100_000_000_000)
+	genesisJSON := prepareGenesis(amount, 0, 0, 0, 0, 10_000_000_000, 3600)
+	issuer, vm, _, _, _ := GenesisVM(t, true, genesisJSON, "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	service := &AvaxAPI{vm}
+
+	pending := &GetPendingFeeRewardReply{}
+	require.NoError(service.GetPendingFeeReward(nil, nil, pending))
+	require.Equal(GetPendingFeeRewardReply{
+		Amount:          json.Uint64(amount),
+		ExportAmount:    json.Uint64(amount * 3 / 10),
+		IncentiveAmount: json.Uint64(amount * 3 / 10),
+		NextCollectTime: 0,
+		Collectable:     true,
+	}, *pending)
+
+	parent := vm.LastAcceptedBlockInternal().(*Block)
+	tx, err := vm.NewCollectRewardsTx(parent.ethBlock.Hash(), amount, 0)
+	require.NoError(err)
+	require.NoError(vm.issueTx(tx, true /*=local*/))
+	<-issuer
+
+	blk, err := vm.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(vm.SetPreference(context.Background(), blk.ID()))
+	require.NoError(blk.Accept(context.Background()))
+
+	reply := &GetFeeRewardsReply{}
+	require.NoError(service.GetFeeRewards(nil, &GetFeeRewardsArgs{}, reply))
+	require.Len(reply.FeeRewards, 1)
+	require.Equal(tx.ID(), reply.FeeRewards[0].TxID)
+	require.Equal(json.Uint64(blk.Height()), reply.FeeRewards[0].Height)
+	require.Equal(json.Uint64(amount), reply.FeeRewards[0].Amount)
+	require.Equal(json.Uint64(amount*3/10), reply.FeeRewards[0].ExportAmount)
+	require.Equal(json.Uint64(3600), reply.FeeRewards[0].NextEarliestCollectTime)
+
+	require.ErrorIs(service.GetFeeRewards(nil, &GetFeeRewardsArgs{StartHeight: 2, EndHeight: 1}, reply), errInvalidHeightRange)
+
+	// The fees left after the collection are not collectable before the next collect time
+	require.NoError(service.GetPendingFeeReward(nil, nil, pending))
+	require.Equal(json.Uint64(3600), pending.NextCollectTime)
+	require.False(pending.Collectable)
+}
+
+func TestGetAtomicTxsByAddress(t *testing.T) {
+	require := require.New(t)
+
+	_, vm, _, _, _ := GenesisVM(t, true, genesisJSONApricotPhase0, "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	service := &AvaxAPI{vm}
+
+	evmAddr := common.Address{1}
+	owner := ids.ShortID{2}
+	importTx := newTestAddressImportTx(t, evmAddr, 1_000)
+	exportTx := newTestAddressExportTx(t, evmAddr, owner, 500)
+	require.NoError(vm.atomicTxRepository.Write(1, []*Tx{importTx}))
+	require.NoError(vm.atomicTxRepository.Write(2, []*Tx{exportTx}))
+
+	reply := &GetAtomicTxsByAddressReply{}
+	require.NoError(service.GetAtomicTxsByAddress(nil, &GetAtomicTxsByAddressArgs{
+		Address:  evmAddr.Hex(),
+		Limit:    1,
+		Encoding: formatting.Hex,
+	}, reply))
+	require.Equal(json.Uint64(1), reply.NumFetched)
+	require.Equal(AtomicTxIndex{Height: 1, TxID: importTx.ID()}, reply.EndIndex)
+	txStr, err := formatting.Encode(formatting.Hex, importTx.SignedBytes())
+	require.NoError(err)
+	require.Equal(txStr, reply.Txs[0].Tx)
+
+	require.NoError(service.GetAtomicTxsByAddress(nil, &GetAtomicTxsByAddressArgs{
+		Address:    evmAddr.Hex(),
+		StartIndex: reply.EndIndex,
+		Encoding:   formatting.Hex,
+	}, reply))
+	require.Equal(json.Uint64(1), reply.NumFetched)
+	require.Equal(AtomicTxIndex{Height: 2, TxID: exportTx.ID()}, reply.EndIndex)
+
+	ownerStr, err := vm.FormatAddress(vm.ctx.XChainID, owner)
+	require.NoError(err)
+	require.NoError(service.GetAtomicTxsByAddress(nil, &GetAtomicTxsByAddressArgs{
+		Address:  ownerStr,
+		Encoding: formatting.Hex,
+	}, reply))
+	require.Equal(json.Uint64(1), reply.NumFetched)
+	require.Equal(json.Uint64(2), *reply.Txs[0].BlockHeight)
+
+	require.ErrorIs(service.GetAtomicTxsByAddress(nil, &GetAtomicTxsByAddressArgs{}, reply), errNoAddress)
+	require.Error(service.GetAtomicTxsByAddress(nil, &GetAtomicTxsByAddressArgs{Address: "invalid"}, reply))
+}
+
+func TestParseExportAmounts(t *testing.T) {
+	require := require.New(t)
+
+	_, vm, _, _, _ := GenesisVM(t, true, genesisJSONApricotPhase0, "", "")
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	service := &AvaxAPI{vm}
+	assetID := ids.ID{1}
+
+	tests := map[string]struct {
+		args    ExportArgs
+		amounts map[ids.ID]uint64
+		wantErr error
+	}{
+		"single asset": {
+			args:    ExportArgs{ExportAVAXArgs: ExportAVAXArgs{Amount: 10}, AssetID: "AVAX"},
+			amounts: map[ids.ID]uint64{vm.ctx.AVAXAssetID: 10},
+		},
+		"assets only": {
+			args: ExportArgs{Assets: []ExportAsset{
+				{AssetID: "AVAX", Amount: 10},
+				{AssetID: assetID.String(), Amount: 20},
+			}},
+			amounts: map[ids.ID]uint64{vm.ctx.AVAXAssetID: 10, assetID: 20},
+		},
+		"asset and assets": {
+			args: ExportArgs{
+				ExportAVAXArgs: ExportAVAXArgs{Amount: 10},
+				AssetID:        "AVAX",
+				Assets:         []ExportAsset{{AssetID: assetID.String(), Amount: 20}},
+			},
+			amounts: map[ids.ID]uint64{vm.ctx.AVAXAssetID: 10, assetID: 20},
+		},
+		"zero amount": {
+			args:    ExportArgs{AssetID: "AVAX"},
+			wantErr: errZeroExportAmount,
+		},
+		"zero asset amount": {
+			args:    ExportArgs{Assets: []ExportAsset{{AssetID: assetID.String()}}},
+			wantErr: errZeroExportAmount,
+		},
+		"duplicate asset": {
+			args: ExportArgs{
+				ExportAVAXArgs: ExportAVAXArgs{Amount: 10},
+				AssetID:        vm.ctx.AVAXAssetID.String(),
+				Assets:         []ExportAsset{{AssetID: "AVAX", Amount: 20}},
+			},
+			wantErr: errDuplicateExportAsset,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			amounts, err := service.parseExportAmounts(tt.args.AssetID, tt.args.Amount, tt.args.Assets)
+			require.ErrorIs(err, tt.wantErr)
+			require.Equal(tt.amounts, amounts)
+		})
+	}
+}
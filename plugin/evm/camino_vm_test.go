@@ -17,8 +17,10 @@ import (
 	"github.com/ava-labs/coreth/core"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/rpc"
 	"github.com/ava-labs/coreth/trie"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/stretchr/testify/assert"
 )
@@ -267,3 +269,36 @@ func DisabledTestSunrisePhase0OrphanBlock(t *testing.T) {
 	checks := vm.DeferedChecks.deferedChecks
 	assert.Equal(t, checks[ids.ID(common.Hash{})], orphanBlock)
 }
+
+func TestCaminoAPI(t *testing.T) {
+	tests := map[string]struct {
+		configJSON string
+		enabled    bool
+	}{
+		"enabled by default": {configJSON: "", enabled: true},
+		"disabled":           {configJSON: `{"camino-api-enabled": false}`, enabled: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, vm, _, _, _ := GenesisVM(t, true, genesisJSONSunrisePhase0, test.configJSON, "")
+			defer func() {
+				assert.NoError(t, vm.Shutdown(context.Background()))
+			}()
+
+			handlers, err := vm.CreateHandlers(context.Background())
+			assert.NoError(t, err)
+			client := rpc.DialInProc(handlers[ethRPCEndpoint].Handler.(*rpc.Server))
+			defer client.Close()
+
+			// The camino service is served next to the eth APIs
+			var baseFee hexutil.Big
+			err = client.Call(&baseFee, "camino_getBaseFee", "latest")
+			if !test.enabled {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, sunriseBaseFee, baseFee.ToInt())
+		})
+	}
+}
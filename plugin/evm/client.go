@@ -33,12 +33,27 @@ type Client interface {
 	Import(ctx context.Context, userPass api.UserPass, to string, sourceChain string) (ids.ID, error)
 	ExportAVAX(ctx context.Context, userPass api.UserPass, amount uint64, to string) (ids.ID, error)
 	Export(ctx context.Context, userPass api.UserPass, amount uint64, to string, assetID string) (ids.ID, error)
+	ExportToOwners(ctx context.Context, userPass api.UserPass, amount uint64, locktime uint64, threshold uint32, to []string, assetID string) (ids.ID, error)
+	ExportMultiAsset(ctx context.Context, userPass api.UserPass, assets []ExportAsset, to string, coinSelection CoinSelection) (ids.ID, error)
+	GetMultiCoinBalances(ctx context.Context, addr string, assetIDs []string) ([]MultiCoinBalance, error)
+	GetFeeRewards(ctx context.Context, startHeight uint64, endHeight uint64, limit uint32) ([]*FeeReward, error)
+	GetPendingFeeReward(ctx context.Context) (*GetPendingFeeRewardReply, error)
+	GetAtomicTxsByAddress(ctx context.Context, addr string, startIndex AtomicTxIndex, limit uint32) ([][]byte, AtomicTxIndex, error)
+	GetMempoolContent(ctx context.Context) ([]*MempoolTx, error)
+	GetMempoolStatus(ctx context.Context) (*MempoolStatus, error)
+	DropAtomicTx(ctx context.Context, txID ids.ID) error
+	ReplaceAtomicTx(ctx context.Context, userPass api.UserPass, txID ids.ID) (ids.ID, error)
+	BuildImport(ctx context.Context, to string, sourceChain string, addrs []string) (*Tx, []AtomicTxInput, error)
+	BuildExport(ctx context.Context, from []string, assets []ExportAsset, to string, coinSelection CoinSelection) (*Tx, []AtomicTxInput, error)
 	StartCPUProfiler(ctx context.Context) error
 	StopCPUProfiler(ctx context.Context) error
 	MemoryProfile(ctx context.Context) error
 	LockProfile(ctx context.Context) error
 	SetLogLevel(ctx context.Context, level log.Lvl) error
 	GetVMConfig(ctx context.Context) (*Config, error)
+	StartStatePruning(ctx context.Context) error
+	StopStatePruning(ctx context.Context) error
+	StatePruningStatus(ctx context.Context) (*StatePruningStatusReply, error)
 }
 
 // Client implementation for interacting with EVM [chain]
@@ -199,6 +214,201 @@ func (c *client) Export(
 	return res.TxID, err
 }
 
+// ExportToOwners sends an asset from this chain to the P/X-Chain into an
+// output spendable by [threshold] of the addresses [to] after [locktime].
+// All addresses of [to] must be on the same chain.
+// Returns the ID of the newly created atomic transaction
+func (c *client) ExportToOwners(
+	ctx context.Context,
+	user api.UserPass,
+	amount uint64,
+	locktime uint64,
+	threshold uint32,
+	to []string,
+	assetID string,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "avax.export", &ExportArgs{
+		ExportAVAXArgs: ExportAVAXArgs{
+			UserPass: user,
+			Amount:   cjson.Uint64(amount),
+			Owners: &ExportOwners{
+				Locktime:  cjson.Uint64(locktime),
+				Threshold: cjson.Uint32(threshold),
+				Addresses: to,
+			},
+		},
+		AssetID: assetID,
+	}, res)
+	return res.TxID, err
+}
+
+// ExportMultiAsset sends several assets from this chain to the P/X-Chain in
+// one tx, the addresses whose funds are spent are selected with [coinSelection].
+// Returns the ID of the newly created atomic transaction
+func (c *client) ExportMultiAsset(
+	ctx context.Context,
+	user api.UserPass,
+	assets []ExportAsset,
+	to string,
+	coinSelection CoinSelection,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "avax.export", &ExportArgs{
+		ExportAVAXArgs: ExportAVAXArgs{
+			UserPass: user,
+			To:       to,
+		},
+		Assets:        assets,
+		CoinSelection: coinSelection,
+	}, res)
+	return res.TxID, err
+}
+
+// BuildImport returns an unsigned tx importing the UTXOs owned by [addrs]
+// from [sourceChain] to [to], along with the inputs to sign with
+// SignAtomicTx. The signed tx is issued with IssueTx.
+func (c *client) BuildImport(ctx context.Context, to string, sourceChain string, addrs []string) (*Tx, []AtomicTxInput, error) {
+	res := &BuildAtomicTxReply{}
+	err := c.requester.SendRequest(ctx, "avax.buildImport", &BuildImportArgs{
+		To:          to,
+		SourceChain: sourceChain,
+		Addresses:   addrs,
+		Encoding:    formatting.Hex,
+	}, res)
+	if err != nil {
+		return nil, nil, err
+	}
+	return parseBuiltAtomicTx(res)
+}
+
+// BuildExport returns an unsigned tx exporting [assets] from [from] to the
+// P/X-Chain address [to], along with the inputs to sign with SignAtomicTx.
+// The signed tx is issued with IssueTx.
+func (c *client) BuildExport(
+	ctx context.Context,
+	from []string,
+	assets []ExportAsset,
+	to string,
+	coinSelection CoinSelection,
+) (*Tx, []AtomicTxInput, error) {
+	res := &BuildAtomicTxReply{}
+	err := c.requester.SendRequest(ctx, "avax.buildExport", &BuildExportArgs{
+		Assets:        assets,
+		To:            to,
+		From:          from,
+		CoinSelection: coinSelection,
+		Encoding:      formatting.Hex,
+	}, res)
+	if err != nil {
+		return nil, nil, err
+	}
+	return parseBuiltAtomicTx(res)
+}
+
+// parseBuiltAtomicTx returns the unsigned tx and the inputs of [res]
+func parseBuiltAtomicTx(res *BuildAtomicTxReply) (*Tx, []AtomicTxInput, error) {
+	txBytes, err := formatting.Decode(res.Encoding, res.Tx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't decode tx: %w", err)
+	}
+	tx := &Tx{}
+	if _, err := Codec.Unmarshal(txBytes, tx); err != nil {
+		return nil, nil, fmt.Errorf("couldn't parse tx: %w", err)
+	}
+	return tx, res.Inputs, nil
+}
+
+// GetMultiCoinBalances returns the non-zero balances of [addr] in the non-AVAX
+// assets [assetIDs], or in the assets imported to [addr] if [assetIDs] is empty
+func (c *client) GetMultiCoinBalances(ctx context.Context, addr string, assetIDs []string) ([]MultiCoinBalance, error) {
+	res := &GetMultiCoinBalancesReply{}
+	err := c.requester.SendRequest(ctx, "avax.getMultiCoinBalances", &GetMultiCoinBalancesArgs{
+		Address:  addr,
+		AssetIDs: assetIDs,
+	}, res)
+	return res.Balances, err
+}
+
+// GetFeeRewards returns up to [limit] fee rewards collected by the blocks
+// accepted at heights [startHeight] to [endHeight] (0 = last accepted)
+func (c *client) GetFeeRewards(ctx context.Context, startHeight uint64, endHeight uint64, limit uint32) ([]*FeeReward, error) {
+	res := &GetFeeRewardsReply{}
+	err := c.requester.SendRequest(ctx, "avax.getFeeRewards", &GetFeeRewardsArgs{
+		StartHeight: cjson.Uint64(startHeight),
+		EndHeight:   cjson.Uint64(endHeight),
+		Limit:       cjson.Uint32(limit),
+	}, res)
+	return res.FeeRewards, err
+}
+
+// GetPendingFeeReward returns the fees not paid out yet and the earliest
+// time of the next fee reward collection
+func (c *client) GetPendingFeeReward(ctx context.Context) (*GetPendingFeeRewardReply, error) {
+	res := &GetPendingFeeRewardReply{}
+	err := c.requester.SendRequest(ctx, "avax.getPendingFeeReward", struct{}{}, res)
+	return res, err
+}
+
+// GetAtomicTxsByAddress returns the byte representation of up to [limit]
+// accepted atomic txs touching [addr] following [startIndex] and the index of
+// the last tx returned
+func (c *client) GetAtomicTxsByAddress(ctx context.Context, addr string, startIndex AtomicTxIndex, limit uint32) ([][]byte, AtomicTxIndex, error) {
+	res := &GetAtomicTxsByAddressReply{}
+	err := c.requester.SendRequest(ctx, "avax.getAtomicTxsByAddress", &GetAtomicTxsByAddressArgs{
+		Address:    addr,
+		StartIndex: startIndex,
+		Limit:      cjson.Uint32(limit),
+		Encoding:   formatting.Hex,
+	}, res)
+	if err != nil {
+		return nil, AtomicTxIndex{}, err
+	}
+
+	txs := make([][]byte, len(res.Txs))
+	for i, tx := range res.Txs {
+		txBytes, err := formatting.Decode(formatting.Hex, tx.Tx)
+		if err != nil {
+			return nil, AtomicTxIndex{}, err
+		}
+		txs[i] = txBytes
+	}
+	return txs, res.EndIndex, nil
+}
+
+// GetMempoolContent returns the txs in the atomic mempool and the recently
+// discarded txs
+func (c *client) GetMempoolContent(ctx context.Context) ([]*MempoolTx, error) {
+	res := &GetMempoolContentReply{}
+	err := c.requester.SendRequest(ctx, "avax.getMempoolContent", struct{}{}, res)
+	return res.Txs, err
+}
+
+// GetMempoolStatus returns the number of txs in the atomic mempool by status
+func (c *client) GetMempoolStatus(ctx context.Context) (*MempoolStatus, error) {
+	res := &MempoolStatus{}
+	err := c.requester.SendRequest(ctx, "avax.getMempoolStatus", struct{}{}, res)
+	return res, err
+}
+
+// DropAtomicTx discards [txID] from the atomic mempool, requires the admin API
+func (c *client) DropAtomicTx(ctx context.Context, txID ids.ID) error {
+	return c.adminRequester.SendRequest(ctx, "avax.dropAtomicTx", &api.JSONTxID{
+		TxID: txID,
+	}, &api.EmptyReply{})
+}
+
+// ReplaceAtomicTx replaces the pending atomic tx [txID] by a tx spending the
+// same UTXOs with a higher fee. Returns the ID of the replacement.
+func (c *client) ReplaceAtomicTx(ctx context.Context, user api.UserPass, txID ids.ID) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "avax.replaceAtomicTx", &ReplaceAtomicTxArgs{
+		UserPass: user,
+		TxID:     txID,
+	}, res)
+	return res.TxID, err
+}
+
 func (c *client) StartCPUProfiler(ctx context.Context) error {
 	return c.adminRequester.SendRequest(ctx, "admin.startCPUProfiler", struct{}{}, &api.EmptyReply{})
 }
@@ -228,3 +438,20 @@ func (c *client) GetVMConfig(ctx context.Context) (*Config, error) {
 	err := c.adminRequester.SendRequest(ctx, "admin.getVMConfig", struct{}{}, res)
 	return res.Config, err
 }
+
+// StartStatePruning starts or resumes the online pruning of the stale state
+func (c *client) StartStatePruning(ctx context.Context) error {
+	return c.adminRequester.SendRequest(ctx, "admin.startStatePruning", struct{}{}, &api.EmptyReply{})
+}
+
+// StopStatePruning interrupts the online pruning of the stale state
+func (c *client) StopStatePruning(ctx context.Context) error {
+	return c.adminRequester.SendRequest(ctx, "admin.stopStatePruning", struct{}{}, &api.EmptyReply{})
+}
+
+// StatePruningStatus returns the progress of the online pruning of the stale state
+func (c *client) StatePruningStatus(ctx context.Context) (*StatePruningStatusReply, error) {
+	res := &StatePruningStatusReply{}
+	err := c.adminRequester.SendRequest(ctx, "admin.statePruningStatus", struct{}{}, res)
+	return res, err
+}
@@ -27,6 +27,7 @@ const (
 	defaultRpcGasCap                                  = 50_000_000 // Default to 50M Gas Limit
 	defaultRpcTxFeeCap                                = 100        // 100 AVAX
 	defaultMetricsExpensiveEnabled                    = true
+	defaultCaminoAPIEnabled                           = true
 	defaultApiMaxDuration                             = 0 // Default to no maximum API call duration
 	defaultWsCpuRefillRate                            = 0 // Default to no maximum WS CPU usage
 	defaultWsCpuMaxStored                             = 0 // Default to no maximum WS CPU usage
@@ -36,6 +37,8 @@ const (
 	defaultTxRegossipFrequency                        = 1 * time.Minute
 	defaultTxRegossipMaxSize                          = 15
 	defaultOfflinePruningBloomFilterSize       uint64 = 512 // Default size (MB) for the offline pruner to use
+	defaultOnlinePruningBloomFilterSize        uint64 = 512 // Default size (MB) for the online pruner to use
+	defaultOnlinePruningRate                   uint64 = 100_000
 	defaultLogLevel                                   = "info"
 	defaultLogJSONFormat                              = false
 	defaultPopulateMissingTriesParallelism            = 1024
@@ -43,6 +46,9 @@ const (
 	defaultMaxOutboundActiveCrossChainRequests        = 64
 	defaultStateSyncServerTrieCache                   = 64 // MB
 	defaultAcceptedCacheSize                          = 32 // blocks
+	defaultHealthMaxAcceptorQueueSaturation           = 0.9
+	defaultHealthMaxTxPoolSaturation                  = 0.9
+	defaultHealthMaxAtomicMempoolSaturation           = 0.9
 
 	// defaultStateSyncMinBlocks is the minimum number of blocks the blockchain
 	// should be ahead of local last accepted to perform state sync.
@@ -79,6 +85,7 @@ type Config struct {
 	SnowmanAPIEnabled     bool   `json:"snowman-api-enabled"`
 	CorethAdminAPIEnabled bool   `json:"coreth-admin-api-enabled"`
 	CorethAdminAPIDir     string `json:"coreth-admin-api-dir"`
+	CaminoAPIEnabled      bool   `json:"camino-api-enabled"`
 
 	// EnabledEthAPIs is a list of Ethereum services that should be enabled
 	// If none is specified, then we use the default list [defaultEnabledAPIs]
@@ -129,6 +136,11 @@ type Config struct {
 	TxPoolAccountQueue uint64   `json:"tx-pool-account-queue"`
 	TxPoolGlobalQueue  uint64   `json:"tx-pool-global-queue"`
 
+	// Atomic Mempool Settings
+	AtomicTxJournal   string   `json:"atomic-tx-journal"`    // Journal of local atomic txs, relative to the chain data dir (empty disables the journal)
+	AtomicTxRejournal Duration `json:"atomic-tx-rejournal"`  // Time interval to regenerate the local atomic tx journal
+	AtomicTxPriceBump uint64   `json:"atomic-tx-price-bump"` // Minimum gas price bump percentage to replace a conflicting atomic tx
+
 	APIMaxDuration           Duration      `json:"api-max-duration"`
 	WSCPURefillRate          Duration      `json:"ws-cpu-refill-rate"`
 	WSCPUMaxStored           Duration      `json:"ws-cpu-max-stored"`
@@ -156,6 +168,12 @@ type Config struct {
 	OfflinePruningBloomFilterSize uint64 `json:"offline-pruning-bloom-filter-size"`
 	OfflinePruningDataDirectory   string `json:"offline-pruning-data-directory"`
 
+	// Online Pruning Settings
+	// Online pruning is started through the admin API and deletes the stale
+	// state while the node keeps running.
+	OnlinePruningBloomFilterSize uint64 `json:"online-pruning-bloom-filter-size"`
+	OnlinePruningRate            uint64 `json:"online-pruning-rate"` // Max state entries processed per second (0 = unlimited)
+
 	// VM2VM network
 	MaxOutboundActiveRequests           int64 `json:"max-outbound-active-requests"`
 	MaxOutboundActiveCrossChainRequests int64 `json:"max-outbound-active-cross-chain-requests"`
@@ -189,6 +207,22 @@ type Config struct {
 	//  * 0:   means no limit
 	//  * N:   means N block limit [HEAD-N+1, HEAD] and delete extra indexes
 	TxLookupLimit uint64 `json:"tx-lookup-limit"`
+
+	// StateHistoryWindow is the number of recent accepted blocks for which the
+	// state diffs are kept when pruning is enabled. Historical state queries
+	// within the window are served from the nearest committed trie and the
+	// diffs instead of reexecuting blocks. It must be at least the commit
+	// interval to cover all blocks of the window (0 = disabled).
+	StateHistoryWindow uint64 `json:"state-history-window"`
+
+	// Health Settings
+	// The saturations are the fill ratio in [0, 1] at which a queue or pool is
+	// reported unhealthy, 0 disables the check.
+	HealthMaxBlockAge                Duration `json:"health-max-block-age"`                 // Max age of the last accepted block (0 disables the check)
+	HealthMaxAcceptorQueueSaturation float64  `json:"health-max-acceptor-queue-saturation"` // Max saturation of the acceptor queue
+	HealthMaxTxPoolSaturation        float64  `json:"health-max-tx-pool-saturation"`        // Max saturation of the tx pool
+	HealthMaxAtomicMempoolSaturation float64  `json:"health-max-atomic-mempool-saturation"` // Max saturation of the atomic mempool
+	HealthMinConnectedPeers          uint32   `json:"health-min-connected-peers"`           // Min number of connected peers
 }
 
 // EthAPIs returns an array of strings representing the Eth APIs that should be enabled
@@ -202,12 +236,15 @@ func (c Config) EthBackendSettings() eth.Settings {
 
 func (c *Config) SetDefaults() {
 	c.EnabledEthAPIs = defaultEnabledAPIs
+	c.CaminoAPIEnabled = defaultCaminoAPIEnabled
 	c.RPCGasCap = defaultRpcGasCap
 	c.RPCTxFeeCap = defaultRpcTxFeeCap
 	c.MetricsExpensiveEnabled = defaultMetricsExpensiveEnabled
 
 	c.TxPoolJournal = core.DefaultTxPoolConfig.Journal
 	c.TxPoolRejournal = Duration{core.DefaultTxPoolConfig.Rejournal}
+	c.AtomicTxRejournal = Duration{core.DefaultTxPoolConfig.Rejournal}
+	c.AtomicTxPriceBump = core.DefaultTxPoolConfig.PriceBump
 	c.TxPoolPriceLimit = core.DefaultTxPoolConfig.PriceLimit
 	c.TxPoolPriceBump = core.DefaultTxPoolConfig.PriceBump
 	c.TxPoolAccountSlots = core.DefaultTxPoolConfig.AccountSlots
@@ -231,6 +268,8 @@ func (c *Config) SetDefaults() {
 	c.TxRegossipFrequency.Duration = defaultTxRegossipFrequency
 	c.TxRegossipMaxSize = defaultTxRegossipMaxSize
 	c.OfflinePruningBloomFilterSize = defaultOfflinePruningBloomFilterSize
+	c.OnlinePruningBloomFilterSize = defaultOnlinePruningBloomFilterSize
+	c.OnlinePruningRate = defaultOnlinePruningRate
 	c.LogLevel = defaultLogLevel
 	c.PopulateMissingTriesParallelism = defaultPopulateMissingTriesParallelism
 	c.LogJSONFormat = defaultLogJSONFormat
@@ -242,6 +281,9 @@ func (c *Config) SetDefaults() {
 	c.StateSyncMinBlocks = defaultStateSyncMinBlocks
 	c.AllowUnprotectedTxHashes = defaultAllowUnprotectedTxHashes
 	c.AcceptedCacheSize = defaultAcceptedCacheSize
+	c.HealthMaxAcceptorQueueSaturation = defaultHealthMaxAcceptorQueueSaturation
+	c.HealthMaxTxPoolSaturation = defaultHealthMaxTxPoolSaturation
+	c.HealthMaxAtomicMempoolSaturation = defaultHealthMaxAtomicMempoolSaturation
 }
 
 func (d *Duration) UnmarshalJSON(data []byte) (err error) {
@@ -280,5 +322,26 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("cannot use commit interval of 0 with pruning enabled")
 	}
 
+	if !c.Pruning && c.StateHistoryWindow > 0 {
+		return fmt.Errorf("cannot keep state history while pruning is disabled")
+	}
+	if c.StateHistoryWindow > 0 && c.StateHistoryWindow < c.CommitInterval {
+		return fmt.Errorf("state history window must be at least the commit interval (window: %d, commit interval: %d)", c.StateHistoryWindow, c.CommitInterval)
+	}
+
+	if c.AtomicTxJournal != "" && c.AtomicTxRejournal.Duration < time.Second {
+		return fmt.Errorf("atomic tx rejournal must be at least 1s (got %s)", c.AtomicTxRejournal.Duration)
+	}
+
+	for name, saturation := range map[string]float64{
+		"acceptor queue": c.HealthMaxAcceptorQueueSaturation,
+		"tx pool":        c.HealthMaxTxPoolSaturation,
+		"atomic mempool": c.HealthMaxAtomicMempoolSaturation,
+	} {
+		if saturation < 0 || saturation > 1 {
+			return fmt.Errorf("health max %s saturation must be in [0, 1] (got %v)", name, saturation)
+		}
+	}
+
 	return nil
 }
@@ -112,6 +112,18 @@ func TestUnmarshalConfig(t *testing.T) {
 			Config{AllowUnprotectedTxHashes: []common.Hash{common.HexToHash("0x803351deb6d745e91545a6a3e1c0ea3e9a6a02a1a4193b70edfcd2f40f71a01c")}},
 			false,
 		},
+		{
+			"health settings",
+			[]byte(`{"health-max-block-age": "10m", "health-max-acceptor-queue-saturation": 0.5, "health-max-tx-pool-saturation": 0.6, "health-max-atomic-mempool-saturation": 0.7, "health-min-connected-peers": 3}`),
+			Config{
+				HealthMaxBlockAge:                Duration{10 * time.Minute},
+				HealthMaxAcceptorQueueSaturation: 0.5,
+				HealthMaxTxPoolSaturation:        0.6,
+				HealthMaxAtomicMempoolSaturation: 0.7,
+				HealthMinConnectedPeers:          3,
+			},
+			false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -127,3 +139,29 @@ func TestUnmarshalConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateStateHistoryWindow(t *testing.T) {
+	tests := []struct {
+		name        string
+		window      uint64
+		expectedErr bool
+	}{
+		{"disabled", 0, false},
+		{"smaller than the commit interval", defaultCommitInterval - 1, true},
+		{"commit interval", defaultCommitInterval, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c Config
+			c.SetDefaults()
+			c.StateHistoryWindow = tt.window
+			err := c.Validate()
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -176,7 +176,7 @@ func (utx *UnsignedExportTx) Burned(assetID ids.ID) (uint64, error) {
 func (utx *UnsignedExportTx) SemanticVerify(
 	vm *VM,
 	stx *Tx,
-	_ *Block,
+	parent *Block,
 	baseFee *big.Int,
 	rules params.Rules,
 ) error {
@@ -217,6 +217,7 @@ func (utx *UnsignedExportTx) SemanticVerify(
 		return fmt.Errorf("export tx contained mismatched number of inputs/credentials (%d vs. %d)", len(utx.Ins), len(stx.Creds))
 	}
 
+	var aliases secp256k1fx.AliasGetter
 	for i, input := range utx.Ins {
 		cred, ok := stx.Creds[i].(*secp256k1fx.Credential)
 		if !ok {
@@ -226,15 +227,39 @@ func (utx *UnsignedExportTx) SemanticVerify(
 			return err
 		}
 
-		if len(cred.Sigs) != 1 {
-			return fmt.Errorf("expected one signature for EVM Input Credential, but found: %d", len(cred.Sigs))
+		if len(cred.Sigs) == 1 {
+			pubKey, err := vm.secpFactory.RecoverPublicKey(utx.Bytes(), cred.Sigs[0][:])
+			if err != nil {
+				return err
+			}
+			if input.Address == PublicKeyToEthAddress(pubKey) {
+				continue
+			}
 		}
-		pubKey, err := vm.secpFactory.RecoverPublicKey(utx.Bytes(), cred.Sigs[0][:])
-		if err != nil {
-			return err
+
+		// Otherwise the input must be a multisig alias defined in the EVM state,
+		// which is only supported from Sunrise Phase 2 on
+		if !rules.IsSunrisePhase2 {
+			if len(cred.Sigs) != 1 {
+				return fmt.Errorf("expected one signature for EVM Input Credential, but found: %d", len(cred.Sigs))
+			}
+			return errPublicKeySignatureMismatch
+		}
+		if aliases == nil {
+			stateAliases, err := vm.stateAliasGetter(parent)
+			if err != nil {
+				return err
+			}
+			aliases = stateAliases
 		}
-		if input.Address != PublicKeyToEthAddress(pubKey) {
+		err := vm.verifyAliasCredential(utx.Bytes(), input.Address, cred, aliases)
+		switch {
+		case isAliasNotFound(err) && len(cred.Sigs) != 1:
+			return fmt.Errorf("expected one signature for EVM Input Credential, but found: %d", len(cred.Sigs))
+		case isAliasNotFound(err):
 			return errPublicKeySignatureMismatch
+		case err != nil:
+			return fmt.Errorf("export tx input %d failed multisig verification: %w", i, err)
 		}
 	}
 
@@ -284,91 +309,35 @@ func (vm *VM) newExportTx(
 	baseFee *big.Int, // fee to use post-AP3
 	keys []*secp256k1.PrivateKey, // Pay the fee and provide the tokens
 ) (*Tx, error) {
-	outs := []*avax.TransferableOutput{{
-		Asset: avax.Asset{ID: assetID},
-		Out: &secp256k1fx.TransferOutput{
-			Amt: amount,
-			OutputOwners: secp256k1fx.OutputOwners{
-				Locktime:  0,
-				Threshold: 1,
-				Addrs:     []ids.ShortID{to},
-			},
-		},
-	}}
-
-	var (
-		avaxNeeded           uint64 = 0
-		ins, avaxIns         []EVMInput
-		signers, avaxSigners [][]*secp256k1.PrivateKey
-		err                  error
-	)
-
-	// consume non-AVAX
-	if assetID != vm.ctx.AVAXAssetID {
-		ins, signers, err = vm.GetSpendableFunds(keys, assetID, amount)
-		if err != nil {
-			return nil, fmt.Errorf("couldn't generate tx inputs/signers: %w", err)
-		}
-	} else {
-		avaxNeeded = amount
+	owners := secp256k1fx.OutputOwners{
+		Locktime:  0,
+		Threshold: 1,
+		Addrs:     []ids.ShortID{to},
 	}
+	return vm.newExportTxToOwners(assetID, amount, chainID, owners, baseFee, keys)
+}
 
-	rules := vm.currentRules()
-	switch {
-	case rules.IsApricotPhase3:
-		utx := &UnsignedExportTx{
-			NetworkID:        vm.ctx.NetworkID,
-			BlockchainID:     vm.ctx.ChainID,
-			DestinationChain: chainID,
-			Ins:              ins,
-			ExportedOutputs:  outs,
-		}
-		tx := &Tx{UnsignedAtomicTx: utx}
-		if err := tx.Sign(vm.codec, nil); err != nil {
-			return nil, err
-		}
-
-		var cost uint64
-		cost, err = tx.GasUsed(rules.IsApricotPhase5)
-		if err != nil {
-			return nil, err
-		}
-
-		avaxIns, avaxSigners, err = vm.GetSpendableAVAXWithFee(keys, avaxNeeded, cost, baseFee)
-	default:
-		var newAvaxNeeded uint64
-		newAvaxNeeded, err = math.Add64(avaxNeeded, params.AvalancheAtomicTxFee)
-		if err != nil {
-			return nil, errOverflowExport
-		}
-		avaxIns, avaxSigners, err = vm.GetSpendableFunds(keys, vm.ctx.AVAXAssetID, newAvaxNeeded)
-	}
-	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/signers: %w", err)
-	}
-	ins = append(ins, avaxIns...)
-	signers = append(signers, avaxSigners...)
-
-	avax.SortTransferableOutputs(outs, vm.codec)
-	SortEVMInputsAndSigners(ins, signers)
-
-	// Create the transaction
-	utx := &UnsignedExportTx{
-		NetworkID:        vm.ctx.NetworkID,
-		BlockchainID:     vm.ctx.ChainID,
-		DestinationChain: chainID,
-		Ins:              ins,
-		ExportedOutputs:  outs,
-	}
-	tx := &Tx{UnsignedAtomicTx: utx}
-	if err := tx.Sign(vm.codec, signers); err != nil {
-		return nil, err
-	}
-	return tx, utx.Verify(vm.ctx, vm.currentRules())
+// newExportTxToOwners returns a new ExportTx whose exported output is owned by [owners]
+func (vm *VM) newExportTxToOwners(
+	assetID ids.ID, // AssetID of the tokens to export
+	amount uint64, // Amount of tokens to export
+	chainID ids.ID, // Chain to send the UTXOs to
+	owners secp256k1fx.OutputOwners, // Owners of the exported output
+	baseFee *big.Int, // fee to use post-AP3
+	keys []*secp256k1.PrivateKey, // Pay the fee and provide the tokens
+) (*Tx, error) {
+	return vm.newMultiAssetExportTx(
+		map[ids.ID]uint64{assetID: amount},
+		chainID,
+		owners,
+		baseFee,
+		keys,
+		CoinSelectionKeyOrder,
+	)
 }
 
 // EVMStateTransfer executes the state update from the atomic export transaction
-func (utx *UnsignedExportTx) EVMStateTransfer(ctx *snow.Context, state *state.StateDB) error {
+func (utx *UnsignedExportTx) EVMStateTransfer(ctx *snow.Context, state *state.StateDB, rules params.Rules) error {
 	addrs := map[[20]byte]uint64{}
 	for _, from := range utx.Ins {
 		if from.AssetID == ctx.AVAXAssetID {
@@ -403,7 +403,7 @@ func TestExportTxEVMStateTransfer(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			err = newTx.EVMStateTransfer(vm.ctx, stateDB)
+			err = newTx.EVMStateTransfer(vm.ctx, stateDB, vm.currentRules())
 			if test.shouldErr {
 				if err == nil {
 					t.Fatal("expected EVMStateTransfer to fail")
@@ -1789,7 +1789,7 @@ func TestNewExportTx(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-			err = exportTx.EVMStateTransfer(vm.ctx, sdb)
+			err = exportTx.EVMStateTransfer(vm.ctx, sdb, vm.currentRules())
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -1977,7 +1977,7 @@ func TestNewExportTxMulticoin(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-			err = exportTx.EVMStateTransfer(vm.ctx, stdb)
+			err = exportTx.EVMStateTransfer(vm.ctx, stdb, vm.currentRules())
 			if err != nil {
 				t.Fatal(err)
 			}
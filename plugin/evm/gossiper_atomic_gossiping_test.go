@@ -185,7 +185,7 @@ func TestMempoolAtmTxsAppGossipHandlingDiscardedTx(t *testing.T) {
 
 	mempool.AddTx(tx)
 	mempool.NextTx()
-	mempool.DiscardCurrentTx(txID)
+	mempool.DiscardCurrentTx(txID, errConflictingAtomicInputs)
 
 	// Check the mempool does not contain the discarded transaction
 	assert.False(mempool.has(txID))
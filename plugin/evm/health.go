@@ -1,14 +1,145 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+//
+// This file is a derived work, based on ava-labs code whose
+// original notices appear below.
+//
+// It is distributed under the same license conditions as the
+// original code from which it is derived.
+//
+// Much love to the original authors for their work.
+// **********************************************************
 // (c) 2019-2020, Ava Labs, Inc. All rights reserved.
 // See the file LICENSE for licensing terms.
 
 package evm
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
 
-// Health returns nil if this chain is healthy.
-// Also returns details, which should be one of:
-// string, []byte, map[string]string
+// HealthCheck returns an error if this chain is unhealthy, that is if one of
+// the checks configured with the health-* config options fails.
+// Also returns details about the chain, the queues and pools, state sync,
+// snapshot generation and the connected peers.
 func (vm *VM) HealthCheck(context.Context) (interface{}, error) {
-	// TODO perform actual health check
-	return nil, nil
+	var (
+		details  = make(map[string]interface{})
+		failures []string
+	)
+
+	// Last accepted block
+	lastAccepted := vm.blockChain.LastAcceptedBlock()
+	blockAge := vm.clock.Time().Sub(time.Unix(int64(lastAccepted.Time()), 0))
+	details["lastAccepted"] = map[string]interface{}{
+		"height": lastAccepted.NumberU64(),
+		"time":   lastAccepted.Time(),
+		"age":    blockAge.String(),
+	}
+	if maxAge := vm.config.HealthMaxBlockAge.Duration; vm.bootstrapped && maxAge > 0 && blockAge > maxAge {
+		failures = append(failures, fmt.Sprintf("last accepted block is %s old (max %s)", blockAge, maxAge))
+	}
+
+	// Queues and pools
+	acceptorQueueLen := vm.blockChain.AcceptorQueueLen()
+	details["acceptorQueue"] = map[string]interface{}{
+		"backlog": acceptorQueueLen,
+		"limit":   vm.config.AcceptorQueueLimit,
+	}
+	if msg, ok := checkSaturation("acceptor queue", acceptorQueueLen, vm.config.AcceptorQueueLimit, vm.config.HealthMaxAcceptorQueueSaturation); !ok {
+		failures = append(failures, msg)
+	}
+
+	pending, queued := vm.txPool.Stats()
+	txPoolCapacity := int(vm.config.TxPoolGlobalSlots + vm.config.TxPoolGlobalQueue)
+	details["txPool"] = map[string]interface{}{
+		"pending":  pending,
+		"queued":   queued,
+		"capacity": txPoolCapacity,
+	}
+	if msg, ok := checkSaturation("tx pool", pending+queued, txPoolCapacity, vm.config.HealthMaxTxPoolSaturation); !ok {
+		failures = append(failures, msg)
+	}
+
+	mempoolLen := vm.mempool.Len()
+	details["atomicMempool"] = map[string]interface{}{
+		"size":     mempoolLen,
+		"capacity": vm.mempool.maxSize,
+	}
+	if msg, ok := checkSaturation("atomic mempool", mempoolLen, vm.mempool.maxSize, vm.config.HealthMaxAtomicMempoolSaturation); !ok {
+		failures = append(failures, msg)
+	}
+
+	// State sync
+	stateSync := map[string]interface{}{
+		"bootstrapped": vm.bootstrapped,
+	}
+	if vm.StateSyncClient != nil {
+		if targetHeight := vm.StateSyncClient.TargetHeight(); targetHeight > 0 {
+			stateSync["targetHeight"] = targetHeight
+		}
+		if err := vm.StateSyncClient.Error(); err != nil {
+			stateSync["error"] = err.Error()
+			failures = append(failures, fmt.Sprintf("state sync failed: %s", err))
+		}
+	}
+	details["stateSync"] = stateSync
+
+	// Snapshot generation and the application of atomic operations to shared
+	// memory are informational only, both resume on restart.
+	snaps := vm.blockChain.Snapshots()
+	snapshot := map[string]interface{}{
+		"enabled": snaps != nil,
+	}
+	if snaps != nil {
+		generating, err := snaps.Generating()
+		snapshot["generating"] = generating
+		if err != nil {
+			snapshot["error"] = err.Error()
+		}
+	}
+	details["snapshot"] = snapshot
+
+	cursorHeight, cursorPending, err := vm.atomicBackend.ApplyToSharedMemoryCursor()
+	if err != nil {
+		return details, fmt.Errorf("failed to read shared memory cursor: %w", err)
+	}
+	sharedMemory := map[string]interface{}{
+		"pending": cursorPending,
+	}
+	if cursorPending {
+		sharedMemory["cursorHeight"] = cursorHeight
+	}
+	details["sharedMemory"] = sharedMemory
+
+	// Network
+	peers := vm.Network.Size()
+	details["peers"] = map[string]interface{}{
+		"connected": peers,
+		"min":       vm.config.HealthMinConnectedPeers,
+	}
+	if peers < vm.config.HealthMinConnectedPeers {
+		failures = append(failures, fmt.Sprintf("connected to %d peers (min %d)", peers, vm.config.HealthMinConnectedPeers))
+	}
+
+	if len(failures) > 0 {
+		return details, fmt.Errorf("chain is unhealthy: %s", strings.Join(failures, "; "))
+	}
+	return details, nil
+}
+
+// checkSaturation returns false and a failure message if [size] exceeds
+// [maxSaturation] of [capacity]. A [maxSaturation] or [capacity] of 0
+// disables the check.
+func checkSaturation(name string, size, capacity int, maxSaturation float64) (string, bool) {
+	if maxSaturation == 0 || capacity <= 0 {
+		return "", true
+	}
+	saturation := float64(size) / float64(capacity)
+	if saturation <= maxSaturation {
+		return "", true
+	}
+	return fmt.Sprintf("%s saturation %.2f exceeds %.2f (%d/%d)", name, saturation, maxSaturation, size, capacity), false
 }
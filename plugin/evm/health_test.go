@@ -0,0 +1,78 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCheck(t *testing.T) {
+	tests := map[string]struct {
+		configJSON  string
+		advance     time.Duration
+		expectedErr string
+	}{
+		"healthy with defaults": {
+			configJSON: "",
+			advance:    time.Hour,
+		},
+		"recent block": {
+			configJSON: `{"health-max-block-age": "1m"}`,
+			advance:    30 * time.Second,
+		},
+		"stale block": {
+			configJSON:  `{"health-max-block-age": "1m"}`,
+			advance:     time.Hour,
+			expectedErr: "last accepted block is",
+		},
+		"not enough peers": {
+			configJSON:  `{"health-min-connected-peers": 1}`,
+			expectedErr: "connected to 0 peers (min 1)",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			_, vm, _, _, _ := GenesisVM(t, true, genesisJSONLatest, test.configJSON, "")
+			defer func() {
+				require.NoError(vm.Shutdown(context.Background()))
+			}()
+			genesisTime := time.Unix(int64(vm.blockChain.Genesis().Time()), 0)
+			vm.clock.Set(genesisTime.Add(test.advance))
+
+			details, err := vm.HealthCheck(context.Background())
+			if test.expectedErr != "" {
+				require.ErrorContains(err, test.expectedErr)
+			} else {
+				require.NoError(err)
+			}
+
+			detailsMap, ok := details.(map[string]interface{})
+			require.True(ok)
+			for _, key := range []string{"lastAccepted", "acceptorQueue", "txPool", "atomicMempool", "stateSync", "snapshot", "sharedMemory", "peers"} {
+				require.Contains(detailsMap, key)
+			}
+			require.Equal(map[string]interface{}{"pending": false}, detailsMap["sharedMemory"])
+		})
+	}
+}
+
+func TestCheckSaturation(t *testing.T) {
+	require := require.New(t)
+
+	_, ok := checkSaturation("queue", 9, 10, 0.9)
+	require.True(ok)
+	msg, ok := checkSaturation("queue", 10, 10, 0.9)
+	require.False(ok)
+	require.Equal("queue saturation 1.00 exceeds 0.90 (10/10)", msg)
+	_, ok = checkSaturation("queue", 10, 10, 0)
+	require.True(ok)
+	_, ok = checkSaturation("queue", 10, 0, 0.9)
+	require.True(ok)
+}
@@ -251,6 +251,17 @@ func (utx *UnsignedImportTx) SemanticVerify(
 		aliasSet.Add(aliases...)
 	}
 
+	// From Sunrise Phase 2 on, aliases defined in the EVM state apply unless
+	// the UTXOs carry a newer definition
+	var aliases secp256k1fx.AliasGetter = aliasSet
+	if rules.IsSunrisePhase2 {
+		stateAliases, err := vm.stateAliasGetter(parent)
+		if err != nil {
+			return err
+		}
+		aliases = core.AliasGetters{aliasSet, stateAliases}
+	}
+
 	rcpts := make(map[ids.ShortID]map[ids.ID]uint64)
 	rcpts[ids.ShortEmpty] = make(map[ids.ID]uint64, 0)
 
@@ -278,7 +289,7 @@ func (utx *UnsignedImportTx) SemanticVerify(
 			rcpts[ids.ShortEmpty][utxoAssetID] += utxoAmount
 		}
 
-		if err := vm.fx.VerifyMultisigTransfer(utx, in.In, cred, utxo.Out, aliasSet); err != nil {
+		if err := vm.fx.VerifyMultisigTransfer(utx, in.In, cred, utxo.Out, aliases); err != nil {
 			return fmt.Errorf("import tx transfer failed verification: %w", err)
 		}
 	}
@@ -347,25 +358,51 @@ func (vm *VM) newImportTxWithUTXOs(
 	kc *secp256k1fx.Keychain, // Keychain to use for signing the atomic UTXOs
 	atomicUTXOs []*avax.UTXO, // UTXOs to spend
 ) (*Tx, error) {
+	utx, utxoSigners, err := vm.newUnsignedImportTxWithUTXOs(chainID, to, baseFee, kc.Addresses(), atomicUTXOs)
+	if err != nil {
+		return nil, err
+	}
+	signers := make([][]*secp256k1.PrivateKey, len(utxoSigners))
+	for i, addrs := range utxoSigners {
+		signers[i] = make([]*secp256k1.PrivateKey, len(addrs))
+		for j, addr := range addrs {
+			signer, _ := kc.Get(addr)
+			signers[i][j] = signer.(*secp256k1.PrivateKey)
+		}
+	}
+	tx := &Tx{UnsignedAtomicTx: utx}
+	if err := tx.Sign(vm.codec, signers); err != nil {
+		return nil, err
+	}
+	return tx, utx.Verify(vm.ctx, vm.currentRules())
+}
+
+// newUnsignedImportTxWithUTXOs returns a new unsigned ImportTx spending the
+// UTXOs of [atomicUTXOs] which can be spent by [addrs], along with the
+// addresses signing each imported input
+func (vm *VM) newUnsignedImportTxWithUTXOs(
+	chainID ids.ID, // chain to import from
+	to common.Address, // Address of recipient
+	baseFee *big.Int, // fee to use post-AP3
+	addrs set.Set[ids.ShortID], // Addresses signing the atomic UTXOs
+	atomicUTXOs []*avax.UTXO, // UTXOs to spend
+) (*UnsignedImportTx, [][]ids.ShortID, error) {
 	importedInputs := []*avax.TransferableInput{}
-	signers := [][]*secp256k1.PrivateKey{}
+	signers := [][]ids.ShortID{}
 
 	importedAmount := make(map[ids.ID]uint64)
 	now := vm.clock.Unix()
 	for _, utxo := range atomicUTXOs {
-		inputIntf, utxoSigners, err := kc.Spend(utxo.Out, now)
-		if err != nil {
-			continue
-		}
-		input, ok := inputIntf.(avax.TransferableIn)
+		input, utxoSigners, ok := spendAtomicUTXO(addrs, utxo.Out, now)
 		if !ok {
 			continue
 		}
 		aid := utxo.AssetID()
-		importedAmount[aid], err = math.Add64(importedAmount[aid], input.Amount())
+		amount, err := math.Add64(importedAmount[aid], input.Amount())
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
+		importedAmount[aid] = amount
 		importedInputs = append(importedInputs, &avax.TransferableInput{
 			UTXOID: utxo.UTXOID,
 			Asset:  utxo.Asset,
@@ -373,7 +410,7 @@ func (vm *VM) newImportTxWithUTXOs(
 		})
 		signers = append(signers, utxoSigners)
 	}
-	avax.SortTransferableInputsWithSigners(importedInputs, signers)
+	sortTransferableInputsWithAddrs(importedInputs, signers)
 	importedAVAXAmount := importedAmount[vm.ctx.AVAXAssetID]
 
 	outs := make([]EVMOutput, 0, len(importedAmount))
@@ -401,7 +438,7 @@ func (vm *VM) newImportTxWithUTXOs(
 	switch {
 	case rules.IsApricotPhase3:
 		if baseFee == nil {
-			return nil, errNilBaseFeeApricotPhase3
+			return nil, nil, errNilBaseFeeApricotPhase3
 		}
 		utx := &UnsignedImportTx{
 			NetworkID:      vm.ctx.NetworkID,
@@ -412,22 +449,22 @@ func (vm *VM) newImportTxWithUTXOs(
 		}
 		tx := &Tx{UnsignedAtomicTx: utx}
 		if err := tx.Sign(vm.codec, nil); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		gasUsedWithoutChange, err := tx.GasUsed(rules.IsApricotPhase5)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		gasUsedWithChange := gasUsedWithoutChange + EVMOutputGas
 
 		txFeeWithoutChange, err = calculateDynamicFee(gasUsedWithoutChange, baseFee)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		txFeeWithChange, err = calculateDynamicFee(gasUsedWithChange, baseFee)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	case rules.IsApricotPhase2:
 		txFeeWithoutChange = params.AvalancheAtomicTxFee
@@ -436,7 +473,7 @@ func (vm *VM) newImportTxWithUTXOs(
 
 	// AVAX output
 	if importedAVAXAmount < txFeeWithoutChange { // imported amount goes toward paying tx fee
-		return nil, errInsufficientFundsForFee
+		return nil, nil, errInsufficientFundsForFee
 	}
 
 	if importedAVAXAmount > txFeeWithChange {
@@ -451,7 +488,7 @@ func (vm *VM) newImportTxWithUTXOs(
 	// Note: this can happen if there is exactly enough AVAX to pay the
 	// transaction fee, but no other funds to be imported.
 	if len(outs) == 0 {
-		return nil, errNoEVMOutputs
+		return nil, nil, errNoEVMOutputs
 	}
 
 	SortEVMOutputs(outs)
@@ -464,16 +501,12 @@ func (vm *VM) newImportTxWithUTXOs(
 		ImportedInputs: importedInputs,
 		SourceChain:    chainID,
 	}
-	tx := &Tx{UnsignedAtomicTx: utx}
-	if err := tx.Sign(vm.codec, signers); err != nil {
-		return nil, err
-	}
-	return tx, utx.Verify(vm.ctx, vm.currentRules())
+	return utx, signers, nil
 }
 
 // EVMStateTransfer performs the state transfer to increase the balances of
 // accounts accordingly with the imported EVMOutputs
-func (utx *UnsignedImportTx) EVMStateTransfer(ctx *snow.Context, state *state.StateDB) error {
+func (utx *UnsignedImportTx) EVMStateTransfer(ctx *snow.Context, state *state.StateDB, rules params.Rules) error {
 	for _, to := range utx.Outs {
 		if to.AssetID == ctx.AVAXAssetID {
 			log.Debug("crosschain", "src", utx.SourceChain, "addr", to.Address, "amount", to.Amount, "assetID", "AVAX")
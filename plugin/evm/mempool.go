@@ -10,6 +10,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/linkedhashmap"
 	"github.com/ava-labs/coreth/metrics"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -52,6 +53,9 @@ type Mempool struct {
 	AVAXAssetID ids.ID
 	// maxSize is the maximum number of transactions allowed to be kept in mempool
 	maxSize int
+	// priceBump is the minimum gas price bump percentage to replace conflicting
+	// transactions
+	priceBump uint64
 	// currentTxs is the set of transactions about to be added to a block.
 	currentTxs map[ids.ID]*Tx
 	// issuedTxs is the set of transactions that have been issued into a new block
@@ -59,6 +63,9 @@ type Mempool struct {
 	// discardedTxs is an LRU Cache of transactions that have been discarded after failing
 	// verification.
 	discardedTxs *cache.LRU[ids.ID, *Tx]
+	// discardReasons records the reasons of the recently discarded transactions
+	// in the order they were discarded.
+	discardReasons linkedhashmap.LinkedHashmap[ids.ID, *discardedTx]
 	// Pending is a channel of length one, which the mempool ensures has an item on
 	// it as long as there is an unissued transaction remaining in [txs]
 	Pending chan struct{}
@@ -73,18 +80,21 @@ type Mempool struct {
 	metrics *mempoolMetrics
 }
 
-// NewMempool returns a Mempool with [maxSize]
-func NewMempool(AVAXAssetID ids.ID, maxSize int) *Mempool {
+// NewMempool returns a Mempool with [maxSize], which replaces conflicting
+// transactions only if the gas price is bumped by at least [priceBump] percent
+func NewMempool(AVAXAssetID ids.ID, maxSize int, priceBump uint64) *Mempool {
 	return &Mempool{
-		AVAXAssetID:  AVAXAssetID,
-		issuedTxs:    make(map[ids.ID]*Tx),
-		discardedTxs: &cache.LRU[ids.ID, *Tx]{Size: discardedTxsCacheSize},
-		currentTxs:   make(map[ids.ID]*Tx),
-		Pending:      make(chan struct{}, 1),
-		txHeap:       newTxHeap(maxSize),
-		maxSize:      maxSize,
-		utxoSpenders: make(map[ids.ID]*Tx),
-		metrics:      newMempoolMetrics(),
+		AVAXAssetID:    AVAXAssetID,
+		issuedTxs:      make(map[ids.ID]*Tx),
+		discardedTxs:   &cache.LRU[ids.ID, *Tx]{Size: discardedTxsCacheSize},
+		discardReasons: linkedhashmap.New[ids.ID, *discardedTx](),
+		currentTxs:     make(map[ids.ID]*Tx),
+		Pending:        make(chan struct{}, 1),
+		txHeap:         newTxHeap(maxSize),
+		maxSize:        maxSize,
+		priceBump:      priceBump,
+		utxoSpenders:   make(map[ids.ID]*Tx),
+		metrics:        newMempoolMetrics(),
 	}
 }
 
@@ -197,14 +207,15 @@ func (m *Mempool) addTx(tx *Tx, force bool) error {
 		return err
 	}
 	if len(conflictingTxs) != 0 && !force {
-		// If [tx] does not have a higher fee than all of its conflicts,
-		// we refuse to issue it to the mempool.
-		if highestGasPrice >= gasPrice {
+		// If [tx] does not have a higher fee than all of its conflicts, bumped
+		// by at least [priceBump] percent, we refuse to issue it to the mempool.
+		if minGasPrice := m.minReplacementGasPrice(highestGasPrice); gasPrice < minGasPrice {
 			return fmt.Errorf(
-				"%w: issued tx (%s) gas price %d <= conflict tx (%s) gas price %d (%d total conflicts in mempool)",
+				"%w: issued tx (%s) gas price %d < %d required to replace conflict tx (%s) gas price %d (%d total conflicts in mempool)",
 				errConflictingAtomicTx,
 				txID,
 				gasPrice,
+				minGasPrice,
 				highestGasPriceConflictTxID,
 				highestGasPrice,
 				len(conflictingTxs),
@@ -212,7 +223,7 @@ func (m *Mempool) addTx(tx *Tx, force bool) error {
 		}
 		// Remove any conflicting transactions from the mempool
 		for _, conflictTx := range conflictingTxs {
-			m.removeTx(conflictTx, true)
+			m.removeTx(conflictTx, fmt.Sprintf("replaced by conflicting tx %s with gas price %d", txID, gasPrice))
 		}
 	}
 	// If adding this transaction would exceed the mempool's size, check if there is a lower priced
@@ -233,7 +244,7 @@ func (m *Mempool) addTx(tx *Tx, force bool) error {
 				)
 			}
 
-			m.removeTx(minTx, true)
+			m.removeTx(minTx, fmt.Sprintf("evicted by tx %s with gas price %d", txID, gasPrice))
 		} else {
 			// This could occur if we have used our entire size allowance on
 			// transactions that are currently processing.
@@ -247,7 +258,7 @@ func (m *Mempool) addTx(tx *Tx, force bool) error {
 	// due to an atomic UTXO not being present yet.
 	if _, has := m.discardedTxs.Get(txID); has {
 		log.Debug("Adding recently discarded transaction %s back to the mempool", txID)
-		m.discardedTxs.Evict(txID)
+		m.evictDiscardedTx(txID)
 	}
 
 	// Add the transaction to the [txHeap] so we can evaluate new entries based
@@ -388,7 +399,7 @@ func (m *Mempool) cancelTx(tx *Tx) {
 		// invalid. This should never happen but we guard against the case it does.
 		log.Error("failed to calculate atomic tx gas price while canceling current tx", "err", err)
 		m.removeSpenders(tx)
-		m.discardedTxs.Put(tx.ID(), tx)
+		m.discardTx(tx, fmt.Sprintf("failed to calculate gas price: %s", err))
 		m.metrics.discardedTxs.Inc(1)
 	}
 
@@ -397,13 +408,13 @@ func (m *Mempool) cancelTx(tx *Tx) {
 }
 
 // DiscardCurrentTx marks a [tx] in the [currentTxs] map as invalid and aborts the attempt
-// to issue it since it failed verification.
-func (m *Mempool) DiscardCurrentTx(txID ids.ID) {
+// to issue it since it failed verification with [reason].
+func (m *Mempool) DiscardCurrentTx(txID ids.ID, reason error) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
 	if tx, ok := m.currentTxs[txID]; ok {
-		m.discardCurrentTx(tx)
+		m.discardCurrentTx(tx, reason.Error())
 	}
 }
 
@@ -413,27 +424,28 @@ func (m *Mempool) DiscardCurrentTxs() {
 	defer m.lock.Unlock()
 
 	for _, tx := range m.currentTxs {
-		m.discardCurrentTx(tx)
+		m.discardCurrentTx(tx, "failed to build block")
 	}
 }
 
 // discardCurrentTx discards [tx] from the set of current transactions.
 // Assumes the lock is held.
-func (m *Mempool) discardCurrentTx(tx *Tx) {
+func (m *Mempool) discardCurrentTx(tx *Tx, reason string) {
 	m.removeSpenders(tx)
-	m.discardedTxs.Put(tx.ID(), tx)
+	m.discardTx(tx, reason)
 	delete(m.currentTxs, tx.ID())
 	m.metrics.currentTxs.Update(int64(len(m.currentTxs)))
 	m.metrics.discardedTxs.Inc(1)
 }
 
-// removeTx removes [txID] from the mempool.
+// removeTx removes [txID] from the mempool. If [discardReason] is not empty,
+// [txID] is recorded as discarded.
 // Note: removeTx will delete all entries from [utxoSpenders] corresponding
 // to input UTXOs of [txID]. This means that when replacing a conflicting tx,
 // removeTx must be called for all conflicts before overwriting the utxoSpenders
 // map.
 // Assumes lock is held.
-func (m *Mempool) removeTx(tx *Tx, discard bool) {
+func (m *Mempool) removeTx(tx *Tx, discardReason string) {
 	txID := tx.ID()
 
 	// Remove from [currentTxs], [txHeap], and [issuedTxs].
@@ -441,11 +453,13 @@ func (m *Mempool) removeTx(tx *Tx, discard bool) {
 	m.txHeap.Remove(txID)
 	delete(m.issuedTxs, txID)
 
-	if discard {
-		m.discardedTxs.Put(txID, tx)
+	if discardReason != "" {
+		// Make sure a discarded tx is not gossiped anymore
+		m.removeNewTx(txID)
+		m.discardTx(tx, discardReason)
 		m.metrics.discardedTxs.Inc(1)
 	} else {
-		m.discardedTxs.Evict(txID)
+		m.evictDiscardedTx(txID)
 	}
 	m.metrics.pendingTxs.Update(int64(m.txHeap.Len()))
 	m.metrics.currentTxs.Update(int64(len(m.currentTxs)))
@@ -470,7 +484,7 @@ func (m *Mempool) RemoveTx(tx *Tx) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
-	m.removeTx(tx, false)
+	m.removeTx(tx, "")
 }
 
 // addPending makes sure that an item is in the Pending channel.
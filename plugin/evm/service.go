@@ -14,10 +14,12 @@ import (
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/coreth/params"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -38,6 +40,7 @@ var (
 	errNoSourceChain     = errors.New("no source chain provided")
 	errNilTxID           = errors.New("nil transaction ID")
 	errMissingPrivateKey = errors.New("argument 'privateKey' not given")
+	errToAndOwners       = errors.New("only one of 'to' and 'owners' can be given")
 
 	initialBaseFee = big.NewInt(params.ApricotPhase3InitialBaseFee)
 )
@@ -107,6 +110,9 @@ type ExportKeyReply struct {
 }
 
 // ExportKey returns a private key from the provided user
+//
+// Deprecated: keys should be held by the client, see BuildImport and
+// BuildExport
 func (service *AvaxAPI) ExportKey(r *http.Request, args *ExportKeyArgs, reply *ExportKeyReply) error {
 	log.Info("EVM: ExportKey called")
 
@@ -140,6 +146,9 @@ type ImportKeyArgs struct {
 }
 
 // ImportKey adds a private key to the provided user
+//
+// Deprecated: keys should be held by the client, see BuildImport and
+// BuildExport
 func (service *AvaxAPI) ImportKey(r *http.Request, args *ImportKeyArgs, reply *api.JSONAddress) error {
 	log.Info("EVM: ImportKey called", "username", args.Username)
 
@@ -186,6 +195,8 @@ func (service *AvaxAPI) ImportAVAX(_ *http.Request, args *ImportArgs, response *
 
 // Import issues a transaction to import AVAX from the X-chain. The AVAX
 // must have already been exported from the X-Chain.
+//
+// Deprecated: use BuildImport, sign the inputs and issue the tx with IssueTx
 func (service *AvaxAPI) Import(_ *http.Request, args *ImportArgs, response *api.JSONTxID) error {
 	log.Info("EVM: ImportAVAX called")
 
@@ -248,6 +259,21 @@ type ExportAVAXArgs struct {
 	// ID of the address that will receive the AVAX. This address includes the
 	// chainID, which is used to determine what the destination chain is.
 	To string `json:"to"`
+
+	// Owners of the exported output, used instead of [To] to export to
+	// more than one address or with a locktime
+	Owners *ExportOwners `json:"owners,omitempty"`
+}
+
+// ExportOwners are the owners of an exported output
+type ExportOwners struct {
+	// Locktime until which the output cannot be spent
+	Locktime json.Uint64 `json:"locktime"`
+	// Threshold of signatures required to spend the output
+	Threshold json.Uint32 `json:"threshold"`
+	// Addresses allowed to sign. All addresses must include the same chainID,
+	// which is used to determine what the destination chain is.
+	Addresses []string `json:"addresses"`
 }
 
 // ExportAVAX exports AVAX from the C-Chain to the X-Chain
@@ -264,23 +290,29 @@ type ExportArgs struct {
 	ExportAVAXArgs
 	// AssetID of the tokens
 	AssetID string `json:"assetID"`
+	// Assets exported in the same tx in addition to [Amount] of [AssetID],
+	// which can be omitted if [Assets] are given
+	Assets []ExportAsset `json:"assets,omitempty"`
+	// Strategy to select the addresses whose funds are spent
+	CoinSelection CoinSelection `json:"coinSelection,omitempty"`
 }
 
 // Export exports an asset from the C-Chain to the X-Chain
 // It must be imported on the X-Chain to complete the transfer
+//
+// Deprecated: use BuildExport, sign the inputs and issue the tx with IssueTx
 func (service *AvaxAPI) Export(_ *http.Request, args *ExportArgs, response *api.JSONTxID) error {
 	log.Info("EVM: Export called")
 
-	assetID, err := service.parseAssetID(args.AssetID)
+	amounts, err := service.parseExportAmounts(args.AssetID, args.Amount, args.Assets)
 	if err != nil {
 		return err
 	}
-
-	if args.Amount == 0 {
-		return errors.New("argument 'amount' must be > 0")
+	if err := args.CoinSelection.Verify(); err != nil {
+		return err
 	}
 
-	chainID, to, err := service.vm.ParseAddress(args.To)
+	chainID, owners, err := service.parseExportOwners(args.To, args.Owners)
 	if err != nil {
 		return err
 	}
@@ -313,13 +345,13 @@ func (service *AvaxAPI) Export(_ *http.Request, args *ExportArgs, response *api.
 	}
 
 	// Create the transaction
-	tx, err := service.vm.newExportTx(
-		assetID,             // AssetID
-		uint64(args.Amount), // Amount
-		chainID,             // ID of the chain to send the funds to
-		to,                  // Address
+	tx, err := service.vm.newMultiAssetExportTx(
+		amounts, // Amounts by AssetID
+		chainID, // ID of the chain to send the funds to
+		owners,  // Owners of the exported outputs
 		baseFee,
 		privKeys, // Private keys
+		args.CoinSelection,
 	)
 	if err != nil {
 		return fmt.Errorf("couldn't create tx: %w", err)
@@ -329,6 +361,50 @@ func (service *AvaxAPI) Export(_ *http.Request, args *ExportArgs, response *api.
 	return service.vm.issueTx(tx, true /*=local*/)
 }
 
+// parseExportOwners returns the destination chain and the owners of the
+// output exported to [to] or to [exportOwners]
+func (service *AvaxAPI) parseExportOwners(to string, exportOwners *ExportOwners) (ids.ID, secp256k1fx.OutputOwners, error) {
+	if exportOwners == nil {
+		chainID, addr, err := service.vm.ParseAddress(to)
+		if err != nil {
+			return ids.ID{}, secp256k1fx.OutputOwners{}, err
+		}
+		return chainID, secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		}, nil
+	}
+
+	switch {
+	case to != "":
+		return ids.ID{}, secp256k1fx.OutputOwners{}, errToAndOwners
+	case len(exportOwners.Addresses) == 0:
+		return ids.ID{}, secp256k1fx.OutputOwners{}, errNoAddresses
+	}
+	owners := secp256k1fx.OutputOwners{
+		Locktime:  uint64(exportOwners.Locktime),
+		Threshold: uint32(exportOwners.Threshold),
+		Addrs:     make([]ids.ShortID, len(exportOwners.Addresses)),
+	}
+	var chainID ids.ID
+	for i, addrStr := range exportOwners.Addresses {
+		addrChainID, addr, err := service.vm.ParseAddress(addrStr)
+		if err != nil {
+			return ids.ID{}, secp256k1fx.OutputOwners{}, fmt.Errorf("couldn't parse owner %q: %w", addrStr, err)
+		}
+		if i > 0 && addrChainID != chainID {
+			return ids.ID{}, secp256k1fx.OutputOwners{}, fmt.Errorf("owner %q is on chain %s, expected %s", addrStr, addrChainID, chainID)
+		}
+		chainID = addrChainID
+		owners.Addrs[i] = addr
+	}
+	utils.Sort(owners.Addrs)
+	if err := owners.Verify(); err != nil {
+		return ids.ID{}, secp256k1fx.OutputOwners{}, fmt.Errorf("invalid owners: %w", err)
+	}
+	return chainID, owners, nil
+}
+
 // GetUTXOs gets all utxos for passed in addresses
 func (service *AvaxAPI) GetUTXOs(r *http.Request, args *api.GetUTXOsArgs, reply *api.GetUTXOsReply) error {
 	log.Info("EVM: GetUTXOs called", "Addresses", args.Addresses)
@@ -475,18 +551,9 @@ func (service *AvaxAPI) GetAtomicTx(r *http.Request, args *api.GetTxArgs, reply
 		return fmt.Errorf("could not find tx %s", args.TxID)
 	}
 
-	if args.Encoding == formatting.JSON {
-		txBytes, err := json_encoder.Marshal(tx)
-		if err != nil {
-			return fmt.Errorf("couldn't marshal UTXO %q: %w", tx.ID(), err)
-		}
-		reply.Tx = string(txBytes)
-	} else {
-		txBytes, err := formatting.Encode(args.Encoding, tx.SignedBytes())
-		if err != nil {
-			return err
-		}
-		reply.Tx = txBytes
+	reply.Tx, err = formatAtomicTx(tx, args.Encoding)
+	if err != nil {
+		return err
 	}
 
 	reply.Encoding = args.Encoding
@@ -496,3 +563,15 @@ func (service *AvaxAPI) GetAtomicTx(r *http.Request, args *api.GetTxArgs, reply
 	}
 	return nil
 }
+
+// formatAtomicTx returns [tx] as JSON or its signed bytes in [encoding]
+func formatAtomicTx(tx *Tx, encoding formatting.Encoding) (string, error) {
+	if encoding == formatting.JSON {
+		txBytes, err := json_encoder.Marshal(tx)
+		if err != nil {
+			return "", fmt.Errorf("couldn't marshal UTXO %q: %w", tx.ID(), err)
+		}
+		return string(txBytes), nil
+	}
+	return formatting.Encode(encoding, tx.SignedBytes())
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/versiondb"
@@ -70,6 +71,9 @@ type stateSyncerClient struct {
 	// State Sync results
 	syncSummary  message.SyncSummary
 	stateSyncErr error
+
+	// [targetHeight] is the height of the summary being synced to, accessed atomically
+	targetHeight uint64
 }
 
 func NewStateSyncClient(config *stateSyncClientConfig) StateSyncClient {
@@ -88,6 +92,7 @@ type StateSyncClient interface {
 	StateSyncClearOngoingSummary() error
 	Shutdown() error
 	Error() error
+	TargetHeight() uint64
 }
 
 // Syncer represents a step in state sync,
@@ -194,6 +199,7 @@ func (client *stateSyncerClient) acceptSyncSummary(proposedSummary message.SyncS
 		snapshot.ResetSnapshotGeneration(client.chaindb)
 	}
 	client.syncSummary = proposedSummary
+	atomic.StoreUint64(&client.targetHeight, proposedSummary.BlockNumber)
 
 	// Update the current state sync summary key in the database
 	// Note: this must be performed after WipeSnapshot finishes so that we do not start a state sync
@@ -408,3 +414,9 @@ func (client *stateSyncerClient) updateVMMarkers() error {
 
 // Error returns a non-nil error if one occurred during the sync.
 func (client *stateSyncerClient) Error() error { return client.stateSyncErr }
+
+// TargetHeight returns the height of the summary state sync was started
+// with, or 0 if state sync was not performed.
+func (client *stateSyncerClient) TargetHeight() uint64 {
+	return atomic.LoadUint64(&client.targetHeight)
+}
@@ -114,7 +114,7 @@ type UnsignedAtomicTx interface {
 	// The set of atomic requests must be returned in a consistent order.
 	AtomicOps() (ids.ID, *atomic.Requests, error)
 
-	EVMStateTransfer(ctx *snow.Context, state *state.StateDB) error
+	EVMStateTransfer(ctx *snow.Context, state *state.StateDB, rules params.Rules) error
 }
 
 // Tx is a signed transaction
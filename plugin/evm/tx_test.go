@@ -128,7 +128,7 @@ func executeTxTest(t *testing.T, test atomicTxTest) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if err := tx.UnsignedAtomicTx.EVMStateTransfer(vm.ctx, sdb); len(test.evmStateTransferErr) == 0 && err != nil {
+	if err := tx.UnsignedAtomicTx.EVMStateTransfer(vm.ctx, sdb, rules); len(test.evmStateTransferErr) == 0 && err != nil {
 		t.Fatalf("EVMStateTransfer failed unexpectedly due to: %s", err)
 	} else if len(test.evmStateTransferErr) != 0 {
 		if err == nil {
@@ -29,12 +29,12 @@ import (
 	avalanchegoMetrics "github.com/ava-labs/avalanchego/api/metrics"
 
 	"github.com/ava-labs/coreth/consensus/dummy"
-	corethConstants "github.com/ava-labs/coreth/constants"
 	"github.com/ava-labs/coreth/core"
 	"github.com/ava-labs/coreth/core/rawdb"
 	"github.com/ava-labs/coreth/core/state"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/eth"
+	"github.com/ava-labs/coreth/eth/ethadmin"
 	"github.com/ava-labs/coreth/eth/ethconfig"
 	"github.com/ava-labs/coreth/ethdb"
 	corethPrometheus "github.com/ava-labs/coreth/metrics/prometheus"
@@ -271,6 +271,11 @@ type VM struct {
 	clock     mockable.Clock
 	mempool   *Mempool
 
+	// [rewardNotifier] notifies the P-Chain about collected fee rewards
+	rewardNotifier *rewardNotifier
+	// [atomicTxJournal] persists the locally issued atomic txs
+	atomicTxJournal *atomicTxJournal
+
 	shutdownChan chan struct{}
 	shutdownWg   sync.WaitGroup
 
@@ -491,6 +496,9 @@ func (vm *VM) Initialize(
 	vm.ethConfig.SkipUpgradeCheck = vm.config.SkipUpgradeCheck
 	vm.ethConfig.AcceptedCacheSize = vm.config.AcceptedCacheSize
 	vm.ethConfig.TxLookupLimit = vm.config.TxLookupLimit
+	vm.ethConfig.StateHistoryWindow = vm.config.StateHistoryWindow
+	vm.ethConfig.OnlinePruningBloomFilterSize = vm.config.OnlinePruningBloomFilterSize
+	vm.ethConfig.OnlinePruningRate = vm.config.OnlinePruningRate
 
 	// Create directory for offline pruning
 	if len(vm.ethConfig.OfflinePruningDataDirectory) != 0 {
@@ -511,7 +519,10 @@ func (vm *VM) Initialize(
 	vm.codec = Codec
 
 	// TODO: read size from settings
-	vm.mempool = NewMempool(chainCtx.AVAXAssetID, defaultMempoolSize)
+	vm.mempool = NewMempool(chainCtx.AVAXAssetID, defaultMempoolSize, vm.config.AtomicTxPriceBump)
+	if path := vm.atomicTxJournalPath(); path != "" {
+		vm.atomicTxJournal = newAtomicTxJournal(path, vm.codec)
+	}
 
 	if err := vm.initializeMetrics(); err != nil {
 		return err
@@ -521,6 +532,10 @@ func (vm *VM) Initialize(
 	vm.networkCodec = message.Codec
 	vm.Network = peer.NewNetwork(appSender, vm.networkCodec, message.CrossChainCodec, chainCtx.NodeID, vm.config.MaxOutboundActiveRequests, vm.config.MaxOutboundActiveCrossChainRequests)
 	vm.client = peer.NewNetworkClient(vm.Network)
+	vm.rewardNotifier, err = newRewardNotifier(prefixdb.New(rewardNotificationDBPrefix, vm.db), vm.db.Commit, vm.Network)
+	if err != nil {
+		return fmt.Errorf("failed to initialize reward notifier: %w", err)
+	}
 
 	if err := vm.initializeChain(lastAcceptedHash); err != nil {
 		return err
@@ -611,7 +626,9 @@ func (vm *VM) initializeChain(lastAcceptedHash common.Hash) error {
 	if err != nil {
 		return err
 	}
-	vm.eth.SetEtherbase(corethConstants.BlackholeAddr)
+	// The miner uses the blackhole address active at each block, the
+	// etherbase is only reported over RPC.
+	vm.eth.SetEtherbase(vm.chainConfig.CaminoSystemContracts(vm.eth.BlockChain().LastAcceptedBlock().Timestamp()).BlackholeAddress)
 	vm.txPool = vm.eth.TxPool()
 	vm.blockChain = vm.eth.BlockChain()
 	vm.miner = vm.eth.Miner()
@@ -737,7 +754,7 @@ func (vm *VM) preBatchOnFinalizeAndAssemble(header *types.Header, state *state.S
 		rules := vm.chainConfig.CaminoRules(header.Number, new(big.Int).SetUint64(header.Time))
 		if err := vm.verifyTx(tx, header.ParentHash, header.BaseFee, state, &rules); err != nil {
 			// Discard the transaction from the mempool on failed verification.
-			vm.mempool.DiscardCurrentTx(tx.ID())
+			vm.mempool.DiscardCurrentTx(tx.ID(), err)
 			state.RevertToSnapshot(snapshot)
 			continue
 		}
@@ -746,7 +763,7 @@ func (vm *VM) preBatchOnFinalizeAndAssemble(header *types.Header, state *state.S
 		if err != nil {
 			// Discard the transaction from the mempool and error if the transaction
 			// cannot be marshalled. This should never happen.
-			vm.mempool.DiscardCurrentTx(tx.ID())
+			vm.mempool.DiscardCurrentTx(tx.ID(), err)
 			return nil, nil, nil, fmt.Errorf("failed to marshal atomic transaction %s due to %w", tx.ID(), err)
 		}
 		var contribution, gasUsed *big.Int
@@ -817,7 +834,7 @@ func (vm *VM) postBatchOnFinalizeAndAssemble(header *types.Header, state *state.
 			// valid, but we discard it early here based on the assumption that the proposed
 			// block will most likely be accepted.
 			// Discard the transaction from the mempool on failed verification.
-			vm.mempool.DiscardCurrentTx(tx.ID())
+			vm.mempool.DiscardCurrentTx(tx.ID(), errConflictingAtomicInputs)
 			continue
 		}
 
@@ -827,7 +844,7 @@ func (vm *VM) postBatchOnFinalizeAndAssemble(header *types.Header, state *state.
 			// if it fails verification here.
 			// Note: prior to this point, we have not modified [state] so there is no need to
 			// revert to a snapshot if we discard the transaction prior to this point.
-			vm.mempool.DiscardCurrentTx(tx.ID())
+			vm.mempool.DiscardCurrentTx(tx.ID(), err)
 			state.RevertToSnapshot(snapshot)
 			continue
 		}
@@ -908,7 +925,7 @@ func (vm *VM) onExtraStateChange(block *types.Block, state *state.StateDB) (*big
 	}
 
 	for _, tx := range txs {
-		if err := tx.UnsignedAtomicTx.EVMStateTransfer(vm.ctx, state); err != nil {
+		if err := tx.UnsignedAtomicTx.EVMStateTransfer(vm.ctx, state, rules); err != nil {
 			return nil, nil, err
 		}
 		// If ApricotPhase4 is enabled, calculate the block fee contribution
@@ -952,6 +969,10 @@ func (vm *VM) SetState(_ context.Context, state snow.State) error {
 			return fmt.Errorf("%d blocks has been verified", num)
 		}
 		vm.bootstrapped = true
+		vm.startRewardNotifier()
+		if err := vm.startAtomicTxJournal(); err != nil {
+			return err
+		}
 		return vm.fx.Bootstrapped()
 	default:
 		return snow.ErrUnknownState
@@ -1184,6 +1205,10 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]*commonEng.HTTPHandler
 		if err != nil {
 			return nil, fmt.Errorf("failed to register service for admin API due to %w", err)
 		}
+		// The admin only avax calls are served by the admin endpoint
+		if err := adminAPI.Handler.(*avalancheRPC.Server).RegisterService(&AvaxAdminAPI{vm}, "avax"); err != nil {
+			return nil, fmt.Errorf("failed to register service for AVAX admin API due to %w", err)
+		}
 		apis[adminEndpoint] = adminAPI
 		enabledAPIs = append(enabledAPIs, "coreth-admin")
 	}
@@ -1195,6 +1220,13 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]*commonEng.HTTPHandler
 		enabledAPIs = append(enabledAPIs, "snowman")
 	}
 
+	if vm.config.CaminoAPIEnabled {
+		if err := handler.RegisterName("camino", ethadmin.NewAPI(vm.eth.APIBackend, vm.eth.AdminController())); err != nil {
+			return nil, err
+		}
+		enabledAPIs = append(enabledAPIs, "camino")
+	}
+
 	log.Info(fmt.Sprintf("Enabled APIs: %s", strings.Join(enabledAPIs, ", ")))
 	apis[ethRPCEndpoint] = &commonEng.HTTPHandler{
 		LockOptions: commonEng.NoLock,
@@ -1325,7 +1357,7 @@ func (vm *VM) issueTx(tx *Tx, local bool) error {
 			// unlike local txs, invalid remote txs are recorded as discarded
 			// so that they won't be requested again
 			txID := tx.ID()
-			vm.mempool.discardedTxs.Put(txID, tx)
+			vm.mempool.DiscardTx(tx, err)
 			log.Debug("failed to verify remote tx being issued to the mempool",
 				"txID", txID,
 				"err", err,
@@ -1340,7 +1372,7 @@ func (vm *VM) issueTx(tx *Tx, local bool) error {
 			// unlike local txs, invalid remote txs are recorded as discarded
 			// so that they won't be requested again
 			txID := tx.ID()
-			vm.mempool.discardedTxs.Put(tx.ID(), tx)
+			vm.mempool.DiscardTx(tx, err)
 			log.Debug("failed to issue remote tx to mempool",
 				"txID", txID,
 				"err", err,
@@ -1349,6 +1381,9 @@ func (vm *VM) issueTx(tx *Tx, local bool) error {
 		}
 		return err
 	}
+	if local {
+		vm.journalAtomicTx(tx)
+	}
 	// NOTE: Gossiping of the issued [Tx] is handled in [AddTx]
 	return nil
 }
@@ -1395,7 +1430,7 @@ func (vm *VM) verifyTx(tx *Tx, parentHash common.Hash, baseFee *big.Int, state *
 	if err := tx.UnsignedAtomicTx.SemanticVerify(vm, tx, parent, baseFee, *rules); err != nil {
 		return err
 	}
-	return tx.UnsignedAtomicTx.EVMStateTransfer(vm.ctx, state)
+	return tx.UnsignedAtomicTx.EVMStateTransfer(vm.ctx, state, *rules)
 }
 
 // verifyTxs verifies that [txs] are valid to be issued into a block with parent block [parentHash]
@@ -1490,7 +1525,8 @@ func (vm *VM) GetAtomicUTXOs(
 }
 
 // GetSpendableFunds returns a list of EVMInputs and keys (in corresponding
-// order) to total [amount] of [assetID] owned by [keys].
+// order) to total [amount] of [assetID] owned by [keys]. The keys whose funds
+// are spent are selected with [selection].
 // Note: we return [][]*secp256k1.PrivateKey even though each input
 // corresponds to a single key, so that the signers can be passed in to
 // [tx.Sign] which supports multiple keys on a single input.
@@ -1498,29 +1534,40 @@ func (vm *VM) GetSpendableFunds(
 	keys []*secp256k1.PrivateKey,
 	assetID ids.ID,
 	amount uint64,
+	selection CoinSelection,
 ) ([]EVMInput, [][]*secp256k1.PrivateKey, error) {
+	inputs, err := vm.getSpendableFunds(ethAddresses(keys), assetID, amount, selection)
+	if err != nil {
+		return nil, nil, err
+	}
+	return inputs, evmInputSigners(inputs, keys), nil
+}
+
+// getSpendableFunds returns a list of EVMInputs to total [amount] of [assetID]
+// owned by [addrs], which are selected with [selection].
+func (vm *VM) getSpendableFunds(
+	addrs []common.Address,
+	assetID ids.ID,
+	amount uint64,
+	selection CoinSelection,
+) ([]EVMInput, error) {
 	// Note: current state uses the state of the preferred block.
 	state, err := vm.blockChain.State()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+	addrs, err = vm.selectAddresses(state, addrs, assetID, selection)
+	if err != nil {
+		return nil, err
 	}
 	inputs := []EVMInput{}
-	signers := [][]*secp256k1.PrivateKey{}
-	// Note: we assume that each key in [keys] is unique, so that iterating over
-	// the keys will not produce duplicated nonces in the returned EVMInput slice.
-	for _, key := range keys {
+	// Note: we assume that each address in [addrs] is unique, so that iterating over
+	// the addresses will not produce duplicated nonces in the returned EVMInput slice.
+	for _, addr := range addrs {
 		if amount == 0 {
 			break
 		}
-		addr := GetEthAddress(key)
-		var balance uint64
-		if assetID == vm.ctx.AVAXAssetID {
-			// If the asset is AVAX, we divide by the x2cRate to convert back to the correct
-			// denomination of AVAX that can be exported.
-			balance = new(big.Int).Div(state.GetBalance(addr), x2cRate).Uint64()
-		} else {
-			balance = state.GetBalanceMultiCoin(addr, common.Hash(assetID)).Uint64()
-		}
+		balance := vm.spendableBalance(state, addr, assetID)
 		if balance == 0 {
 			continue
 		}
@@ -1529,7 +1576,7 @@ func (vm *VM) GetSpendableFunds(
 		}
 		nonce, err := vm.GetCurrentNonce(addr)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		inputs = append(inputs, EVMInput{
 			Address: addr,
@@ -1537,19 +1584,19 @@ func (vm *VM) GetSpendableFunds(
 			AssetID: assetID,
 			Nonce:   nonce,
 		})
-		signers = append(signers, []*secp256k1.PrivateKey{key})
 		amount -= balance
 	}
 
 	if amount > 0 {
-		return nil, nil, errInsufficientFunds
+		return nil, errInsufficientFunds
 	}
 
-	return inputs, signers, nil
+	return inputs, nil
 }
 
 // GetSpendableAVAXWithFee returns a list of EVMInputs and keys (in corresponding
-// order) to total [amount] + [fee] of [AVAX] owned by [keys].
+// order) to total [amount] + [fee] of [AVAX] owned by [keys], which are
+// selected with [selection].
 // This function accounts for the added cost of the additional inputs needed to
 // create the transaction and makes sure to skip any keys with a balance that is
 // insufficient to cover the additional fee.
@@ -1561,50 +1608,68 @@ func (vm *VM) GetSpendableAVAXWithFee(
 	amount uint64,
 	cost uint64,
 	baseFee *big.Int,
+	selection CoinSelection,
 ) ([]EVMInput, [][]*secp256k1.PrivateKey, error) {
+	inputs, err := vm.getSpendableAVAXWithFee(ethAddresses(keys), amount, cost, baseFee, selection)
+	if err != nil {
+		return nil, nil, err
+	}
+	return inputs, evmInputSigners(inputs, keys), nil
+}
+
+// getSpendableAVAXWithFee returns a list of EVMInputs to total [amount] + [fee]
+// of [AVAX] owned by [addrs], which are selected with [selection].
+// See GetSpendableAVAXWithFee.
+func (vm *VM) getSpendableAVAXWithFee(
+	addrs []common.Address,
+	amount uint64,
+	cost uint64,
+	baseFee *big.Int,
+	selection CoinSelection,
+) ([]EVMInput, error) {
 	// Note: current state uses the state of the preferred block.
 	state, err := vm.blockChain.State()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+	addrs, err = vm.selectAddresses(state, addrs, vm.ctx.AVAXAssetID, selection)
+	if err != nil {
+		return nil, err
 	}
 
 	initialFee, err := calculateDynamicFee(cost, baseFee)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	newAmount, err := math.Add64(amount, initialFee)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	amount = newAmount
 
 	inputs := []EVMInput{}
-	signers := [][]*secp256k1.PrivateKey{}
-	// Note: we assume that each key in [keys] is unique, so that iterating over
-	// the keys will not produce duplicated nonces in the returned EVMInput slice.
-	for _, key := range keys {
+	// Note: we assume that each address in [addrs] is unique, so that iterating over
+	// the addresses will not produce duplicated nonces in the returned EVMInput slice.
+	for _, addr := range addrs {
 		if amount == 0 {
 			break
 		}
 
 		prevFee, err := calculateDynamicFee(cost, baseFee)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
 		newCost := cost + EVMInputGas
 		newFee, err := calculateDynamicFee(newCost, baseFee)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
 		additionalFee := newFee - prevFee
 
-		addr := GetEthAddress(key)
-		// Since the asset is AVAX, we divide by the x2cRate to convert back to
-		// the correct denomination of AVAX that can be exported.
-		balance := new(big.Int).Div(state.GetBalance(addr), x2cRate).Uint64()
+		balance := vm.spendableBalance(state, addr, vm.ctx.AVAXAssetID)
 		// If the balance for [addr] is insufficient to cover the additional cost
 		// of adding an input to the transaction, skip adding the input altogether
 		if balance <= additionalFee {
@@ -1616,7 +1681,7 @@ func (vm *VM) GetSpendableAVAXWithFee(
 
 		newAmount, err := math.Add64(amount, additionalFee)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		amount = newAmount
 
@@ -1629,7 +1694,7 @@ func (vm *VM) GetSpendableAVAXWithFee(
 		}
 		nonce, err := vm.GetCurrentNonce(addr)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		inputs = append(inputs, EVMInput{
 			Address: addr,
@@ -1637,15 +1702,14 @@ func (vm *VM) GetSpendableAVAXWithFee(
 			AssetID: vm.ctx.AVAXAssetID,
 			Nonce:   nonce,
 		})
-		signers = append(signers, []*secp256k1.PrivateKey{key})
 		amount -= inputAmount
 	}
 
 	if amount > 0 {
-		return nil, nil, errInsufficientFunds
+		return nil, errInsufficientFunds
 	}
 
-	return inputs, signers, nil
+	return inputs, nil
 }
 
 // GetCurrentNonce returns the nonce associated with the address at the
@@ -0,0 +1,204 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompile
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// KycAdminConfigKey is the key of the KYC admin precompile in precompileUpgrades
+	KycAdminConfigKey = "kycAdminConfig"
+
+	// Gas costs of the KYC admin precompile functions. They cover the cold
+	// storage reads and the slot hashing of each function. From Sunrise
+	// Phase 2 on baseFee reads the scheduled base fee as well and costs
+	// BaseFeeScheduledGasCost.
+	KycStateGasCost         uint64 = 2_200
+	BaseFeeGasCost          uint64 = 2_100
+	BaseFeeScheduledGasCost uint64 = 6_300
+	IsBlacklistedGasCost    uint64 = 4_400
+
+	blacklisted = 1
+)
+
+var (
+	_ StatefulPrecompileConfig = &KycAdminConfig{}
+
+	errInvalidKycAdminInput = errors.New("invalid input to KYC admin precompile")
+
+	kycAdminPrecompile = newStatefulPrecompileWithFunctionSelectors(nil, []*statefulPrecompileFunction{
+		newStatefulPrecompileFunction(CalculateFunctionSelector("kycState(address)"), kycState),
+		newStatefulPrecompileFunction(CalculateFunctionSelector("baseFee()"), baseFee),
+		newStatefulPrecompileFunction(CalculateFunctionSelector("isBlacklisted(address,bytes4)"), isBlacklisted),
+	})
+)
+
+func init() {
+	if err := RegisterModule(Module{
+		ConfigKey: KycAdminConfigKey,
+		Address:   KycAdminAddress,
+		NewConfig: func() StatefulPrecompileConfig { return &KycAdminConfig{} },
+	}); err != nil {
+		panic(err)
+	}
+}
+
+// AdminState locates the state of the Camino admin contract which is read by
+// the KYC admin precompile
+type AdminState struct {
+	// Address of the admin contract proxy
+	Address common.Address
+	// BaseFeeSlot stores the fixed base fee (uint256)
+	BaseFeeSlot common.Hash
+	// KycSlot stores the KYC state mapping (address => uint256)
+	KycSlot common.Hash
+	// BlacklistSlot stores the blacklist mapping (uint256 => uint256)
+	BlacklistSlot common.Hash
+	// DefaultBaseFee applies if no base fee is set in the admin contract
+	DefaultBaseFee *big.Int
+	// PendingBaseFeeSlot stores the scheduled fixed base fee (uint256)
+	PendingBaseFeeSlot common.Hash
+	// PendingBaseFeeTimeSlot stores the activation timestamp of the scheduled
+	// fixed base fee (uint256, 0 = none scheduled)
+	PendingBaseFeeTimeSlot common.Hash
+}
+
+// KycAdminConfig enables or disables the KYC admin precompile, which offers
+// read access to the KYC state, the fixed base fee and the blacklist of the
+// admin contract:
+//
+//	function kycState(address account) external view returns (uint256);
+//	function baseFee() external view returns (uint256);
+//	function isBlacklisted(address account, bytes4 signature) external view returns (bool);
+type KycAdminConfig struct {
+	UpgradeableConfig
+}
+
+// Address returns the address of the KYC admin precompile
+func (c *KycAdminConfig) Address() common.Address {
+	return KycAdminAddress
+}
+
+// Equal returns true if [other] is a KycAdminConfig with the same upgrade
+func (c *KycAdminConfig) Equal(other StatefulPrecompileConfig) bool {
+	o, ok := other.(*KycAdminConfig)
+	return ok && c.UpgradeableConfig.Equal(&o.UpgradeableConfig)
+}
+
+// Verify returns nil, the config has no parameters besides the upgrade
+func (c *KycAdminConfig) Verify() error {
+	return nil
+}
+
+// Configure does nothing, the precompile keeps no state of its own
+func (c *KycAdminConfig) Configure(ChainConfig, StateDB, BlockContext) {}
+
+// Contract returns the KYC admin precompile
+func (c *KycAdminConfig) Contract() StatefulPrecompiledContract {
+	return kycAdminPrecompile
+}
+
+// kycState returns the KYC state bitmask of the address in [input]
+func kycState(accessibleState PrecompileAccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = deductGas(suppliedGas, KycStateGasCost); err != nil {
+		return nil, 0, err
+	}
+	account, err := unpackAddress(input, 1)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	admin := accessibleState.GetChainConfig().CaminoAdminState(accessibleState.GetBlockContext().Timestamp())
+	storagePos := crypto.Keccak256Hash(account.Hash().Bytes(), admin.KycSlot.Bytes())
+	state := accessibleState.GetStateDB().GetState(admin.Address, storagePos)
+	return state.Bytes(), remainingGas, nil
+}
+
+// baseFee returns the fixed base fee set in the admin contract or, as of
+// Sunrise Phase 2, the scheduled fixed base fee once it is activated
+func baseFee(accessibleState PrecompileAccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	timestamp := accessibleState.GetBlockContext().Timestamp()
+	scheduled := accessibleState.GetChainConfig().IsSunrisePhase2(timestamp)
+	gasCost := BaseFeeGasCost
+	if scheduled {
+		gasCost = BaseFeeScheduledGasCost
+	}
+	if remainingGas, err = deductGas(suppliedGas, gasCost); err != nil {
+		return nil, 0, err
+	}
+	if len(input) != 0 {
+		return nil, remainingGas, fmt.Errorf("%w: baseFee takes no arguments", errInvalidKycAdminInput)
+	}
+	admin := accessibleState.GetChainConfig().CaminoAdminState(timestamp)
+	state := accessibleState.GetStateDB()
+	fee := state.GetState(admin.Address, admin.BaseFeeSlot)
+	if scheduled {
+		if activation := state.GetState(admin.Address, admin.PendingBaseFeeTimeSlot).Big(); activation.Sign() != 0 && timestamp.Cmp(activation) >= 0 {
+			fee = state.GetState(admin.Address, admin.PendingBaseFeeSlot)
+		}
+	}
+	if fee == (common.Hash{}) && admin.DefaultBaseFee != nil {
+		fee = common.BigToHash(admin.DefaultBaseFee)
+	}
+	return fee.Bytes(), remainingGas, nil
+}
+
+// isBlacklisted returns true if calls of the function signature in [input]
+// into the address in [input] are rejected, either by the entry of the
+// signature or by the entry of the address without signature. Like in the
+// EVM, entries only apply as of Sunrise Phase 2 and never to the admin
+// contract.
+func isBlacklisted(accessibleState PrecompileAccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = deductGas(suppliedGas, IsBlacklistedGasCost); err != nil {
+		return nil, 0, err
+	}
+	account, err := unpackAddress(input, 2)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	// bytes4 is left aligned in its word
+	var signature [4]byte
+	copy(signature[:], input[common.HashLength:])
+	if (common.BytesToHash(input[common.HashLength+len(signature):]) != common.Hash{}) {
+		return nil, remainingGas, fmt.Errorf("%w: signature is not a bytes4", errInvalidKycAdminInput)
+	}
+
+	timestamp := accessibleState.GetBlockContext().Timestamp()
+	admin := accessibleState.GetChainConfig().CaminoAdminState(timestamp)
+	state := accessibleState.GetStateDB()
+	result := accessibleState.GetChainConfig().IsSunrisePhase2(timestamp) && account != admin.Address &&
+		(blacklistState(admin, state, account, [4]byte{})&blacklisted != 0 ||
+			blacklistState(admin, state, account, signature)&blacklisted != 0)
+	if result {
+		return common.BigToHash(common.Big1).Bytes(), remainingGas, nil
+	}
+	return common.Hash{}.Bytes(), remainingGas, nil
+}
+
+// blacklistState reads the blacklist state of (account, signature) which is
+// stored with the key uint256(account) | uint256(signature) << 160
+func blacklistState(admin AdminState, state StateDB, account common.Address, signature [4]byte) uint64 {
+	var key common.Hash
+	copy(key[common.HashLength-common.AddressLength-len(signature):], signature[:])
+	copy(key[common.HashLength-common.AddressLength:], account[:])
+	storagePos := crypto.Keccak256Hash(key.Bytes(), admin.BlacklistSlot.Bytes())
+	return state.GetState(admin.Address, storagePos).Big().Uint64()
+}
+
+// unpackAddress checks that [input] consists of [words] ABI encoded words and
+// returns the address encoded in the first one
+func unpackAddress(input []byte, words int) (common.Address, error) {
+	if len(input) != words*common.HashLength {
+		return common.Address{}, fmt.Errorf("%w: expected %d bytes, got %d", errInvalidKycAdminInput, words*common.HashLength, len(input))
+	}
+	if (common.BytesToAddress(input[:common.HashLength-common.AddressLength]) != common.Address{}) {
+		return common.Address{}, fmt.Errorf("%w: dirty address padding", errInvalidKycAdminInput)
+	}
+	return common.BytesToAddress(input[common.HashLength-common.AddressLength : common.HashLength]), nil
+}
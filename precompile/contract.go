@@ -20,6 +20,7 @@ type RunStatefulPrecompileFunc func(accessibleState PrecompileAccessibleState, c
 type PrecompileAccessibleState interface {
 	GetStateDB() StateDB
 	GetBlockContext() BlockContext
+	GetChainConfig() ChainConfig
 	NativeAssetCall(caller common.Address, input []byte, suppliedGas uint64, gasGost uint64, readOnly bool) (ret []byte, remainingGas uint64, err error)
 }
 
@@ -35,8 +36,12 @@ type BlockContext interface {
 // about the chain configuration. The precompile can access this information to initialize
 // its state.
 type ChainConfig interface {
-	// Note: None of the existing stateful precompiles currently access chain config information
-	// in Configure so this interface is empty.
+	// CaminoAdminState returns the location of the admin contract state
+	// active at [blockTimestamp]
+	CaminoAdminState(blockTimestamp *big.Int) AdminState
+	// IsSunrisePhase2 returns true if [blockTimestamp] is after the Sunrise
+	// Phase 2 upgrade
+	IsSunrisePhase2(blockTimestamp *big.Int) bool
 }
 
 // StateDB is the interface for accessing EVM state
@@ -59,6 +64,14 @@ type StateDB interface {
 
 	CreateAccount(common.Address)
 	Exist(common.Address) bool
+	Suicide(common.Address) bool
+}
+
+// UpgradeStateDB is the interface for accessing the EVM state while applying
+// precompile upgrades
+type UpgradeStateDB interface {
+	StateDB
+	Finalise(deleteEmptyObjects bool)
 }
 
 // StatefulPrecompiledContract is the interface for executing a precompiled contract
@@ -77,7 +90,6 @@ type statefulPrecompileFunction struct {
 }
 
 // newStatefulPrecompileFunction creates a stateful precompile function with the given arguments
-//nolint:unused,deadcode
 func newStatefulPrecompileFunction(selector []byte, execute RunStatefulPrecompileFunc) *statefulPrecompileFunction {
 	return &statefulPrecompileFunction{
 		selector: selector,
@@ -95,7 +107,6 @@ type statefulPrecompileWithFunctionSelectors struct {
 
 // newStatefulPrecompileWithFunctionSelectors generates new StatefulPrecompile using [functions] as the available functions and [fallback]
 // as an optional fallback if there is no input data. Note: the selector of [fallback] will be ignored, so it is required to be left empty.
-//nolint:unused,deadcode
 func newStatefulPrecompileWithFunctionSelectors(fallback *statefulPrecompileFunction, functions []*statefulPrecompileFunction) StatefulPrecompiledContract {
 	// Ensure that if a fallback is present, it does not have a mistakenly populated function selector.
 	if fallback != nil && len(fallback.selector) != 0 {
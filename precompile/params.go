@@ -33,8 +33,18 @@ func (a *AddressRange) Contains(addr common.Address) bool {
 var (
 	UsedAddresses = []common.Address{
 		// precompile contract addresses can be added here
+		KycAdminAddress,
 	}
 
+	// KycAdminAddress is the address of the KYC admin precompile
+	KycAdminAddress = common.HexToAddress("0x0100000000000000000000000000000000000003")
+
+	// NativeAssetBalanceAddress and NativeAssetCallAddress are the fixed addresses of
+	// the native asset precompiles implemented in core/vm. They cannot be used by
+	// registered precompile modules.
+	NativeAssetBalanceAddress = common.HexToAddress("0x0100000000000000000000000000000000000001")
+	NativeAssetCallAddress    = common.HexToAddress("0x0100000000000000000000000000000000000002")
+
 	// ReservedRanges contains addresses ranges that are reserved
 	// for precompiles and cannot be used as EOA or deployed contracts.
 	ReservedRanges = []AddressRange{
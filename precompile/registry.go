@@ -0,0 +1,107 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	errModuleNoConfigKey = errors.New("precompile module has no config key")
+	errModuleNoConfig    = errors.New("precompile module has no config constructor")
+
+	// registeredModules is sorted by address
+	registeredModules []Module
+)
+
+// Module is a stateful precompile which can be enabled and disabled through the
+// precompileUpgrades of the chain config. Modules register themselves from the
+// init function of their package with RegisterModule.
+type Module struct {
+	// ConfigKey identifies the module in the JSON encoding of a precompile upgrade
+	ConfigKey string
+	// Address is the address the precompile is accessible at
+	Address common.Address
+	// NewConfig returns an empty config of the module to decode upgrades into
+	NewConfig func() StatefulPrecompileConfig
+}
+
+// RegisterModule adds [module] to the registered precompile modules. The address
+// of [module] must be within a reserved range and must neither collide with the
+// native asset precompiles nor with another module.
+func RegisterModule(module Module) error {
+	switch {
+	case module.ConfigKey == "":
+		return errModuleNoConfigKey
+	case module.NewConfig == nil:
+		return fmt.Errorf("%w: %s", errModuleNoConfig, module.ConfigKey)
+	case IsNativeAddress(module.Address):
+		return fmt.Errorf("precompile module %s collides with native address %s", module.ConfigKey, module.Address)
+	case !IsReservedAddress(module.Address):
+		return fmt.Errorf("precompile module %s address %s is not in any reserved range", module.ConfigKey, module.Address)
+	}
+	for _, registered := range registeredModules {
+		if registered.ConfigKey == module.ConfigKey {
+			return fmt.Errorf("precompile module config key %s already registered", module.ConfigKey)
+		}
+		if registered.Address == module.Address {
+			return fmt.Errorf("precompile module %s address %s already used by %s",
+				module.ConfigKey, module.Address, registered.ConfigKey)
+		}
+	}
+
+	registeredModules = append(registeredModules, module)
+	sort.Slice(registeredModules, func(i, j int) bool {
+		return bytes.Compare(registeredModules[i].Address[:], registeredModules[j].Address[:]) < 0
+	})
+	return nil
+}
+
+// GetModule returns the registered module with [configKey]
+func GetModule(configKey string) (Module, bool) {
+	for _, module := range registeredModules {
+		if module.ConfigKey == configKey {
+			return module, true
+		}
+	}
+	return Module{}, false
+}
+
+// GetModuleByAddress returns the registered module at [address]
+func GetModuleByAddress(address common.Address) (Module, bool) {
+	for _, module := range registeredModules {
+		if module.Address == address {
+			return module, true
+		}
+	}
+	return Module{}, false
+}
+
+// RegisteredModules returns the registered modules sorted by address
+func RegisteredModules() []Module {
+	return registeredModules
+}
+
+// IsNativeAddress returns true if [address] is used by a precompile which
+// is built into core/vm. The blackhole address is configured in the chain
+// config, which checks that it does not collide with a registered module.
+func IsNativeAddress(address common.Address) bool {
+	return address == NativeAssetBalanceAddress ||
+		address == NativeAssetCallAddress
+}
+
+// IsReservedAddress returns true if [address] is within a reserved range
+func IsReservedAddress(address common.Address) bool {
+	for _, reservedRange := range ReservedRanges {
+		if reservedRange.Contains(address) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package precompile
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterModule(t *testing.T) {
+	defer func(modules []Module) { registeredModules = modules }(registeredModules)
+
+	registered := len(registeredModules)
+	newConfig := func() StatefulPrecompileConfig { return nil }
+	module := Module{
+		ConfigKey: "testConfig",
+		Address:   common.HexToAddress("0x0100000000000000000000000000000000000020"),
+		NewConfig: newConfig,
+	}
+	require.NoError(t, RegisterModule(module))
+
+	got, ok := GetModule("testConfig")
+	require.True(t, ok)
+	require.Equal(t, module.Address, got.Address)
+	_, ok = GetModuleByAddress(module.Address)
+	require.True(t, ok)
+
+	tests := map[string]Module{
+		"duplicated key":     {ConfigKey: "testConfig", Address: common.HexToAddress("0x0100000000000000000000000000000000000021"), NewConfig: newConfig},
+		"duplicated address": {ConfigKey: "otherConfig", Address: module.Address, NewConfig: newConfig},
+		"native asset call":  {ConfigKey: "otherConfig", Address: NativeAssetCallAddress, NewConfig: newConfig},
+		"not reserved":       {ConfigKey: "otherConfig", Address: common.HexToAddress("0x0200000000000000000000000000000000000000"), NewConfig: newConfig},
+		"no config":          {ConfigKey: "otherConfig", Address: common.HexToAddress("0x0100000000000000000000000000000000000021")},
+		"no key":             {Address: common.HexToAddress("0x0100000000000000000000000000000000000021"), NewConfig: newConfig},
+	}
+	for name, module := range tests {
+		require.Error(t, RegisterModule(module), name)
+	}
+	require.Len(t, RegisteredModules(), registered+1)
+}
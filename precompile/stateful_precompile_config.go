@@ -20,6 +20,12 @@ type StatefulPrecompileConfig interface {
 	// 2) n indicates that the precompile should be enabled in the first block with timestamp >= [n].
 	// 3) nil indicates that the precompile is never enabled.
 	Timestamp() *big.Int
+	// IsDisabled returns true if this upgrade disables the stateful precompile.
+	IsDisabled() bool
+	// Equal returns true if [other] is the same upgrade as this config.
+	Equal(other StatefulPrecompileConfig) bool
+	// Verify returns an error if this config is invalid.
+	Verify() error
 	// Configure is called on the first block where the stateful precompile should be enabled.
 	// This allows the stateful precompile to configure its own state via [StateDB] as necessary.
 	// This function must be deterministic since it will impact the EVM state. If a change to the
@@ -40,13 +46,19 @@ type StatefulPrecompileConfig interface {
 // If it does, then it calls Configure on [precompileConfig] to make the necessary state update to enable the StatefulPrecompile.
 // Note: this function is called within genesis to configure the starting state if [precompileConfig] specifies that it should be
 // configured at genesis, or happens during block processing to update the state before processing the given block.
-// TODO: add ability to call Configure at different timestamps, so that developers can easily re-configure by updating the
-// stateful precompile config.
+// If [precompileConfig] disables the precompile, its account is removed instead.
 // Assumes that [config] is non-nil.
-func CheckConfigure(chainConfig ChainConfig, parentTimestamp *big.Int, blockContext BlockContext, precompileConfig StatefulPrecompileConfig, state StateDB) {
+func CheckConfigure(chainConfig ChainConfig, parentTimestamp *big.Int, blockContext BlockContext, precompileConfig StatefulPrecompileConfig, state UpgradeStateDB) {
 	forkTimestamp := precompileConfig.Timestamp()
 	// If the network upgrade goes into effect within this transition, configure the stateful precompile
 	if utils.IsForkTransition(forkTimestamp, parentTimestamp, blockContext.Timestamp()) {
+		if precompileConfig.IsDisabled() {
+			// Remove the precompile's account including its storage. Finalise is called so that
+			// the deletion is applied before a later upgrade in this transition enables it again.
+			state.Suicide(precompileConfig.Address())
+			state.Finalise(true)
+			return
+		}
 		// Set the nonce of the precompile's address (as is done when a contract is created) to ensure
 		// that it is marked as non-empty and will not be cleaned up when the statedb is finalized.
 		state.SetNonce(precompileConfig.Address(), 1)
@@ -57,3 +69,34 @@ func CheckConfigure(chainConfig ChainConfig, parentTimestamp *big.Int, blockCont
 		precompileConfig.Configure(chainConfig, state, blockContext)
 	}
 }
+
+// UpgradeableConfig contains the fields shared by all stateful precompile configs.
+// It can be embedded in a config to implement Timestamp, IsDisabled and part of Equal.
+type UpgradeableConfig struct {
+	BlockTimestamp *big.Int `json:"blockTimestamp"`
+	Disable        bool     `json:"disable,omitempty"`
+}
+
+// Timestamp returns the timestamp at which the upgrade takes effect
+func (c *UpgradeableConfig) Timestamp() *big.Int {
+	return c.BlockTimestamp
+}
+
+// IsDisabled returns true if the upgrade disables the precompile
+func (c *UpgradeableConfig) IsDisabled() bool {
+	return c.Disable
+}
+
+// Equal returns true if [other] has the same timestamp and disable flag
+func (c *UpgradeableConfig) Equal(other *UpgradeableConfig) bool {
+	if other == nil {
+		return false
+	}
+	if c.Disable != other.Disable {
+		return false
+	}
+	if c.BlockTimestamp == nil || other.BlockTimestamp == nil {
+		return c.BlockTimestamp == other.BlockTimestamp
+	}
+	return c.BlockTimestamp.Cmp(other.BlockTimestamp) == 0
+}
@@ -25,7 +25,6 @@ func CalculateFunctionSelector(functionSignature string) []byte {
 }
 
 // deductGas checks if [suppliedGas] is sufficient against [requiredGas] and deducts [requiredGas] from [suppliedGas].
-//nolint:unused,deadcode
 func deductGas(suppliedGas uint64, requiredGas uint64) (uint64, error) {
 	if suppliedGas < requiredGas {
 		return 0, vmerrs.ErrOutOfGas
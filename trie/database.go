@@ -452,6 +452,19 @@ func (db *Database) Nodes() []common.Hash {
 	return hashes
 }
 
+// Roots retrieves the hashes of the tries referenced from the metaroot, which
+// are the roots kept in memory.
+func (db *Database) Roots() []common.Hash {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	var roots = make([]common.Hash, 0, len(db.dirties[common.Hash{}].children))
+	for hash := range db.dirties[common.Hash{}].children {
+		roots = append(roots, hash)
+	}
+	return roots
+}
+
 // Reference adds a new reference from a parent node to a child node.
 // This function is used to add reference between internal trie node
 // and external node(e.g. storage trie root), all internal trie nodes
@@ -9,4 +9,5 @@ import (
 // List evm execution errors
 var (
 	ErrNotKycVerified = errors.New("not KYC verified")
+	ErrBlacklisted    = errors.New("address or function blacklisted")
 )